@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"grind/internal/auth"
+	"grind/internal/i18n"
+	"grind/internal/tui"
+)
+
+var abandonCmd = &cobra.Command{
+	Use:   "abandon <quest-number | title>",
+	Short: "Drop a quest without deleting it",
+	Long: `Abandon a quest you've decided not to do.
+
+Unlike deleting, the quest stays in your history (and your crew's feed)
+marked as dropped. It earns no XP and no longer counts toward potential XP.
+
+Examples:
+  grind abandon 3         # Drop quest #3
+  grind abandon taxes     # Drop the quest with "taxes" in its title`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runAbandon,
+}
+
+func runAbandon(cmd *cobra.Command, args []string) error {
+	cfg, err := auth.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if !cfg.IsLoggedIn() {
+		fmt.Println(tui.ErrorStyle.Render(i18n.T("cmd.notLoggedIn")))
+		return nil
+	}
+
+	client := clientFor(cfg)
+	quest, err := loadQuest(cmd.Context(), client, cfg, questArg(args), isUnfinished)
+	if err != nil {
+		fmt.Println(tui.ErrorStyle.Render(err.Error()))
+		return nil
+	}
+
+	if !quest.IsOpen() {
+		fmt.Println(tui.ErrorStyle.Render("Quest already " + quest.Status + "."))
+		return nil
+	}
+
+	ctx, cancel := requestContext(cmd.Context(), 10*time.Second)
+	defer cancel()
+
+	if err := client.AbandonQuest(ctx, quest.ID); err != nil {
+		fmt.Println(tui.ErrorStyle.Render("Failed to abandon quest: " + err.Error()))
+		return nil
+	}
+
+	fmt.Println(tui.MutedStyle.Render("dropped: ") + quest.Title)
+	return nil
+}
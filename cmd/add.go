@@ -2,16 +2,22 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
 	"strings"
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/term"
 	"github.com/spf13/cobra"
 
 	"grind/internal/api"
 	"grind/internal/auth"
+	"grind/internal/evaluator"
+	"grind/internal/i18n"
 	"grind/internal/tui"
+	"grind/internal/tui/components"
 )
 
 var addCmd = &cobra.Command{
@@ -26,11 +32,29 @@ The AI will assess difficulty and assign points based on:
 Examples:
   grind add "ship landing page"
   grind add "fix auth bug, refactor tests"
-  grind add "gym session"`,
-	Args: cobra.MinimumNArgs(1),
+  grind add "gym session"
+  grind add "fix auth bug" --note "see ticket #42"
+  grind add "gym session" --xp 40     # Skip the AI and set XP yourself
+  grind add --template morning        # Add every quest in a saved template
+  grind add "gym session" --yes       # Save without asking
+  grind add "launch site" --subtask "copy" --subtask "deploy"
+                                      # Split it into sub-tasks
+
+On a terminal, the AI's XP and reasoning are shown before the quest is
+saved: press enter to add it, n to drop it, or e to change the title and
+have it re-evaluated.`,
+	Args: cobra.ArbitraryArgs,
 	RunE: runAdd,
 }
 
+var (
+	addNote     string
+	addXP       int
+	addTemplate string
+	addYes      bool
+	addSubtasks []string
+)
+
 func runAdd(cmd *cobra.Command, args []string) error {
 	cfg, err := auth.Load()
 	if err != nil {
@@ -38,171 +62,237 @@ func runAdd(cmd *cobra.Command, args []string) error {
 	}
 
 	if !cfg.IsLoggedIn() {
-		fmt.Println(tui.ErrorStyle.Render("Not logged in. Run 'grind' to set up."))
+		fmt.Println(tui.ErrorStyle.Render(i18n.T("cmd.notLoggedIn")))
 		return nil
 	}
 
+	if addTemplate != "" {
+		if len(args) > 0 {
+			return fmt.Errorf("--template doesn't take a quest title")
+		}
+		return runAddTemplate(cmd, cfg, addTemplate)
+	}
+	if len(args) == 0 {
+		return fmt.Errorf("give a quest to add, or --template <name>")
+	}
+
 	title := strings.Join(args, " ")
+	note := strings.TrimSpace(addNote)
+	manual := cmd.Flags().Changed("xp")
+
+	// Only the AI's scoring is worth a second look, and only when someone
+	// is there to answer
+	review := !manual && !addYes && term.IsTerminal(os.Stdin.Fd()) && term.IsTerminal(os.Stdout.Fd())
+
+	var xp int
+	var reasoning string
+	for {
+		if manual {
+			// Manual XP skips the AI entirely
+			if xp, err = manualXP(); err != nil {
+				return err
+			}
+			reasoning = api.ManualXPReasoning
+		} else {
+			// Show spinner
+			fmt.Print(tui.MutedStyle.Render("  " + components.Glyphs.Spinner + " evaluating with AI..."))
 
-	// Show spinner
-	fmt.Print(tui.MutedStyle.Render("  ⠋ evaluating with AI..."))
+			// Call Convex AI action to evaluate XP
+			xp, reasoning, err = evaluateQuestWithAI(cmd.Context(), cfg, title)
+		}
+		if err != nil {
+			// Clear spinner and show error
+			fmt.Print("\r\033[K")
+			if errors.Is(err, context.Canceled) {
+				fmt.Println(tui.MutedStyle.Render("cancelled."))
+				return nil
+			}
+			fmt.Println(tui.ErrorStyle.Render("AI evaluation failed: " + err.Error()))
+			return nil
+		}
+		if !review {
+			break
+		}
 
-	// Call Convex AI action to evaluate XP
-	xp, reasoning, err := evaluateQuestWithAI(cfg, title)
-	if err != nil {
-		// Clear spinner and show error
+		// Show the AI's take and let the user accept, drop, or reword it
 		fmt.Print("\r\033[K")
-		fmt.Println(tui.ErrorStyle.Render("AI evaluation failed: " + err.Error()))
-		return nil
+		fmt.Println(renderQuestPreview(title, note, xp, reasoning, addSubtasks))
+		answer, ok := askLine(cmd.Context(), "add this quest? [Y/n/e to edit] ")
+		switch strings.ToLower(answer) {
+		case "", "y", "yes":
+			// Save it below
+		case "e", "edit":
+			if newTitle, ok := askLine(cmd.Context(), "title: "); ok && newTitle != "" {
+				title = newTitle
+			}
+			continue
+		default:
+			ok = false
+		}
+		if !ok {
+			fmt.Println(tui.MutedStyle.Render("cancelled."))
+			return nil
+		}
+		break
 	}
 
-	// Clear spinner line
-	fmt.Print("\r\033[K")
+	// Save quest to Convex
+	if _, err := createQuest(cmd.Context(), cfg, title, note, xp, reasoning, addSubtasks); err != nil {
+		fmt.Print("\r\033[K")
+		fmt.Println(tui.ErrorStyle.Render("Failed to save quest: " + err.Error()))
+		return nil
+	}
 
-	// Show result
-	box := tui.BoxStyle.Width(50).Render(
-		fmt.Sprintf("%s · %s\n%s",
-			tui.XPStyle.Render(fmt.Sprintf("+%d XP", xp)),
-			title,
-			tui.MutedStyle.Render("└─ "+reasoning),
-		),
-	)
-	fmt.Println(box)
-	fmt.Println(tui.MutedStyle.Render("\nquest added. grind on."))
+	if !review {
+		// Clear spinner line and show result; a reviewed quest was
+		// already shown
+		fmt.Print("\r\033[K")
+		fmt.Println(renderQuestPreview(title, note, xp, reasoning, addSubtasks))
+		fmt.Println()
+	}
+	fmt.Println(tui.MutedStyle.Render("quest added. grind on."))
 
 	return nil
 }
 
-// evaluateQuestWithAI calls the Convex AI action to evaluate XP
-func evaluateQuestWithAI(cfg *auth.Config, title string) (int, string, error) {
-	convexURL := cfg.GetConvexURL()
-	if convexURL == "" {
-		return 0, "", fmt.Errorf("Convex URL not configured")
+// renderQuestPreview boxes a quest's XP, title, AI reasoning, note, and
+// sub-tasks
+func renderQuestPreview(title, note string, xp int, reasoning string, subtasks []string) string {
+	body := fmt.Sprintf("%s %s %s\n%s",
+		tui.XPStyle.Render(fmt.Sprintf("+%d XP", xp)),
+		components.Glyphs.Dot,
+		title,
+		tui.MutedStyle.Render(components.Glyphs.Branch+reasoning),
+	)
+	if note != "" {
+		body += "\n" + tui.MutedStyle.Render("   note: "+note)
 	}
-
-	client := api.NewClient(convexURL)
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-
-	result, err := client.Action(ctx, "ai:evaluateQuest", map[string]any{
-		"title": title,
-	})
-	if err != nil {
-		return 0, "", err
+	for _, s := range subtasks {
+		body += "\n" + tui.MutedStyle.Render("   [ ] "+s)
 	}
+	return tui.BoxStyle.Width(50).Render(body)
+}
 
-	// Parse response
-	data, ok := result.(map[string]any)
+// runAddTemplate adds every quest in a saved template, one at a time. A
+// quest that fails is reported and the rest are still added.
+func runAddTemplate(cmd *cobra.Command, cfg *auth.Config, name string) error {
+	titles, ok := cfg.Templates[name]
 	if !ok {
-		return 0, "", fmt.Errorf("unexpected response format")
+		return fmt.Errorf("no template named %q (see 'grind template list')", name)
 	}
 
-	xp := int(data["xp"].(float64))
-	reasoning := data["reasoning"].(string)
-
-	return xp, reasoning, nil
-}
-
-// evaluateQuestXP provides local XP estimation
-func evaluateQuestXP(title string) (int, string) {
-	lower := strings.ToLower(title)
-	xp := 25 // Base XP
-	reasoning := "standard task"
-
-	// High effort keywords
-	highEffort := map[string]string{
-		"ship":      "shipping feature, significant effort",
-		"deploy":    "deployment, medium-high effort",
-		"launch":    "launch, high impact",
-		"build":     "building new functionality",
-		"implement": "implementation work",
-		"create":    "creating new feature",
-		"refactor":  "refactoring, medium effort",
-		"architect": "architecture work, high complexity",
-	}
-
-	// Medium effort keywords
-	medEffort := map[string]string{
-		"fix":    "bug fix, focused work",
-		"update": "update task, moderate effort",
-		"review": "code review, careful attention",
-		"test":   "testing work",
-		"write":  "writing task",
-		"design": "design work",
-		"debug":  "debugging session",
-	}
-
-	// Low effort keywords
-	lowEffort := map[string]string{
-		"call":    "communication task",
-		"email":   "quick communication",
-		"meeting": "meeting attendance",
-		"read":    "reading task",
-		"check":   "quick check",
-	}
-
-	// Physical activity
-	physicalActivity := map[string]string{
-		"gym":     "physical training",
-		"workout": "exercise session",
-		"run":     "cardio exercise",
-		"walk":    "light activity",
-	}
-
-	for kw, desc := range highEffort {
-		if strings.Contains(lower, kw) {
-			xp += 40
-			reasoning = desc
-			break
+	manual := cmd.Flags().Changed("xp")
+	var fixedXP int
+	if manual {
+		var err error
+		if fixedXP, err = manualXP(); err != nil {
+			return err
 		}
 	}
+	note := strings.TrimSpace(addNote)
+
+	added, total := 0, 0
+	for _, title := range titles {
+		xp, reasoning := fixedXP, api.ManualXPReasoning
+		var err error
+		if !manual {
+			fmt.Print(tui.MutedStyle.Render("  " + components.Glyphs.Spinner + " evaluating " + title + "..."))
+			xp, reasoning, err = evaluateQuestWithAI(cmd.Context(), cfg, title)
+		}
+		if err == nil {
+			_, err = createQuest(cmd.Context(), cfg, title, note, xp, reasoning, nil)
+		}
+		fmt.Print("\r\033[K")
 
-	for kw, desc := range medEffort {
-		if strings.Contains(lower, kw) {
-			xp += 20
-			if reasoning == "standard task" {
-				reasoning = desc
-			}
+		if errors.Is(err, context.Canceled) {
+			fmt.Println(tui.MutedStyle.Render("cancelled."))
 			break
 		}
+		if err != nil {
+			fmt.Println(tui.ErrorStyle.Render(fmt.Sprintf("%s %s: %v", components.Glyphs.Cross, title, err)))
+			continue
+		}
+		fmt.Printf(tui.XPStyle.Render("+%d XP")+" %s %s\n", xp, components.Glyphs.Dot, title)
+		added++
+		total += xp
 	}
 
-	for kw, desc := range lowEffort {
-		if strings.Contains(lower, kw) {
-			xp -= 5
-			if reasoning == "standard task" {
-				reasoning = desc
-			}
-			break
-		}
+	fmt.Println(tui.MutedStyle.Render(fmt.Sprintf("\nadded %d of %d quests from %q (+%d XP on the table). grind on.", added, len(titles), name, total)))
+	return nil
+}
+
+// manualXP validates --xp, capping it at the maximum with a notice
+func manualXP() (int, error) {
+	if addXP < api.MinQuestXP {
+		return 0, fmt.Errorf("--xp can't be negative")
+	}
+	if addXP > api.MaxQuestXP {
+		fmt.Println(tui.MutedStyle.Render(fmt.Sprintf("XP capped at %d", api.MaxQuestXP)))
+		return api.MaxQuestXP, nil
 	}
+	return addXP, nil
+}
 
-	for kw, desc := range physicalActivity {
-		if strings.Contains(lower, kw) {
-			xp = 35
-			reasoning = desc
-			break
+// evaluateQuestWithAI scores a quest with the configured evaluator: the
+// Convex AI action by default, or the local estimate in local mode
+func evaluateQuestWithAI(parent context.Context, cfg *auth.Config, title string) (int, string, error) {
+	var backend api.ConvexAPI
+	if !cfg.Local && cfg.GetEvaluator() == evaluator.NameAI {
+		convexURL := cfg.GetConvexURL()
+		if convexURL == "" {
+			return 0, "", fmt.Errorf("Convex URL not configured")
 		}
+		backend = newBackend(convexURL)
 	}
 
-	// Length/complexity bonus
-	words := len(strings.Fields(title))
-	if words > 5 {
-		xp += 10
-	}
+	ctx, cancel := requestContext(parent, 30*time.Second)
+	defer cancel()
+	return evaluator.For(cfg, backend).Evaluate(ctx, title)
+}
+
+// createQuest saves a quest to Convex via quests:create and returns its ID.
+// Each call is one logical add with its own idempotency key, so the backend
+// never stores it twice.
+func createQuest(parent context.Context, cfg *auth.Config, title, notes string, xp int, reasoning string, subtasks []string) (string, error) {
+	client := clientFor(cfg)
+	ctx, cancel := requestContext(parent, 10*time.Second)
+	defer cancel()
 
-	// Clamp
-	if xp < 10 {
-		xp = 10
+	args := map[string]any{
+		"userId":         cfg.UserID,
+		"title":          title,
+		"xp":             xp,
+		"aiReasoning":    reasoning,
+		"idempotencyKey": api.NewIdempotencyKey(),
 	}
-	if xp > 100 {
-		xp = 100
+	if notes != "" {
+		args["notes"] = notes
+	}
+	if len(subtasks) > 0 {
+		args["subtasks"] = subtasks
+	}
+
+	result, err := client.Mutation(ctx, "quests:create", args)
+	if err != nil {
+		return "", err
 	}
 
-	return xp, reasoning
+	var created struct {
+		QuestID string `json:"questId"`
+	}
+	if err := api.DecodeInto(result, &created); err != nil {
+		return "", fmt.Errorf("decode quest: %w", err)
+	}
+	return created.QuestID, nil
 }
 
 func init() {
+	addCmd.Flags().StringVarP(&addNote, "note", "n", "", "Attach a note to the quest")
+	addCmd.Flags().StringVarP(&addTemplate, "template", "t", "", "Add every quest in a saved template (see 'grind template')")
+	addCmd.Flags().BoolVarP(&addYes, "yes", "y", false, "Save without reviewing the AI's XP first")
+	addCmd.Flags().StringArrayVarP(&addSubtasks, "subtask", "s", nil, "Add a sub-task (repeatable); each one done pays part of the XP")
+	addCmd.Flags().IntVar(&addXP, "xp", 0, fmt.Sprintf("Set XP yourself (%d-%d) instead of asking the AI", api.MinQuestXP, api.MaxQuestXP))
+
 	// Silence default usage
 	_ = lipgloss.NewStyle()
 	_ = time.Now()
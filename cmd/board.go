@@ -3,12 +3,17 @@ package cmd
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/spf13/cobra"
 
+	"grind/internal/api"
 	"grind/internal/auth"
+	"grind/internal/goals"
+	"grind/internal/i18n"
 	"grind/internal/tui"
+	"grind/internal/tui/components"
 )
 
 var boardCmd = &cobra.Command{
@@ -18,13 +23,25 @@ var boardCmd = &cobra.Command{
 
 Shows rankings based on XP earned this week.
 
+--format takes a Go template applied to each entry. Fields:
+  .Rank .UserName .Level .WeeklyXP .TotalXP
+
+--share prints the board as plain text in a fenced code block, with the
+crew name and week, ready to paste into Discord or Slack.
+
 Examples:
   grind board           # Show weekly leaderboard
-  grind board --all     # Show all-time leaderboard`,
+  grind board --all     # Show all-time leaderboard
+  grind board --share | pbcopy
+  grind board --format '{{.Rank}}. {{.UserName}} {{.WeeklyXP}}'`,
 	RunE: runBoard,
 }
 
-var boardAllTime bool
+var (
+	boardAllTime bool
+	boardFormat  string
+	boardShare   bool
+)
 
 func runBoard(cmd *cobra.Command, args []string) error {
 	cfg, err := auth.Load()
@@ -33,38 +50,60 @@ func runBoard(cmd *cobra.Command, args []string) error {
 	}
 
 	if !cfg.IsLoggedIn() {
-		fmt.Println(tui.ErrorStyle.Render("Not logged in. Run 'grind' to set up."))
+		fmt.Println(tui.ErrorStyle.Render(i18n.T("cmd.notLoggedIn")))
 		return nil
 	}
 
 	if !cfg.HasGroup() {
-		fmt.Println(tui.ErrorStyle.Render("Not in a group. Run 'grind join <code>' to join one."))
+		fmt.Println(tui.ErrorStyle.Render(i18n.T("cmd.noGroup")))
+		return nil
+	}
+
+	client := clientFor(cfg)
+	ctx, cancel := requestContext(cmd.Context(), 10*time.Second)
+	defer cancel()
+
+	entries, err := client.GetLeaderboard(ctx, cfg.GroupID, boardAllTime)
+	if err != nil {
+		fmt.Println(tui.ErrorStyle.Render("Failed to load leaderboard: " + err.Error()))
+		return nil
+	}
+
+	if boardFormat != "" {
+		return printFormatted(boardFormat, entries)
+	}
+	if boardShare {
+		// The crew name is a nicety; share without it rather than fail
+		crew := "crew"
+		if group, err := client.GetGroup(ctx, cfg.GroupID); err == nil && group != nil {
+			crew = group.Name
+		}
+		fmt.Println(shareBoard(crew, entries, boardAllTime, time.Now()))
 		return nil
 	}
 
 	// Header
-	title := "LEADERBOARD · this week"
+	title := "LEADERBOARD " + components.Glyphs.Dot + " this week"
 	if boardAllTime {
-		title = "LEADERBOARD · all time"
+		title = "LEADERBOARD " + components.Glyphs.Dot + " all time"
 	}
 
-	// TODO: Fetch leaderboard from Convex
-	// For now, show user's own entry
-
-	// Sample leaderboard
-	entries := []struct {
-		rank   int
-		name   string
-		level  int
-		xp     int
-	}{
-		{1, cfg.UserName, 1, 0},
+	// Bars are relative to the leader
+	entryXP := func(e api.LeaderboardEntry) int {
+		if boardAllTime {
+			return e.TotalXP
+		}
+		return e.WeeklyXP
+	}
+	top := 1
+	for _, e := range entries {
+		top = max(top, entryXP(e))
 	}
 
 	var rows []string
 	for _, e := range entries {
 		rankStyle := tui.MutedStyle
-		switch e.rank {
+		switch e.Rank {
 		case 1:
 			rankStyle = tui.Rank1Style
 		case 2:
@@ -75,19 +114,27 @@ func runBoard(cmd *cobra.Command, args []string) error {
 
 		// Progress bar
 		barWidth := 20
-		bar := tui.ProgressBar(e.xp, 100, barWidth)
+		bar := tui.ProgressBar(entryXP(e)*barWidth/top, barWidth, barWidth)
 
-		row := fmt.Sprintf("  %s  %-12s L%d  %s  %d XP",
-			rankStyle.Render(fmt.Sprintf("#%d", e.rank)),
-			e.name,
-			e.level,
+		row := fmt.Sprintf("  %s  %s L%d  %s  %d XP",
+			rankStyle.Render(fmt.Sprintf("#%d", e.Rank)),
+			components.PadName(e.UserName, 12),
+			e.Level,
 			bar,
-			e.xp,
+			entryXP(e),
 		)
 		rows = append(rows, row)
 	}
+	if len(rows) == 0 {
+		rows = append(rows, tui.MutedStyle.Render("  No one on the board yet."))
+	}
 
-	separator := tui.MutedStyle.Render(strings.Repeat("═", 50))
+	separator := tui.MutedStyle.Render(strings.Repeat(components.Glyphs.Rule, 50))
+
+	footer := ""
+	if !boardAllTime {
+		footer = tui.MutedStyle.Render(resetsIn(time.Now()))
+	}
 
 	content := lipgloss.JoinVertical(
 		lipgloss.Left,
@@ -97,7 +144,7 @@ func runBoard(cmd *cobra.Command, args []string) error {
 		strings.Join(rows, "\n"),
 		"",
 		separator,
-		tui.MutedStyle.Render("resets in 7 days"),
+		footer,
 	)
 
 	box := tui.BoxStyle.Width(55).Render(content)
@@ -106,6 +153,56 @@ func runBoard(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// shareBoard renders the leaderboard as an unstyled markdown code block,
+// so it keeps its alignment when pasted into chat
+func shareBoard(crew string, entries []api.LeaderboardEntry, allTime bool, now time.Time) string {
+	period := "all time"
+	if !allTime {
+		start := goals.WeekStart(now)
+		period = fmt.Sprintf("week of %s - %s", start.Format("Jan 2"), start.AddDate(0, 0, 6).Format("Jan 2"))
+	}
+
+	entryXP := func(e api.LeaderboardEntry) int {
+		if allTime {
+			return e.TotalXP
+		}
+		return e.WeeklyXP
+	}
+	top := 1
+	for _, e := range entries {
+		top = max(top, entryXP(e))
+	}
+
+	lines := []string{"```", fmt.Sprintf("GRIND %s %s %s %s", components.Glyphs.Dot, crew, components.Glyphs.Dot, period), ""}
+	for _, e := range entries {
+		const barWidth = 16
+		filled := entryXP(e) * barWidth / top
+		bar := strings.Repeat(components.Glyphs.BarFull, filled) + strings.Repeat(components.Glyphs.BarLight, barWidth-filled)
+		lines = append(lines, fmt.Sprintf("#%-2d %s L%-2d %s %6s XP",
+			e.Rank, components.PadName(e.UserName, 12), e.Level, bar, i18n.Number(entryXP(e))))
+	}
+	if len(entries) == 0 {
+		lines = append(lines, "No one on the board yet.")
+	}
+	return strings.Join(append(lines, "```"), "\n")
+}
+
+// resetsIn describes how long until the weekly leaderboard resets on Monday
+func resetsIn(now time.Time) string {
+	reset := goals.WeekStart(now).AddDate(0, 0, 7)
+	days := int(reset.Sub(now).Hours() / 24)
+	switch days {
+	case 0:
+		return "resets tonight"
+	case 1:
+		return "resets in 1 day"
+	default:
+		return fmt.Sprintf("resets in %d days", days)
+	}
+}
+
 func init() {
 	boardCmd.Flags().BoolVarP(&boardAllTime, "all", "a", false, "Show all-time leaderboard")
+	boardCmd.Flags().BoolVar(&boardShare, "share", false, "Print a plain-text board to paste into chat")
+	boardCmd.Flags().StringVar(&boardFormat, "format", "", "Print each entry with a Go template (see --help for fields)")
 }
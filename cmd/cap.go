@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"grind/internal/api"
+	"grind/internal/auth"
+	"grind/internal/i18n"
+	"grind/internal/tui"
+	"grind/internal/tui/components"
+)
+
+var capCmd = &cobra.Command{
+	Use:   "cap",
+	Short: "Show or set your crew's daily XP cap",
+	Long: `Show how much XP you can still earn today under your crew's daily cap.
+
+A crew can opt in to a daily XP cap so nobody tops the leaderboard by
+farming dozens of trivial quests. Once you hit it, quests still complete
+but earn only the XP left under the cap, then none until tomorrow. Only
+the crew's creator can change it.
+
+Examples:
+  grind cap            # Show today's usage
+  grind cap set 300    # Cap everyone in the crew at 300 XP a day
+  grind cap off        # Remove the cap`,
+	Args: cobra.NoArgs,
+	RunE: runCap,
+}
+
+var capSetCmd = &cobra.Command{
+	Use:   "set <xp>",
+	Short: "Set the crew's daily XP cap",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runCapSet,
+}
+
+var capOffCmd = &cobra.Command{
+	Use:   "off",
+	Short: "Remove the crew's daily XP cap",
+	Args:  cobra.NoArgs,
+	RunE:  runCapOff,
+}
+
+func runCap(cmd *cobra.Command, args []string) error {
+	cfg, err := auth.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if !cfg.IsLoggedIn() {
+		fmt.Println(tui.ErrorStyle.Render(i18n.T("cmd.notLoggedIn")))
+		return nil
+	}
+
+	client := clientFor(cfg)
+	ctx, cancel := requestContext(cmd.Context(), 10*time.Second)
+	defer cancel()
+
+	stats, err := client.GetStats(ctx, cfg.UserID)
+	if err != nil {
+		fmt.Println(tui.ErrorStyle.Render("Failed to load stats: " + err.Error()))
+		return nil
+	}
+	if stats == nil || stats.Today.XPCap <= 0 {
+		fmt.Println(tui.MutedStyle.Render("Your crew has no daily XP cap."))
+		return nil
+	}
+
+	today := stats.Today
+	bar := tui.ProgressBarBracketed(today.CapUsed, today.XPCap, 30)
+	fmt.Printf("%s %d / %d XP today\n", bar, today.CapUsed, today.XPCap)
+	if remaining := today.CapRemaining(); remaining > 0 {
+		fmt.Println(tui.MutedStyle.Render(fmt.Sprintf("%d XP left today", remaining)))
+	} else {
+		fmt.Println(tui.AlertStyle.Render("daily cap reached. quests still count, XP resets tomorrow."))
+	}
+	return nil
+}
+
+func runCapSet(cmd *cobra.Command, args []string) error {
+	xpCap, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid cap: %s", args[0])
+	}
+	if xpCap < 1 || xpCap > api.MaxDailyXPCap {
+		return fmt.Errorf("daily cap must be between 1 and %d XP", api.MaxDailyXPCap)
+	}
+	return setDailyCap(cmd, xpCap)
+}
+
+func runCapOff(cmd *cobra.Command, args []string) error {
+	return setDailyCap(cmd, 0)
+}
+
+// setDailyCap changes the crew's cap; 0 removes it
+func setDailyCap(cmd *cobra.Command, xpCap int) error {
+	cfg, err := auth.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if !cfg.IsLoggedIn() {
+		fmt.Println(tui.ErrorStyle.Render(i18n.T("cmd.notLoggedIn")))
+		return nil
+	}
+	if !cfg.HasGroup() {
+		fmt.Println(tui.ErrorStyle.Render(auth.ErrNoGroup.Error()))
+		return nil
+	}
+
+	client := clientFor(cfg)
+	ctx, cancel := requestContext(cmd.Context(), 10*time.Second)
+	defer cancel()
+
+	if err := client.SetDailyCap(ctx, cfg.GroupID, cfg.UserID, xpCap); err != nil {
+		fmt.Println(tui.ErrorStyle.Render("Failed to set daily cap: " + err.Error()))
+		return nil
+	}
+
+	if xpCap == 0 {
+		fmt.Println(tui.MutedStyle.Render("daily cap removed"))
+	} else {
+		fmt.Println(tui.SuccessStyle.Render(fmt.Sprintf("%s daily cap = %d XP", components.Glyphs.Check, xpCap)))
+	}
+	return nil
+}
+
+func init() {
+	capCmd.AddCommand(capSetCmd)
+	capCmd.AddCommand(capOffCmd)
+}
@@ -0,0 +1,725 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"grind/internal/api"
+	"grind/internal/auth"
+	"grind/internal/evaluator"
+	"grind/internal/i18n"
+	"grind/internal/streaks"
+	"grind/internal/tui"
+	"grind/internal/tui/components"
+)
+
+var (
+	configDryRun bool
+	configForce  bool
+	configHard   bool
+	configYes    bool
+	configEdit   bool
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "View or change settings",
+	Long: `View or change grind settings stored in ~/.grind/config.json.
+
+Examples:
+  grind config get                   # Show all settings
+  grind config get pollInterval      # Show one setting
+  grind config set pollInterval 10s  # Refresh the dashboard every 10s
+  grind config set glyphs ascii      # Plain ASCII for terminals without unicode
+  grind config set layout compact    # Single-column dashboard for narrow panes
+  grind config set convexUrl https://x.convex.cloud --dry-run
+                                     # Check a new backend without saving
+  grind config edit                  # Edit config.json in $EDITOR
+  grind config reset                 # Default settings, same account
+
+Changing convexUrl first checks the new deployment is reachable. Before
+any change is saved, the previous config is copied to config.json.bak.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if configEdit {
+			return runConfigEdit(cmd, args)
+		}
+		return cmd.Help()
+	},
+}
+
+var configGetCmd = &cobra.Command{
+	Use:   "get [key]",
+	Short: "Show settings",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runConfigGet,
+}
+
+var configSetCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "Change a setting",
+	Args:  cobra.ExactArgs(2),
+	RunE:  runConfigSet,
+}
+
+var configEditCmd = &cobra.Command{
+	Use:   "edit",
+	Short: "Edit config.json in $EDITOR",
+	Long: `Open config.json in $VISUAL or $EDITOR (vi if neither is set) to change
+several settings at once.
+
+When the editor exits, the file is checked: it must be valid JSON with
+only known fields, and settings must hold values 'grind config set' would
+accept. A bad edit is rolled back to the previous config.`,
+	Args: cobra.NoArgs,
+	RunE: runConfigEdit,
+}
+
+var configResetCmd = &cobra.Command{
+	Use:   "reset",
+	Short: "Restore default settings",
+	Long: `Put every setting back to its default, keeping your account.
+
+Your name, crew, backend (convexUrl), templates, and quest timers stay;
+preferences like pollInterval, layout, glyphs, lang, timezone, and
+xpKeywords go back to their defaults. The changes are listed and you're
+asked before anything is written, and the old config is copied to
+config.json.bak first.
+
+--hard removes the whole config instead, signing this machine out of
+your account. The account itself isn't deleted: 'grind' sets it up again.
+
+Examples:
+  grind config reset          # Default settings, same account
+  grind config reset --hard   # Start over from scratch`,
+	Args: cobra.NoArgs,
+	RunE: runConfigReset,
+}
+
+// configKey describes a user-settable config field
+type configKey struct {
+	desc string
+	get  func(cfg *auth.Config) string
+	set  func(cfg *auth.Config, value string) error
+	// check optionally verifies a new value works before it's saved, for
+	// settings that can leave grind unusable
+	check func(ctx context.Context, cfg *auth.Config) error
+}
+
+// configKeys lists the settings exposed through 'grind config'
+var configKeys = map[string]configKey{
+	"pollInterval": {
+		desc: fmt.Sprintf("dashboard refresh interval (default %s, min %s)", auth.DefaultPollInterval, auth.MinPollInterval),
+		get: func(cfg *auth.Config) string {
+			return cfg.GetPollInterval().String()
+		},
+		set: func(cfg *auth.Config, value string) error {
+			if err := auth.ValidatePollInterval(value); err != nil {
+				return err
+			}
+			cfg.PollInterval = value
+			return nil
+		},
+	},
+	"idleAfter": {
+		desc: fmt.Sprintf("pause dashboard refreshes after this long without a keypress (default %s, min %s), or off", auth.DefaultIdleAfter, auth.MinIdleAfter),
+		get: func(cfg *auth.Config) string {
+			if cfg.GetIdleAfter() == 0 {
+				return "off"
+			}
+			return cfg.GetIdleAfter().String()
+		},
+		set: func(cfg *auth.Config, value string) error {
+			if err := auth.ValidateIdleAfter(value); err != nil {
+				return err
+			}
+			cfg.IdleAfter = value
+			return nil
+		},
+	},
+	"glyphs": {
+		desc: "icon set: auto (detect from locale), unicode, or ascii",
+		get: func(cfg *auth.Config) string {
+			if cfg.Glyphs == "" {
+				return "auto"
+			}
+			return cfg.Glyphs
+		},
+		set: func(cfg *auth.Config, value string) error {
+			switch value {
+			case "auto", "unicode", "ascii":
+				cfg.Glyphs = value
+				return nil
+			}
+			return fmt.Errorf("glyphs must be auto, unicode, or ascii")
+		},
+	},
+	"layout": {
+		desc: "dashboard layout: cyber, classic, or compact (single column)",
+		get: func(cfg *auth.Config) string {
+			if cfg.Layout == "" {
+				return "cyber"
+			}
+			return cfg.Layout
+		},
+		set: func(cfg *auth.Config, value string) error {
+			switch value {
+			case "cyber", "classic", "compact":
+				cfg.Layout = value
+				return nil
+			}
+			return fmt.Errorf("layout must be cyber, classic, or compact")
+		},
+	},
+	"streakFreezes": {
+		desc: fmt.Sprintf("missed days forgiven per week before a streak breaks (default %d, max %d)", streaks.DefaultFreezesPerWeek, streaks.MaxFreezesPerWeek),
+		get: func(cfg *auth.Config) string {
+			return strconv.Itoa(cfg.GetStreakFreezes())
+		},
+		set: func(cfg *auth.Config, value string) error {
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("streakFreezes must be a number")
+			}
+			if err := streaks.ValidateFreezes(n); err != nil {
+				return err
+			}
+			cfg.StreakFreezes = &n
+			return nil
+		},
+	},
+	"confirmDoneXp": {
+		desc: fmt.Sprintf("ask before completing quests worth more XP than this (default %d), or off", auth.DefaultConfirmDoneXP),
+		get: func(cfg *auth.Config) string {
+			if cfg.GetConfirmDoneXP() == 0 {
+				return "off"
+			}
+			return strconv.Itoa(cfg.GetConfirmDoneXP())
+		},
+		set: func(cfg *auth.Config, value string) error {
+			n := 0
+			if value != "off" {
+				var err error
+				if n, err = strconv.Atoi(value); err != nil || n < 1 {
+					return fmt.Errorf("confirmDoneXp must be a positive number or off")
+				}
+			}
+			cfg.ConfirmDoneXP = &n
+			return nil
+		},
+	},
+	"lang": {
+		desc: fmt.Sprintf("UI language: %s (GRIND_LANG overrides it)", strings.Join(i18n.Languages(), ", ")),
+		get: func(cfg *auth.Config) string {
+			if cfg.Lang == "" {
+				return i18n.DefaultLang
+			}
+			return cfg.Lang
+		},
+		set: func(cfg *auth.Config, value string) error {
+			if !i18n.Supported(value) {
+				return fmt.Errorf("lang must be one of: %s", strings.Join(i18n.Languages(), ", "))
+			}
+			cfg.Lang = value
+			return nil
+		},
+	},
+	"fullNumbers": {
+		desc: "show full XP numbers (12,345) instead of abbreviations (12.3k): true or false",
+		get: func(cfg *auth.Config) string {
+			return strconv.FormatBool(cfg.FullNumbers)
+		},
+		set: func(cfg *auth.Config, value string) error {
+			full, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("fullNumbers must be true or false")
+			}
+			cfg.FullNumbers = full
+			return nil
+		},
+	},
+	"wrapNavigation": {
+		desc: "up/down wrap around at the ends of lists: true or false",
+		get: func(cfg *auth.Config) string {
+			return strconv.FormatBool(cfg.WrapNavigation)
+		},
+		set: func(cfg *auth.Config, value string) error {
+			wrap, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("wrapNavigation must be true or false")
+			}
+			cfg.WrapNavigation = wrap
+			return nil
+		},
+	},
+	"colorSafe": {
+		desc: "label quest status and rivalry alerts in words, not just color: true or false",
+		get: func(cfg *auth.Config) string {
+			return strconv.FormatBool(cfg.ColorSafe)
+		},
+		set: func(cfg *auth.Config, value string) error {
+			safe, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("colorSafe must be true or false")
+			}
+			cfg.ColorSafe = safe
+			return nil
+		},
+	},
+	"xpTiers": {
+		desc: fmt.Sprintf("XP where quest badges turn from slate to gold and from gold to orange, as mid,high (default %d,%d)", components.DefaultXPTierMid, components.DefaultXPTierHigh),
+		get: func(cfg *auth.Config) string {
+			if len(cfg.XPTiers) != 2 {
+				return fmt.Sprintf("%d,%d", components.DefaultXPTierMid, components.DefaultXPTierHigh)
+			}
+			return fmt.Sprintf("%d,%d", cfg.XPTiers[0], cfg.XPTiers[1])
+		},
+		set: func(cfg *auth.Config, value string) error {
+			midStr, highStr, ok := strings.Cut(value, ",")
+			mid, err1 := strconv.Atoi(strings.TrimSpace(midStr))
+			high, err2 := strconv.Atoi(strings.TrimSpace(highStr))
+			if !ok || err1 != nil || err2 != nil {
+				return fmt.Errorf("xpTiers must be two numbers, e.g. 30,75")
+			}
+			if mid < 1 || high <= mid || high > api.MaxQuestXP {
+				return fmt.Errorf("xpTiers must satisfy 1 <= mid < high <= %d", api.MaxQuestXP)
+			}
+			cfg.XPTiers = []int{mid, high}
+			return nil
+		},
+	},
+	"timezone": {
+		desc: "timezone for the dashboard greeting, e.g. Europe/Berlin (default: system)",
+		get: func(cfg *auth.Config) string {
+			return cfg.Location().String()
+		},
+		set: func(cfg *auth.Config, value string) error {
+			if err := auth.ValidateTimezone(value); err != nil {
+				return err
+			}
+			cfg.Timezone = value
+			return nil
+		},
+	},
+	"evaluator": {
+		desc: "what scores new quests: ai (the backend, default), llm (llmUrl), or keywords (local estimate)",
+		get: func(cfg *auth.Config) string {
+			return cfg.GetEvaluator()
+		},
+		set: func(cfg *auth.Config, value string) error {
+			if !evaluator.Valid(value) {
+				return fmt.Errorf("evaluator must be one of: %s", strings.Join(evaluator.Names(), ", "))
+			}
+			cfg.Evaluator = value
+			return nil
+		},
+	},
+	"llmUrl": {
+		desc: fmt.Sprintf("OpenAI-compatible chat endpoint for evaluator llm (default %s; key in GRIND_LLM_KEY)", evaluator.DefaultLLMURL),
+		get: func(cfg *auth.Config) string {
+			if cfg.LLMURL == "" {
+				return evaluator.DefaultLLMURL
+			}
+			return cfg.LLMURL
+		},
+		set: func(cfg *auth.Config, value string) error {
+			if !strings.HasPrefix(value, "https://") && !strings.HasPrefix(value, "http://") {
+				return fmt.Errorf("llmUrl must start with http:// or https://")
+			}
+			cfg.LLMURL = value
+			return nil
+		},
+	},
+	"llmModel": {
+		desc: fmt.Sprintf("model name sent to llmUrl (default %s)", evaluator.DefaultLLMModel),
+		get: func(cfg *auth.Config) string {
+			if cfg.LLMModel == "" {
+				return evaluator.DefaultLLMModel
+			}
+			return cfg.LLMModel
+		},
+		set: func(cfg *auth.Config, value string) error {
+			if strings.TrimSpace(value) == "" {
+				return fmt.Errorf("llmModel can't be empty")
+			}
+			cfg.LLMModel = value
+			return nil
+		},
+	},
+	"convexUrl": {
+		desc: "Convex deployment URL",
+		get: func(cfg *auth.Config) string {
+			return cfg.GetConvexURL()
+		},
+		set: func(cfg *auth.Config, value string) error {
+			if !strings.HasPrefix(value, "https://") && !strings.HasPrefix(value, "http://") {
+				return fmt.Errorf("convexUrl must start with http:// or https://")
+			}
+			cfg.ConvexURL = strings.TrimRight(value, "/")
+			return nil
+		},
+		check: func(ctx context.Context, cfg *auth.Config) error {
+			if _, err := newClient(cfg.GetConvexURL()).HealthCheck(ctx); err != nil {
+				return fmt.Errorf("can't reach %s: %w", cfg.GetConvexURL(), err)
+			}
+			return nil
+		},
+	},
+}
+
+func runConfigGet(cmd *cobra.Command, args []string) error {
+	cfg, err := auth.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if len(args) == 1 {
+		key, ok := configKeys[args[0]]
+		if !ok {
+			return unknownConfigKey(args[0])
+		}
+		fmt.Println(key.get(cfg))
+		return nil
+	}
+
+	for _, name := range configKeyNames() {
+		key := configKeys[name]
+		fmt.Printf("%-14s %s  %s\n", name, key.get(cfg), tui.MutedStyle.Render("# "+key.desc))
+	}
+	return nil
+}
+
+func runConfigSet(cmd *cobra.Command, args []string) error {
+	cfg, err := auth.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	key, ok := configKeys[args[0]]
+	if !ok {
+		return unknownConfigKey(args[0])
+	}
+
+	before := key.get(cfg)
+	if err := key.set(cfg, strings.TrimSpace(args[1])); err != nil {
+		fmt.Println(tui.ErrorStyle.Render(err.Error()))
+		return nil
+	}
+	after := key.get(cfg)
+
+	if before == after {
+		fmt.Println(tui.MutedStyle.Render(fmt.Sprintf("%s is already %s", args[0], after)))
+		return nil
+	}
+	fmt.Printf("%s: %s %s %s\n", args[0], tui.MutedStyle.Render(before), components.Glyphs.Arrow, after)
+
+	if key.check != nil && !configForce {
+		ctx, cancel := requestContext(cmd.Context(), 5*time.Second)
+		err := key.check(ctx, cfg)
+		cancel()
+		if err != nil {
+			fmt.Println(tui.ErrorStyle.Render(err.Error()))
+			fmt.Println(tui.MutedStyle.Render("not saved - use --force to save it anyway"))
+			return nil
+		}
+	}
+
+	if configDryRun {
+		fmt.Println(tui.MutedStyle.Render("dry run - not saved"))
+		return nil
+	}
+
+	backup, err := auth.Backup()
+	if err != nil {
+		return fmt.Errorf("failed to back up config: %w", err)
+	}
+	if err := auth.Save(cfg); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	fmt.Println(tui.SuccessStyle.Render(fmt.Sprintf("%s %s = %s", components.Glyphs.Check, args[0], after)))
+	if backup != "" && key.check != nil {
+		fmt.Println(tui.MutedStyle.Render("previous config saved to " + backup))
+	}
+	return nil
+}
+
+func runConfigReset(cmd *cobra.Command, args []string) error {
+	cfg, err := auth.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	path, err := auth.Path()
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		fmt.Println(tui.MutedStyle.Render("no config to reset"))
+		return nil
+	}
+
+	question := "Reset these settings?"
+	if configHard {
+		if cfg.IsLoggedIn() {
+			fmt.Printf("signs out %s", cfg.UserName)
+			if cfg.HasGroup() {
+				fmt.Printf(" (crew: %s)", cfg.GroupName)
+			}
+			fmt.Println(" and removes every setting")
+		}
+		if cfg.Local {
+			fmt.Println(tui.MutedStyle.Render("local quests stay in data.json"))
+		}
+		question = "Remove the whole config?"
+	} else {
+		before, err := json.Marshal(cfg)
+		if err != nil {
+			return err
+		}
+		cfg.ResetPreferences()
+		after, err := json.Marshal(cfg)
+		if err != nil {
+			return err
+		}
+		changes := configChanges(before, after)
+		if len(changes) == 0 {
+			fmt.Println(tui.MutedStyle.Render("settings are already the defaults"))
+			return nil
+		}
+		for _, line := range changes {
+			fmt.Println(line)
+		}
+	}
+
+	if !configYes && !confirm(cmd.Context(), question) {
+		fmt.Println(tui.MutedStyle.Render("cancelled."))
+		return nil
+	}
+
+	backup, err := auth.Backup()
+	if err != nil {
+		return fmt.Errorf("failed to back up config: %w", err)
+	}
+	if configHard {
+		err = auth.Clear()
+	} else {
+		err = auth.Save(cfg)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to reset config: %w", err)
+	}
+
+	if configHard {
+		fmt.Println(tui.SuccessStyle.Render(components.Glyphs.Check + " config removed. run 'grind' to set up again"))
+	} else {
+		fmt.Println(tui.SuccessStyle.Render(components.Glyphs.Check + " settings reset to defaults"))
+	}
+	fmt.Println(tui.MutedStyle.Render("previous config saved to " + backup))
+	return nil
+}
+
+func runConfigEdit(cmd *cobra.Command, args []string) error {
+	path, err := auth.Path()
+	if err != nil {
+		return err
+	}
+
+	// Make sure there's a file to edit, then keep a copy to roll back to
+	cfg, err := auth.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		if err := auth.Save(cfg); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+	}
+	if _, err := auth.Backup(); err != nil {
+		return fmt.Errorf("failed to back up config: %w", err)
+	}
+	before, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	if err := openEditor(path); err != nil {
+		return err
+	}
+
+	after, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(before, after) {
+		fmt.Println(tui.MutedStyle.Render("no changes"))
+		return nil
+	}
+
+	if err := validateConfigFile(after); err != nil {
+		if restoreErr := auth.RestoreBackup(); restoreErr != nil {
+			return fmt.Errorf("%v, and restoring the previous config failed: %w", err, restoreErr)
+		}
+		fmt.Println(tui.ErrorStyle.Render(err.Error()))
+		fmt.Println(tui.MutedStyle.Render("edit rejected - previous config restored"))
+		return nil
+	}
+
+	changes := configChanges(before, after)
+	for _, line := range changes {
+		fmt.Println(line)
+	}
+	fmt.Println(tui.SuccessStyle.Render(components.Glyphs.Check + " saved " + i18n.Plural("plural.change", len(changes))))
+	return nil
+}
+
+// openEditor runs the user's editor on path, attached to the terminal.
+// $VISUAL or $EDITOR may carry arguments, e.g. "code --wait".
+func openEditor(path string) error {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+
+	fields := strings.Fields(editor)
+	c := exec.Command(fields[0], append(fields[1:], path)...)
+	c.Stdin, c.Stdout, c.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := c.Run(); err != nil {
+		return fmt.Errorf("editor %q failed: %w", editor, err)
+	}
+	return nil
+}
+
+// validateConfigFile checks an edited config: valid JSON, no unknown or
+// mistyped fields, and settings 'grind config set' would accept
+func validateConfigFile(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var cfg auth.Config
+	if err := dec.Decode(&cfg); err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+
+	values := map[string]string{
+		"pollInterval": cfg.PollInterval,
+		"idleAfter":    cfg.IdleAfter,
+		"glyphs":       cfg.Glyphs,
+		"layout":       cfg.Layout,
+		"lang":         cfg.Lang,
+		"timezone":     cfg.Timezone,
+		"convexUrl":    cfg.ConvexURL,
+		"evaluator":    cfg.Evaluator,
+		"llmUrl":       cfg.LLMURL,
+		"llmModel":     cfg.LLMModel,
+	}
+	if cfg.StreakFreezes != nil {
+		values["streakFreezes"] = strconv.Itoa(*cfg.StreakFreezes)
+	}
+	if len(cfg.XPTiers) > 0 {
+		tiers := make([]string, len(cfg.XPTiers))
+		for i, n := range cfg.XPTiers {
+			tiers[i] = strconv.Itoa(n)
+		}
+		values["xpTiers"] = strings.Join(tiers, ",")
+	}
+	if cfg.ConfirmDoneXP != nil && *cfg.ConfirmDoneXP != 0 {
+		values["confirmDoneXp"] = strconv.Itoa(*cfg.ConfirmDoneXP)
+	}
+	for _, name := range configKeyNames() {
+		value, ok := values[name]
+		if !ok || value == "" {
+			continue
+		}
+		if err := configKeys[name].set(&auth.Config{}, value); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// configChanges lists the top-level fields that differ between two
+// configs, as "key: before → after"
+func configChanges(before, after []byte) []string {
+	var old, cur map[string]json.RawMessage
+	_ = json.Unmarshal(before, &old) // Was saved by grind, so it parses
+	_ = json.Unmarshal(after, &cur)  // Already validated
+
+	keys := map[string]bool{}
+	for k := range old {
+		keys[k] = true
+	}
+	for k := range cur {
+		keys[k] = true
+	}
+	names := make([]string, 0, len(keys))
+	for k := range keys {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	var lines []string
+	for _, k := range names {
+		o, c := compactJSON(old[k]), compactJSON(cur[k])
+		if o == c {
+			continue
+		}
+		if o == "" {
+			o = "(unset)"
+		}
+		if c == "" {
+			c = "(unset)"
+		}
+		lines = append(lines, fmt.Sprintf("%s: %s %s %s", k, tui.MutedStyle.Render(o), components.Glyphs.Arrow, c))
+	}
+	return lines
+}
+
+// compactJSON renders a raw value on one line, or "" if it's absent
+func compactJSON(raw json.RawMessage) string {
+	if raw == nil {
+		return ""
+	}
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, raw); err != nil {
+		return string(raw)
+	}
+	return buf.String()
+}
+
+// configKeyNames returns the settable keys in sorted order
+func configKeyNames() []string {
+	names := make([]string, 0, len(configKeys))
+	for name := range configKeys {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func unknownConfigKey(name string) error {
+	return fmt.Errorf("unknown setting %q (available: %s)", name, strings.Join(configKeyNames(), ", "))
+}
+
+func init() {
+	configSetCmd.Flags().BoolVar(&configDryRun, "dry-run", false, "Show what would change without saving")
+	configSetCmd.Flags().BoolVar(&configForce, "force", false, "Save even if the new value fails its check (e.g. an unreachable convexUrl)")
+	configCmd.Flags().BoolVar(&configEdit, "edit", false, "Edit config.json in $EDITOR (same as 'grind config edit')")
+	configCmd.AddCommand(configGetCmd)
+	configCmd.AddCommand(configSetCmd)
+	configCmd.AddCommand(configEditCmd)
+	configResetCmd.Flags().BoolVar(&configHard, "hard", false, "Remove the whole config, signing out")
+	configResetCmd.Flags().BoolVarP(&configYes, "yes", "y", false, "Reset without asking")
+	configCmd.AddCommand(configResetCmd)
+}
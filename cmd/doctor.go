@@ -0,0 +1,217 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/term"
+	"github.com/spf13/cobra"
+
+	"grind/internal/api"
+	"grind/internal/auth"
+	"grind/internal/i18n"
+	"grind/internal/store"
+	"grind/internal/tui"
+	"grind/internal/tui/components"
+)
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Diagnose your grind setup",
+	Long: `Check your environment for common problems.
+
+Verifies the config file, backend connectivity, your account (or a local
+guest's data file), and terminal capabilities, then prints a report you
+can paste into an issue.
+Exits non-zero if any critical check fails.`,
+	Args: cobra.NoArgs,
+	RunE: runDoctor,
+}
+
+// doctorCheck is a single line in the doctor report
+type doctorCheck struct {
+	name     string
+	ok       bool
+	critical bool
+	detail   string
+	hint     string
+}
+
+func runDoctor(cmd *cobra.Command, args []string) error {
+	var checks []doctorCheck
+
+	// Config file
+	cfg := &auth.Config{}
+	path, err := auth.Path()
+	if err != nil {
+		checks = append(checks, doctorCheck{name: "config", critical: true, detail: err.Error(),
+			hint: "make sure $HOME is set"})
+	} else if data, err := os.ReadFile(path); os.IsNotExist(err) {
+		checks = append(checks, doctorCheck{name: "config", ok: true, detail: "not created yet (" + path + ")"})
+	} else if err != nil {
+		checks = append(checks, doctorCheck{name: "config", critical: true, detail: err.Error(),
+			hint: "check permissions on " + path})
+	} else if err := json.Unmarshal(data, cfg); err != nil {
+		checks = append(checks, doctorCheck{name: "config", critical: true, detail: "invalid JSON: " + err.Error(),
+			hint: "fix or delete " + path + " and run 'grind' again"})
+	} else {
+		checks = append(checks, doctorCheck{name: "config", ok: true, detail: path})
+	}
+
+	// A guest never talks to the backend: check its data file instead
+	if cfg.Local {
+		checks = append(checks, localChecks(cfg)...)
+	} else {
+		checks = append(checks, backendChecks(cmd.Context(), cfg)...)
+	}
+
+	// Terminal capabilities
+	fd := os.Stdout.Fd()
+	if !term.IsTerminal(fd) {
+		checks = append(checks, doctorCheck{name: "terminal", detail: "stdout is not a TTY",
+			hint: "the interactive dashboard needs a real terminal"})
+	} else if w, h, err := term.GetSize(fd); err != nil {
+		checks = append(checks, doctorCheck{name: "terminal", detail: err.Error()})
+	} else {
+		check := doctorCheck{name: "terminal", ok: w >= 80 && h >= 24, detail: fmt.Sprintf("%dx%d", w, h)}
+		if !check.ok {
+			check.hint = "the dashboard looks best at 80x24 or larger"
+		}
+		checks = append(checks, check)
+	}
+
+	profile := lipgloss.ColorProfile().Name()
+	colorCheck := doctorCheck{name: "color", ok: profile != "Ascii", detail: profile}
+	if !colorCheck.ok {
+		colorCheck.hint = "set TERM/COLORTERM for color support"
+	}
+	checks = append(checks, colorCheck)
+
+	locale := firstNonEmpty(os.Getenv("LC_ALL"), os.Getenv("LC_CTYPE"), os.Getenv("LANG"))
+	utf8 := strings.Contains(strings.ToUpper(locale), "UTF-8") || strings.Contains(strings.ToUpper(locale), "UTF8")
+	unicodeCheck := doctorCheck{name: "unicode", ok: utf8, detail: locale}
+	if locale == "" {
+		unicodeCheck.detail = "locale not set"
+	}
+	if !utf8 {
+		unicodeCheck.hint = "set LANG to a UTF-8 locale (e.g. en_US.UTF-8), or run with --ascii"
+	}
+	checks = append(checks, unicodeCheck)
+
+	// Report
+	fmt.Println(tui.TitleStyle.Render("grind doctor"))
+	fmt.Println(tui.MutedStyle.Render(fmt.Sprintf("grind %s %s %s/%s %s %s", Version, components.Glyphs.Dot, runtime.GOOS, runtime.GOARCH, components.Glyphs.Dot, runtime.Version())))
+	fmt.Println()
+
+	failed := 0
+	for _, c := range checks {
+		mark := tui.SuccessStyle.Render(components.Glyphs.Check)
+		if !c.ok {
+			if c.critical {
+				mark = tui.ErrorStyle.Render(components.Glyphs.Cross)
+				failed++
+			} else {
+				mark = tui.XPStyle.Render("!")
+			}
+		}
+		fmt.Printf("  %s %-10s %s\n", mark, c.name, tui.MutedStyle.Render(c.detail))
+		if !c.ok && c.hint != "" {
+			fmt.Printf("    %s\n", tui.MutedStyle.Render(components.Glyphs.Arrow+" "+c.hint))
+		}
+	}
+	fmt.Println()
+
+	if failed > 0 {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("%d critical check(s) failed", failed)
+	}
+	fmt.Println(tui.SuccessStyle.Render("all good. grind on."))
+	return nil
+}
+
+// backendChecks checks the deployment is reachable and knows cfg's account
+func backendChecks(parent context.Context, cfg *auth.Config) []doctorCheck {
+	var checks []doctorCheck
+
+	// Backend connectivity
+	convexURL := cfg.GetConvexURL()
+	client := newClient(convexURL)
+	ctx, cancel := requestContext(parent, 5*time.Second)
+	defer cancel()
+
+	latency, err := client.HealthCheck(ctx)
+	if err != nil {
+		hint := "check your network and the convexUrl in your config"
+		var healthErr *api.HealthError
+		if errors.As(err, &healthErr) {
+			hint = healthErr.Hint()
+		}
+		checks = append(checks, doctorCheck{name: "backend", critical: true, detail: err.Error(), hint: hint})
+	} else {
+		checks = append(checks, doctorCheck{name: "backend", ok: true,
+			detail: fmt.Sprintf("%s (%dms)", convexURL, latency.Milliseconds())})
+	}
+
+	// Account
+	if !cfg.IsLoggedIn() {
+		checks = append(checks, doctorCheck{name: "account", critical: true, detail: "not logged in",
+			hint: "run 'grind' to set up"})
+	} else {
+		result, err := client.Query(ctx, "users:get", map[string]any{"userId": cfg.UserID})
+		switch {
+		case err != nil:
+			checks = append(checks, doctorCheck{name: "account", critical: true, detail: err.Error(),
+				hint: "your user ID may be invalid for this backend"})
+		case result == nil:
+			checks = append(checks, doctorCheck{name: "account", critical: true, detail: "user not found",
+				hint: "delete your config and run 'grind' to set up again"})
+		default:
+			detail := cfg.UserName
+			if cfg.HasGroup() {
+				detail += " " + components.Glyphs.Dot + " crew: " + cfg.GroupName
+			}
+			checks = append(checks, doctorCheck{name: "account", ok: true, detail: detail})
+		}
+	}
+	return checks
+}
+
+// localChecks checks a guest account and the store holding its quests
+func localChecks(cfg *auth.Config) []doctorCheck {
+	checks := []doctorCheck{{name: "backend", ok: true, detail: "n/a (local mode)"}}
+
+	if !cfg.IsLoggedIn() {
+		checks = append(checks, doctorCheck{name: "account", critical: true, detail: "not set up",
+			hint: "run 'grind --local' to set up"})
+	} else {
+		checks = append(checks, doctorCheck{name: "account", ok: true, detail: cfg.UserName + " (guest)"})
+	}
+
+	path, _ := auth.DataPath()
+	data, err := store.Load()
+	if err != nil {
+		checks = append(checks, doctorCheck{name: "data", critical: true, detail: err.Error(),
+			hint: "fix or move aside " + path})
+	} else {
+		checks = append(checks, doctorCheck{name: "data", ok: true,
+			detail: fmt.Sprintf("%s (%s)", path, i18n.Plural("plural.quest", len(data.Quests)))})
+	}
+	return checks
+}
+
+// firstNonEmpty returns the first non-empty string
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"grind/internal/api"
+	"grind/internal/api/apitest"
+	"grind/internal/auth"
+)
+
+func TestDoctorLocal(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	if err := auth.Save(&auth.Config{UserID: "local_u1", UserName: "ada", Local: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Any call reaching the deployment fails the test below
+	fake := apitest.NewFake()
+	backend := newBackend
+	newBackend = func(string) api.ConvexAPI { return fake }
+	defer func() { newBackend = backend }()
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	if err := runDoctor(cmd, nil); err != nil {
+		t.Errorf("healthy guest: %v", err)
+	}
+	if calls := fake.Calls(); len(calls) != 0 {
+		t.Errorf("local doctor called the backend: %+v", calls)
+	}
+
+	path, err := auth.DataPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("{not json"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := runDoctor(cmd, nil); err == nil {
+		t.Error("unreadable data file passed")
+	}
+}
@@ -1,29 +1,46 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"strconv"
+	"os"
+	"strings"
+	"time"
 
+	"github.com/charmbracelet/x/term"
 	"github.com/spf13/cobra"
 
+	"grind/internal/api"
 	"grind/internal/auth"
+	"grind/internal/i18n"
+	"grind/internal/levels"
 	"grind/internal/tui"
+	"grind/internal/tui/components"
 )
 
 var doneCmd = &cobra.Command{
-	Use:   "done [quest-number]",
+	Use:   "done [quest-number | title]",
 	Short: "Complete a quest",
 	Long: `Mark a quest as complete and earn XP.
 
-If no quest number is provided, shows a list of pending quests to choose from.
+Pick the quest by its number or by words from its title (any case, any
+order). If several unfinished quests match, they're listed to choose from.
 
 Examples:
-  grind done 1    # Complete quest #1
-  grind done      # Show list and pick`,
-	Args: cobra.MaximumNArgs(1),
+  grind done 1          # Complete quest #1
+  grind done refactor   # Complete the quest with "refactor" in its title
+  grind done --all      # Complete every unfinished quest (asks first)`,
+	Args: cobra.ArbitraryArgs,
 	RunE: runDone,
 }
 
+var (
+	doneNoAnimation bool
+	doneAll         bool
+	doneYes         bool
+)
+
 func runDone(cmd *cobra.Command, args []string) error {
 	cfg, err := auth.Load()
 	if err != nil {
@@ -31,28 +48,192 @@ func runDone(cmd *cobra.Command, args []string) error {
 	}
 
 	if !cfg.IsLoggedIn() {
-		fmt.Println(tui.ErrorStyle.Render("Not logged in. Run 'grind' to set up."))
+		fmt.Println(tui.ErrorStyle.Render(i18n.T("cmd.notLoggedIn")))
 		return nil
 	}
 
-	// TODO: Get quests from Convex and complete them
-	// For now, show placeholder
+	if doneAll {
+		if len(args) > 0 {
+			return fmt.Errorf("--all doesn't take a quest number")
+		}
+		return runDoneAll(cmd.Context(), cfg)
+	}
 
 	if len(args) == 0 {
 		fmt.Println(tui.MutedStyle.Render("No quests to complete. Add some with 'grind add \"task\"'"))
 		return nil
 	}
 
-	questNum, err := strconv.Atoi(args[0])
+	client := clientFor(cfg)
+	quest, err := loadQuest(cmd.Context(), client, cfg, questArg(args), isUnfinished)
 	if err != nil {
-		return fmt.Errorf("invalid quest number: %s", args[0])
+		fmt.Println(tui.ErrorStyle.Render(err.Error()))
+		return nil
 	}
 
-	// Placeholder completion animation
-	bar := tui.ProgressFullStyle.Render("████████████████████████████████")
-	fmt.Println(bar + " " + tui.SuccessStyle.Render("DONE"))
-	fmt.Println()
-	fmt.Printf(tui.XPStyle.Render("+%d XP")+" · completed quest #%d\n", 50, questNum)
+	if quest.Status == "completed" {
+		fmt.Println(tui.ErrorStyle.Render("Quest already completed."))
+		return nil
+	}
+	if quest.Status == "abandoned" {
+		fmt.Println(tui.ErrorStyle.Render("Quest was abandoned."))
+		return nil
+	}
+
+	// Big quests ask first, on a terminal, so a typo can't cash one in
+	if !doneYes && cfg.ConfirmsDone(quest.XP) && term.IsTerminal(os.Stdin.Fd()) {
+		if !confirm(cmd.Context(), fmt.Sprintf("Complete %q for +%d XP?", quest.Title, quest.XP)) {
+			fmt.Println(tui.MutedStyle.Render("Cancelled."))
+			return nil
+		}
+	}
+
+	ctx, cancel := requestContext(cmd.Context(), 10*time.Second)
+	defer cancel()
+	result, err := client.CompleteQuest(ctx, quest.ID)
+	if err != nil {
+		fmt.Println(tui.ErrorStyle.Render("Failed to complete quest: " + err.Error()))
+		return nil
+	}
+
+	showCompletion(result.XPEarned)
+	boosted := quest.XP
+	xpText := tui.XPStyle.Render(fmt.Sprintf("+%d XP", result.XPEarned))
+	if result.Multiplier > 1 {
+		boosted = api.Boost(quest.XP, result.Multiplier)
+		xpText += " " + tui.XPStyle.Render(components.Multiplier(result.Multiplier))
+	}
+	fmt.Printf("%s %s %s\n", xpText, components.Glyphs.Dot, quest.Title)
+	if result.Capped {
+		fmt.Println(tui.AlertStyle.Render(fmt.Sprintf("daily cap reached: earned %d of %d XP", result.XPEarned, boosted)))
+	}
+	if result.LeveledUp {
+		printLevelUp(result.NewLevel)
+	}
+	celebrateGoal(cmd.Context(), client, cfg)
 
 	return nil
 }
+
+// runDoneAll completes every unfinished quest after confirming. Quests are
+// completed one by one; failures are reported and the rest still count.
+func runDoneAll(parent context.Context, cfg *auth.Config) error {
+	client := clientFor(cfg)
+	ctx, cancel := requestContext(parent, 10*time.Second)
+	quests, err := client.ListTodayQuests(ctx, cfg.UserID)
+	cancel()
+	if err != nil {
+		fmt.Println(tui.ErrorStyle.Render("Failed to load quests: " + err.Error()))
+		return nil
+	}
+
+	pending := api.Unfinished(quests)
+	if len(pending) == 0 {
+		fmt.Println(tui.MutedStyle.Render("Nothing left to complete today."))
+		return nil
+	}
+
+	potential := 0
+	for _, q := range pending {
+		fmt.Printf("  %s %s\n", tui.MutedStyle.Render(fmt.Sprintf("%3d XP", q.XP)), q.Title)
+		potential += q.XP
+	}
+	if !doneYes && !confirm(parent, fmt.Sprintf("Complete all %d quests for +%d XP?", len(pending), potential)) {
+		fmt.Println(tui.MutedStyle.Render("Cancelled."))
+		return nil
+	}
+
+	earned, completed, capped := 0, 0, false
+	startLevel, endLevel := 0, 0
+	var failed []string
+	for _, q := range pending {
+		ctx, cancel := requestContext(parent, 10*time.Second)
+		result, err := client.CompleteQuest(ctx, q.ID)
+		cancel()
+		if err != nil {
+			if errors.Is(err, context.Canceled) {
+				fmt.Println(tui.MutedStyle.Render("Interrupted."))
+				break
+			}
+			failed = append(failed, fmt.Sprintf("%s: %v", q.Title, err))
+			continue
+		}
+		if completed == 0 {
+			startLevel = levels.GetLevel(result.NewTotalXP - result.XPEarned).Number
+		}
+		endLevel = result.NewLevel
+		earned += result.XPEarned
+		capped = capped || result.Capped
+		completed++
+	}
+
+	if completed > 0 {
+		showCompletion(earned)
+		fmt.Printf(tui.XPStyle.Render("+%d XP")+" %s completed %d of %d quests\n", earned, components.Glyphs.Dot, completed, len(pending))
+		if capped {
+			fmt.Println(tui.AlertStyle.Render("daily cap reached: some quests earned reduced XP"))
+		}
+		if endLevel > startLevel {
+			printLevelUp(endLevel)
+		}
+		celebrateGoal(parent, client, cfg)
+	}
+	for _, f := range failed {
+		fmt.Println(tui.ErrorStyle.Render(components.Glyphs.Cross + " " + f))
+	}
+	return nil
+}
+
+// confirm asks a yes/no question on stdin, defaulting to no. Ctrl-C while
+// waiting counts as no.
+func confirm(ctx context.Context, question string) bool {
+	answer, _ := askLine(ctx, question+" [y/N] ")
+	answer = strings.ToLower(answer)
+	return answer == "y" || answer == "yes"
+}
+
+// showCompletion draws the completion bar, animated when on a terminal
+func showCompletion(xp int) {
+	if doneNoAnimation || !term.IsTerminal(os.Stdout.Fd()) {
+		fmt.Println(tui.ProgressBar(completionBarWidth, completionBarWidth, completionBarWidth) + " " + tui.SuccessStyle.Render("DONE"))
+	} else {
+		animateCompletion(xp)
+	}
+	fmt.Println()
+}
+
+// printLevelUp announces a new level
+func printLevelUp(level int) {
+	l := levels.GetLevelByNumber(level)
+	fmt.Println(tui.LevelStyle.Render(fmt.Sprintf("%s LEVEL UP! Lvl %d: %s", components.Glyphs.LevelUp, l.Number, l.Name)))
+}
+
+const completionBarWidth = 32
+
+// animateCompletion fills the progress bar and counts up the XP over ~1s,
+// redrawing a single line in place
+func animateCompletion(xp int) {
+	const frames = 20
+	rate := components.XPTickRate(xp)
+	shown := 0
+
+	for frame := 1; frame <= frames; frame++ {
+		shown += rate
+		if shown > xp || frame == frames {
+			shown = xp
+		}
+		filled := completionBarWidth * frame / frames
+		bar := tui.ProgressBar(filled, completionBarWidth, completionBarWidth)
+		fmt.Printf("\r\033[K%s %s", bar, tui.XPStyle.Render(fmt.Sprintf("+%d XP", shown)))
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	bar := tui.ProgressBar(completionBarWidth, completionBarWidth, completionBarWidth)
+	fmt.Printf("\r\033[K%s %s\n", bar, tui.SuccessStyle.Render("DONE"))
+}
+
+func init() {
+	doneCmd.Flags().BoolVar(&doneNoAnimation, "no-animation", false, "Skip the completion animation")
+	doneCmd.Flags().BoolVar(&doneAll, "all", false, "Complete every unfinished quest for today")
+	doneCmd.Flags().BoolVarP(&doneYes, "yes", "y", false, "Skip confirmations (--all and high-XP quests)")
+}
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"grind/internal/api"
+	"grind/internal/auth"
+	"grind/internal/i18n"
+	"grind/internal/tui"
+	"grind/internal/tui/components"
+)
+
+var editCmd = &cobra.Command{
+	Use:   "edit <quest-number> [new title]",
+	Short: "Edit a quest",
+	Long: `Change a quest's title, note, or XP.
+
+Completed quests are left alone unless --force is given, and their XP
+can't be changed at all.
+
+Examples:
+  grind edit 2 "Fix the login redirect bug"   # Rename quest #2
+  grind edit 2 --note "repro in staging"      # Replace quest #2's note
+  grind edit 3 --xp 40                        # Set quest #3 to 40 XP`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runEdit,
+}
+
+var (
+	editNote  string
+	editXP    int
+	editForce bool
+)
+
+func runEdit(cmd *cobra.Command, args []string) error {
+	cfg, err := auth.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if !cfg.IsLoggedIn() {
+		fmt.Println(tui.ErrorStyle.Render(i18n.T("cmd.notLoggedIn")))
+		return nil
+	}
+
+	var update api.QuestUpdate
+	if len(args) == 2 {
+		title := strings.TrimSpace(args[1])
+		if title == "" {
+			return fmt.Errorf("title can't be empty")
+		}
+		update.Title = &title
+	}
+	if cmd.Flags().Changed("note") {
+		note := strings.TrimSpace(editNote)
+		update.Notes = &note
+	}
+	if cmd.Flags().Changed("xp") {
+		if err := api.ValidateQuestXP(editXP); err != nil {
+			return err
+		}
+		update.XP = &editXP
+	}
+	if update.Title == nil && update.Notes == nil && update.XP == nil {
+		return fmt.Errorf("nothing to change: give a new title, --note, or --xp")
+	}
+
+	client := clientFor(cfg)
+	quest, err := loadQuest(cmd.Context(), client, cfg, args[0], nil)
+	if err != nil {
+		fmt.Println(tui.ErrorStyle.Render(err.Error()))
+		return nil
+	}
+
+	ctx, cancel := requestContext(cmd.Context(), 10*time.Second)
+	defer cancel()
+
+	if !quest.IsOpen() {
+		if update.XP != nil {
+			fmt.Println(tui.ErrorStyle.Render("Can't change the XP of a " + quest.Status + " quest."))
+			return nil
+		}
+		if !editForce {
+			fmt.Println(tui.ErrorStyle.Render("Quest already " + quest.Status + ". Use --force to edit it anyway."))
+			return nil
+		}
+	}
+
+	if err := client.UpdateQuest(ctx, quest.ID, update); err != nil {
+		fmt.Println(tui.ErrorStyle.Render("Failed to update quest: " + err.Error()))
+		return nil
+	}
+
+	if update.Title != nil {
+		printChange("title", quest.Title, *update.Title)
+	}
+	if update.Notes != nil {
+		printChange("note", quest.Notes, *update.Notes)
+	}
+	if update.XP != nil {
+		printChange("xp", fmt.Sprintf("%d XP", quest.XP), fmt.Sprintf("%d XP", *update.XP))
+	}
+	return nil
+}
+
+// printChange shows one edited field as before → after
+func printChange(field, before, after string) {
+	if before == "" {
+		before = "(none)"
+	}
+	if after == "" {
+		after = "(none)"
+	}
+	fmt.Printf("%s %s %s %s\n",
+		tui.MutedStyle.Render(fmt.Sprintf("%-6s", field)),
+		tui.MutedStyle.Render(before),
+		tui.MutedStyle.Render(components.Glyphs.Arrow),
+		after)
+}
+
+func init() {
+	editCmd.Flags().StringVarP(&editNote, "note", "n", "", "Replace the quest's note (\"\" clears it)")
+	editCmd.Flags().IntVar(&editXP, "xp", 0, fmt.Sprintf("Set XP yourself (%d-%d)", api.MinQuestXP, api.MaxQuestXP))
+	editCmd.Flags().BoolVar(&editForce, "force", false, "Allow editing a completed quest")
+}
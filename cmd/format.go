@@ -0,0 +1,24 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/template"
+)
+
+// printFormatted renders each item with a --format Go template, one line
+// per item, e.g. '{{.Title}} {{.XP}}'. It stops at the first item the
+// template fails on, such as one naming a field that doesn't exist.
+func printFormatted[T any](format string, items []T) error {
+	tmpl, err := template.New("format").Option("missingkey=error").Parse(format)
+	if err != nil {
+		return fmt.Errorf("invalid --format: %w", err)
+	}
+	for _, item := range items {
+		if err := tmpl.Execute(os.Stdout, item); err != nil {
+			return fmt.Errorf("invalid --format: %w", err)
+		}
+		fmt.Println()
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"grind/internal/api"
+	"grind/internal/auth"
+	"grind/internal/goals"
+	"grind/internal/i18n"
+	"grind/internal/tui"
+	"grind/internal/tui/components"
+)
+
+var goalCmd = &cobra.Command{
+	Use:   "goal",
+	Short: "Show or set your weekly XP goal",
+	Long: `Show progress toward your weekly XP goal, or change it.
+
+The week runs Monday to Sunday, same as the weekly leaderboard.
+
+Examples:
+  grind goal             # Show this week's progress
+  grind goal set 500     # Aim for 500 XP a week
+  grind goal clear       # Remove the goal`,
+	Args: cobra.NoArgs,
+	RunE: runGoal,
+}
+
+var goalSetCmd = &cobra.Command{
+	Use:   "set <xp>",
+	Short: "Set your weekly XP goal",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runGoalSet,
+}
+
+var goalClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Remove your weekly XP goal",
+	Args:  cobra.NoArgs,
+	RunE:  runGoalClear,
+}
+
+func runGoal(cmd *cobra.Command, args []string) error {
+	cfg, err := auth.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if !cfg.IsLoggedIn() {
+		fmt.Println(tui.ErrorStyle.Render(i18n.T("cmd.notLoggedIn")))
+		return nil
+	}
+
+	if cfg.WeeklyGoal <= 0 {
+		fmt.Println(tui.MutedStyle.Render("No weekly goal set. Try 'grind goal set 500'."))
+		return nil
+	}
+
+	client := clientFor(cfg)
+	ctx, cancel := requestContext(cmd.Context(), 10*time.Second)
+	defer cancel()
+
+	stats, err := client.GetStats(ctx, cfg.UserID)
+	if err != nil {
+		fmt.Println(tui.ErrorStyle.Render("Failed to load stats: " + err.Error()))
+		return nil
+	}
+	weeklyXP := 0
+	if stats != nil {
+		weeklyXP = stats.Week.XP
+	}
+
+	fmt.Println(renderGoal(weeklyXP, cfg.WeeklyGoal, time.Now()))
+
+	if cfg.ClaimGoalCelebration(weeklyXP, goals.WeekKey(time.Now())) {
+		_ = auth.Save(cfg)
+	}
+	return nil
+}
+
+// renderGoal renders "[████▒▒▒▒] 320 / 500 XP this week" plus a status line
+func renderGoal(weeklyXP, goal int, now time.Time) string {
+	bar := tui.ProgressBarBracketed(weeklyXP, goal, 30)
+	line := fmt.Sprintf("%s %d / %d XP this week", bar, weeklyXP, goal)
+
+	var status string
+	if weeklyXP >= goal {
+		status = tui.SuccessStyle.Render(components.Glyphs.Goal + "goal hit! anything more is bonus.")
+	} else if behind := goals.Behind(weeklyXP, goal, now); behind > 0 {
+		status = tui.AlertStyle.Render(fmt.Sprintf("%d XP behind pace. one solid quest closes the gap.", behind))
+	} else {
+		status = tui.MutedStyle.Render(fmt.Sprintf("on pace %s %d XP to go", components.Glyphs.Dot, goal-weeklyXP))
+	}
+	return line + "\n" + status
+}
+
+// celebrateGoal prints a one-time message the first time this week's XP
+// reaches the weekly goal
+func celebrateGoal(parent context.Context, client *api.Client, cfg *auth.Config) {
+	if cfg.WeeklyGoal <= 0 {
+		return
+	}
+
+	ctx, cancel := requestContext(parent, 10*time.Second)
+	defer cancel()
+
+	stats, err := client.GetStats(ctx, cfg.UserID)
+	if err != nil || stats == nil {
+		return
+	}
+	if cfg.ClaimGoalCelebration(stats.Week.XP, goals.WeekKey(time.Now())) {
+		_ = auth.Save(cfg)
+		fmt.Println(tui.SuccessStyle.Render(fmt.Sprintf("%sweekly goal hit! %d / %d XP", components.Glyphs.Goal, stats.Week.XP, cfg.WeeklyGoal)))
+	}
+}
+
+func runGoalSet(cmd *cobra.Command, args []string) error {
+	cfg, err := auth.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	goal, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid goal: %s", args[0])
+	}
+	if err := goals.Validate(goal); err != nil {
+		return err
+	}
+
+	cfg.WeeklyGoal = goal
+	cfg.GoalHitWeek = "" // A new goal can be celebrated again this week
+	if err := auth.Save(cfg); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	fmt.Println(tui.SuccessStyle.Render(fmt.Sprintf("%s weekly goal = %d XP", components.Glyphs.Check, goal)))
+	return nil
+}
+
+func runGoalClear(cmd *cobra.Command, args []string) error {
+	cfg, err := auth.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	cfg.WeeklyGoal = 0
+	cfg.GoalHitWeek = ""
+	if err := auth.Save(cfg); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	fmt.Println(tui.MutedStyle.Render("weekly goal cleared"))
+	return nil
+}
+
+func init() {
+	goalCmd.AddCommand(goalSetCmd)
+	goalCmd.AddCommand(goalClearCmd)
+}
@@ -0,0 +1,433 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/spf13/cobra"
+
+	"grind/internal/api"
+	"grind/internal/auth"
+	"grind/internal/i18n"
+	"grind/internal/levels"
+	"grind/internal/tui"
+	"grind/internal/tui/components"
+)
+
+var (
+	groupCodeOnly  bool
+	groupSwitchYes bool
+	groupLeaveYes  bool
+)
+
+var groupCmd = &cobra.Command{
+	Use:   "group",
+	Short: "Manage your crew",
+}
+
+var groupInviteCmd = &cobra.Command{
+	Use:   "invite",
+	Short: "Print a copy-paste invite to your crew",
+	Long: `Print your crew's invite code along with a ready-to-paste message
+telling a friend how to join.
+
+Examples:
+  grind group invite          # Code plus a message for chat
+  grind group invite --code   # Just the code, for scripts`,
+	Args: cobra.NoArgs,
+	RunE: runGroupInvite,
+}
+
+func runGroupInvite(cmd *cobra.Command, args []string) error {
+	cfg, err := auth.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if !cfg.IsLoggedIn() {
+		fmt.Println(tui.ErrorStyle.Render(i18n.T("cmd.notLoggedIn")))
+		return nil
+	}
+
+	if !cfg.HasGroup() {
+		fmt.Println(tui.ErrorStyle.Render(i18n.T("cmd.noGroup")))
+		return nil
+	}
+
+	client := clientFor(cfg)
+	ctx, cancel := requestContext(cmd.Context(), 10*time.Second)
+	defer cancel()
+
+	group, err := client.GetGroup(ctx, cfg.GroupID)
+	if err != nil {
+		fmt.Println(tui.ErrorStyle.Render("Failed to load crew: " + err.Error()))
+		return nil
+	}
+	if group == nil {
+		_, err := forgetMissingGroup(cmd.Context(), cfg, client)
+		return err
+	}
+
+	if groupCodeOnly {
+		fmt.Println(group.InviteCode)
+		return nil
+	}
+
+	fmt.Println(tui.MutedStyle.Render("invite code: ") + tui.XPStyle.Render(group.InviteCode))
+	if group.MaxMembers > 0 {
+		// Only warn when the crew is known to be full; a failed lookup
+		// shouldn't block the invite
+		if members, err := client.GetMembers(ctx, cfg.GroupID); err == nil && len(members) >= group.MaxMembers {
+			fmt.Println(tui.ErrorStyle.Render(fmt.Sprintf("Your crew is full (%d/%d) - no one else can join.", len(members), group.MaxMembers)))
+			return nil
+		}
+	}
+	fmt.Println(tui.MutedStyle.Render("paste this to a friend:"))
+	fmt.Println()
+	fmt.Println(components.InviteMessage(group.Name, group.InviteCode))
+	return nil
+}
+
+var groupSwitchCmd = &cobra.Command{
+	Use:   "switch <invite-code>",
+	Short: "Move to another crew without leaving first",
+	Long: `Leave your crew and join the one with the invite code in one step.
+
+The code is checked before anything changes, and the move happens all at
+once: if the new crew can't take you, you stay in your current one.
+
+Examples:
+  grind group switch XYZ-789
+  grind group switch xyz789 --yes   # Don't ask first`,
+	Args: cobra.ExactArgs(1),
+	RunE: runGroupSwitch,
+}
+
+func runGroupSwitch(cmd *cobra.Command, args []string) error {
+	cfg, err := auth.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if !cfg.IsLoggedIn() {
+		fmt.Println(tui.ErrorStyle.Render(i18n.T("cmd.notLoggedIn")))
+		return nil
+	}
+
+	if !cfg.HasGroup() {
+		fmt.Println(tui.ErrorStyle.Render("Not in a crew yet - use 'grind join " + args[0] + "' instead."))
+		return nil
+	}
+
+	code, err := api.ValidateInviteCode(args[0])
+	if err != nil {
+		fmt.Println(tui.ErrorStyle.Render(fmt.Sprintf("Invalid invite code %q - codes look like ABC-123.", args[0])))
+		return nil
+	}
+
+	client := clientFor(cfg)
+	ctx, cancel := requestContext(cmd.Context(), 10*time.Second)
+	defer cancel()
+
+	// Check the code before leaving anything
+	group, err := client.GetGroupByInviteCode(ctx, code)
+	if err != nil {
+		fmt.Println(tui.ErrorStyle.Render("Failed to look up crew: " + err.Error()))
+		return nil
+	}
+	if group == nil {
+		fmt.Println(tui.ErrorStyle.Render(fmt.Sprintf("No crew has the invite code %s.", code)))
+		return nil
+	}
+	if group.ID == cfg.GroupID {
+		fmt.Println(tui.MutedStyle.Render("Already in " + group.Name + "."))
+		return nil
+	}
+
+	if !groupSwitchYes {
+		printLeavePreview(ctx, cfg, client)
+	}
+	if !groupSwitchYes && !confirm(cmd.Context(), fmt.Sprintf("Leave %s and join %s?", cfg.GroupName, group.Name)) {
+		fmt.Println(tui.MutedStyle.Render("cancelled."))
+		return nil
+	}
+
+	fmt.Print(tui.MutedStyle.Render("  switching..."))
+	groupID, groupName, err := client.SwitchGroup(ctx, cfg.UserID, code)
+
+	// Clear line
+	fmt.Print("\r\033[K")
+
+	if errors.Is(err, api.ErrGroupFull) {
+		fmt.Println(tui.ErrorStyle.Render(group.Name + " is full - you're still in " + cfg.GroupName + "."))
+		return nil
+	}
+	if err != nil {
+		fmt.Println(tui.ErrorStyle.Render("Failed to switch: " + err.Error() + " - you're still in " + cfg.GroupName + "."))
+		return nil
+	}
+
+	// Save to config
+	cfg.SetGroup(groupID, groupName)
+	if err := auth.Save(cfg); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	fmt.Println(tui.SuccessStyle.Render(components.Glyphs.Check + " switched to " + cfg.GroupName))
+	return nil
+}
+
+var groupLeaveCmd = &cobra.Command{
+	Use:   "leave",
+	Short: "Leave your crew",
+	Long: `Leave your crew, after showing what changes.
+
+Your total XP and level stay with you. Your spot on the crew's weekly
+leaderboard doesn't: you drop off it, and rejoining later starts you at
+the bottom of this week's board.
+
+Examples:
+  grind group leave
+  grind group leave --yes   # Don't ask first`,
+	Args: cobra.NoArgs,
+	RunE: runGroupLeave,
+}
+
+func runGroupLeave(cmd *cobra.Command, args []string) error {
+	cfg, err := auth.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if !cfg.IsLoggedIn() {
+		fmt.Println(tui.ErrorStyle.Render(i18n.T("cmd.notLoggedIn")))
+		return nil
+	}
+
+	if !cfg.HasGroup() {
+		fmt.Println(tui.MutedStyle.Render("Not in a crew."))
+		return nil
+	}
+
+	client := clientFor(cfg)
+	ctx, cancel := requestContext(cmd.Context(), 10*time.Second)
+	defer cancel()
+
+	if !groupLeaveYes {
+		printLeavePreview(ctx, cfg, client)
+		if !confirm(cmd.Context(), fmt.Sprintf("Leave %s?", cfg.GroupName)) {
+			fmt.Println(tui.MutedStyle.Render("cancelled."))
+			return nil
+		}
+	}
+
+	if err := client.LeaveGroup(ctx, cfg.UserID); err != nil {
+		fmt.Println(tui.ErrorStyle.Render("Failed to leave: " + err.Error()))
+		return nil
+	}
+
+	crew := cfg.GroupName
+	cfg.ClearGroup()
+	if err := auth.Save(cfg); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	fmt.Println(tui.SuccessStyle.Render(components.Glyphs.Check + " left " + crew + ". join a crew with 'grind join <code>'"))
+	return nil
+}
+
+// printLeavePreview shows what leaving the crew costs: the weekly standing
+// goes, the total XP and level stay. Standing is left out if the board
+// can't be loaded.
+func printLeavePreview(ctx context.Context, cfg *auth.Config, client *api.Client) {
+	fmt.Println(tui.MutedStyle.Render("leaving ") + tui.XPStyle.Render(cfg.GroupName))
+
+	totalXP := cfg.TotalXP
+	if entries, err := client.GetLeaderboard(ctx, cfg.GroupID, false); err == nil {
+		for _, e := range entries {
+			if e.UserID != cfg.UserID {
+				continue
+			}
+			totalXP = e.TotalXP
+			fmt.Printf("  %s weekly rank #%d of %d (%s XP this week) - you drop off this board\n",
+				tui.ErrorStyle.Render(components.Glyphs.Cross), e.Rank, len(entries), i18n.Number(e.WeeklyXP))
+			break
+		}
+	}
+
+	l := levels.GetLevel(totalXP)
+	fmt.Printf("  %s total XP stays: %s XP, Lvl %d: %s\n",
+		tui.SuccessStyle.Render(components.Glyphs.Check), i18n.Number(totalXP), l.Number, l.Name)
+	fmt.Println()
+}
+
+// forgetMissingGroup offers to leave a crew that was deleted while the
+// config still points at it, which otherwise fails every crew command. It
+// reports whether the crew was cleared.
+func forgetMissingGroup(parent context.Context, cfg *auth.Config, client *api.Client) (bool, error) {
+	answer, ok := askLine(parent, tui.ErrorStyle.Render("your crew no longer exists - leave it?")+" [Y/n] ")
+	if !ok || (answer != "" && !strings.HasPrefix(strings.ToLower(answer), "y")) {
+		fmt.Println(tui.MutedStyle.Render("kept it. crew commands will keep failing until you leave it."))
+		return false, nil
+	}
+
+	ctx, cancel := requestContext(parent, 10*time.Second)
+	defer cancel()
+	if err := client.LeaveGroup(ctx, cfg.UserID); err != nil {
+		fmt.Println(tui.ErrorStyle.Render("Failed to leave: " + err.Error()))
+		return false, nil
+	}
+
+	cfg.ClearGroup()
+	if err := auth.Save(cfg); err != nil {
+		return false, fmt.Errorf("failed to save config: %w", err)
+	}
+	fmt.Println(tui.SuccessStyle.Render(components.Glyphs.Check + " left. join a crew with 'grind join <code>'"))
+	return true, nil
+}
+
+var groupLevelsCmd = &cobra.Command{
+	Use:   "levels",
+	Short: "Show or rename your crew's levels",
+	Long: `Show the level names your crew sees, with the XP each one takes.
+
+A crew can give its levels its own names - a running crew might go from
+"Couch Potato" to "Iron Beast". Only the names change: thresholds and XP
+stay the same. Names are given in order from level 1; fewer than ten
+keeps the default names for the rest, and "" keeps one in the middle.
+Only the crew's creator can rename levels.
+
+Examples:
+  grind group levels                                  # List the levels
+  grind group levels set "Couch Potato" Jogger Racer  # Rename levels 1-3
+  grind group levels reset                            # Back to the defaults`,
+	Args: cobra.NoArgs,
+	RunE: runGroupLevels,
+}
+
+var groupLevelsSetCmd = &cobra.Command{
+	Use:   "set <name>...",
+	Short: "Rename the crew's levels, starting at level 1",
+	Args:  cobra.RangeArgs(1, len(levels.Levels)),
+	RunE:  runGroupLevelsSet,
+}
+
+var groupLevelsResetCmd = &cobra.Command{
+	Use:   "reset",
+	Short: "Restore the default level names",
+	Args:  cobra.NoArgs,
+	RunE:  runGroupLevelsReset,
+}
+
+func runGroupLevels(cmd *cobra.Command, args []string) error {
+	cfg, err := auth.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	// Refresh the cached names when the crew can be reached; the list
+	// below still works from the cache when it can't
+	if cfg.IsLoggedIn() && cfg.HasGroup() && !cfg.Local {
+		client := clientFor(cfg)
+		ctx, cancel := requestContext(cmd.Context(), 10*time.Second)
+		defer cancel()
+
+		if group, err := client.GetGroup(ctx, cfg.GroupID); err == nil && group != nil {
+			if err := cacheLevelNames(cfg, group.LevelNames); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, l := range levels.Levels {
+		name := levels.GetLevelByNumber(l.Number).Name
+		line := fmt.Sprintf("%2d  %s %5d XP", l.Number, components.PadName(name, api.MaxLevelNameLength), l.MinXP)
+		if name != l.Name {
+			line += tui.MutedStyle.Render("  (" + l.Name + ")")
+		}
+		fmt.Println(line)
+	}
+	return nil
+}
+
+func runGroupLevelsSet(cmd *cobra.Command, args []string) error {
+	names := make([]string, len(args))
+	for i, name := range args {
+		names[i] = strings.TrimSpace(name)
+		if utf8.RuneCountInString(names[i]) > api.MaxLevelNameLength {
+			return fmt.Errorf("level names must be at most %d characters: %q", api.MaxLevelNameLength, name)
+		}
+	}
+	return setLevelNames(cmd, names)
+}
+
+func runGroupLevelsReset(cmd *cobra.Command, args []string) error {
+	return setLevelNames(cmd, nil)
+}
+
+// setLevelNames renames the crew's levels; nil restores the defaults
+func setLevelNames(cmd *cobra.Command, names []string) error {
+	cfg, err := auth.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if !cfg.IsLoggedIn() {
+		fmt.Println(tui.ErrorStyle.Render(i18n.T("cmd.notLoggedIn")))
+		return nil
+	}
+	if !cfg.HasGroup() {
+		fmt.Println(tui.ErrorStyle.Render(auth.ErrNoGroup.Error()))
+		return nil
+	}
+
+	client := clientFor(cfg)
+	ctx, cancel := requestContext(cmd.Context(), 10*time.Second)
+	defer cancel()
+
+	if err := client.SetLevelNames(ctx, cfg.GroupID, cfg.UserID, names); err != nil {
+		fmt.Println(tui.ErrorStyle.Render("Failed to rename levels: " + err.Error()))
+		return nil
+	}
+	if err := cacheLevelNames(cfg, names); err != nil {
+		return err
+	}
+
+	if names == nil {
+		fmt.Println(tui.MutedStyle.Render("level names reset"))
+	} else {
+		fmt.Println(tui.SuccessStyle.Render(components.Glyphs.Check + " renamed " + i18n.Plural("plural.level", len(names))))
+	}
+	return nil
+}
+
+// cacheLevelNames saves the crew's level names to the config, so every
+// command shows them, and uses them for this one
+func cacheLevelNames(cfg *auth.Config, names []string) error {
+	levels.SetNames(names)
+	if slices.Equal(cfg.LevelNames, names) {
+		return nil
+	}
+	cfg.LevelNames = names
+	if err := auth.Save(cfg); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+	return nil
+}
+
+func init() {
+	groupInviteCmd.Flags().BoolVar(&groupCodeOnly, "code", false, "Print only the invite code")
+	groupSwitchCmd.Flags().BoolVarP(&groupSwitchYes, "yes", "y", false, "Switch without asking")
+	groupLeaveCmd.Flags().BoolVarP(&groupLeaveYes, "yes", "y", false, "Leave without asking")
+	groupCmd.AddCommand(groupInviteCmd)
+	groupCmd.AddCommand(groupSwitchCmd)
+	groupCmd.AddCommand(groupLeaveCmd)
+	groupLevelsCmd.AddCommand(groupLevelsSetCmd)
+	groupLevelsCmd.AddCommand(groupLevelsResetCmd)
+	groupCmd.AddCommand(groupLevelsCmd)
+}
@@ -0,0 +1,230 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"grind/internal/api"
+	"grind/internal/auth"
+	"grind/internal/i18n"
+	"grind/internal/tui"
+	"grind/internal/tui/components"
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Add quests from a markdown checklist",
+	Long: `Add a quest for every unchecked "- [ ] task" line in a markdown file.
+Each one is evaluated by the AI (or the local estimate in local mode),
+like 'grind add'.
+
+Items that match a quest you already have open, or one from today, are
+skipped, so importing the same file twice doesn't double up. Checked
+"- [x]" items are ignored unless --done is given, which adds them as
+completed quests.
+
+Examples:
+  grind import TODO.md
+  grind import TODO.md --dry-run     # Show what would be added
+  grind import notes.md --done       # Also credit the ticked-off items
+  grind import TODO.md --xp 20       # Skip the AI and give each 20 XP`,
+	Args: cobra.ExactArgs(1),
+	RunE: runImport,
+}
+
+var (
+	importDryRun bool
+	importDone   bool
+	importXP     int
+)
+
+// checklistLine matches a markdown task list item: "- [ ] task",
+// "* [x] task" or "1. [ ] task", indented or not
+var checklistLine = regexp.MustCompile(`^\s*(?:[-*+]|\d+[.)])\s+\[([ xX])\]\s+(.+)$`)
+
+// checklistItem is one task parsed from a checklist
+type checklistItem struct {
+	Title string
+	Done  bool
+}
+
+// parseChecklist returns the task list items in a markdown document, in
+// order. Other lines, and items with no text, are ignored.
+func parseChecklist(data []byte) []checklistItem {
+	var items []checklistItem
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		m := checklistLine.FindStringSubmatch(scanner.Text())
+		if m == nil {
+			continue
+		}
+		title := strings.Join(strings.Fields(m[2]), " ")
+		if title == "" {
+			continue
+		}
+		items = append(items, checklistItem{Title: title, Done: m[1] != " "})
+	}
+	return items
+}
+
+// titleKey normalizes a title for duplicate checks
+func titleKey(title string) string {
+	return strings.ToLower(strings.Join(strings.Fields(title), " "))
+}
+
+func runImport(cmd *cobra.Command, args []string) error {
+	cfg, err := auth.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if !cfg.IsLoggedIn() {
+		fmt.Println(tui.ErrorStyle.Render(i18n.T("cmd.notLoggedIn")))
+		return nil
+	}
+
+	manual := cmd.Flags().Changed("xp")
+	if manual {
+		if err := api.ValidateQuestXP(importXP); err != nil {
+			return fmt.Errorf("--xp: %w", err)
+		}
+	}
+
+	data, err := os.ReadFile(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", args[0], err)
+	}
+	items := parseChecklist(data)
+	if len(items) == 0 {
+		fmt.Println(tui.MutedStyle.Render("no \"- [ ] task\" items in " + args[0] + "."))
+		return nil
+	}
+
+	existing, err := existingTitles(cmd.Context(), cfg)
+	if err != nil {
+		fmt.Println(tui.ErrorStyle.Render("Failed to load quests: " + err.Error()))
+		return nil
+	}
+
+	// Work out what to add before touching anything, so --dry-run shows
+	// exactly what a real run would do
+	var todo []checklistItem
+	skipped := 0
+	for _, item := range items {
+		key := titleKey(item.Title)
+		switch {
+		case item.Done && !importDone:
+			skipped++
+		case existing[key]:
+			fmt.Println(tui.MutedStyle.Render("= " + item.Title + " (already a quest)"))
+			skipped++
+		default:
+			existing[key] = true // Later copies in the file are duplicates too
+			todo = append(todo, item)
+		}
+	}
+
+	if importDryRun {
+		for _, item := range todo {
+			if item.Done {
+				fmt.Println(components.Glyphs.Check + " " + item.Title + tui.MutedStyle.Render(" (completed)"))
+			} else {
+				fmt.Println("+ " + item.Title)
+			}
+		}
+		fmt.Println(tui.MutedStyle.Render(fmt.Sprintf("\nwould add %d quests, skip %d. nothing saved (--dry-run).", len(todo), skipped)))
+		return nil
+	}
+
+	added, total := 0, 0
+	for _, item := range todo {
+		xp, reasoning := importXP, api.ManualXPReasoning
+		var err error
+		if !manual {
+			fmt.Print(tui.MutedStyle.Render("  " + components.Glyphs.Spinner + " evaluating " + item.Title + "..."))
+			xp, reasoning, err = evaluateQuestWithAI(cmd.Context(), cfg, item.Title)
+		}
+		var questID string
+		if err == nil {
+			questID, err = createQuest(cmd.Context(), cfg, item.Title, "", xp, reasoning, nil)
+		}
+		if err == nil && item.Done {
+			err = completeImported(cmd.Context(), cfg, questID)
+		}
+		fmt.Print("\r\033[K")
+
+		if errors.Is(err, context.Canceled) {
+			fmt.Println(tui.MutedStyle.Render("cancelled."))
+			break
+		}
+		if err != nil {
+			fmt.Println(tui.ErrorStyle.Render(fmt.Sprintf("%s %s: %v", components.Glyphs.Cross, item.Title, err)))
+			continue
+		}
+		mark := ""
+		if item.Done {
+			mark = components.Glyphs.Check + " "
+		}
+		fmt.Printf("%s"+tui.XPStyle.Render("+%d XP")+" %s %s\n", mark, xp, components.Glyphs.Dot, item.Title)
+		added++
+		total += xp
+	}
+
+	fmt.Println(tui.MutedStyle.Render(fmt.Sprintf("\nadded %d of %d quests from %s, skipped %d (+%d XP). grind on.", added, len(todo), args[0], skipped, total)))
+	return nil
+}
+
+// existingTitles returns the normalized titles an import shouldn't repeat:
+// every quest that's still open, and everything from today
+func existingTitles(parent context.Context, cfg *auth.Config) (map[string]bool, error) {
+	client := clientFor(cfg)
+	ctx, cancel := requestContext(parent, 10*time.Second)
+	defer cancel()
+
+	// Open quests by status, so an import doesn't read the whole history
+	var quests []api.Quest
+	for _, status := range []string{"pending", "in_progress"} {
+		open, err := client.ListQuests(ctx, cfg.UserID, api.ListOptions{Status: status})
+		if err != nil {
+			return nil, err
+		}
+		quests = append(quests, open...)
+	}
+	today, err := client.ListTodayQuests(ctx, cfg.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	titles := map[string]bool{}
+	for _, q := range append(quests, today...) {
+		titles[titleKey(q.Title)] = true
+	}
+	return titles, nil
+}
+
+// completeImported completes a quest imported from a ticked item
+func completeImported(parent context.Context, cfg *auth.Config, questID string) error {
+	if questID == "" {
+		return fmt.Errorf("quest was added but can't be completed: no ID returned")
+	}
+	ctx, cancel := requestContext(parent, 10*time.Second)
+	defer cancel()
+
+	_, err := clientFor(cfg).CompleteQuest(ctx, questID)
+	return err
+}
+
+func init() {
+	importCmd.Flags().BoolVar(&importDryRun, "dry-run", false, "Show what would be added without saving")
+	importCmd.Flags().BoolVar(&importDone, "done", false, "Also add checked items, as completed quests")
+	importCmd.Flags().IntVar(&importXP, "xp", 0, fmt.Sprintf("Give every quest this XP (%d-%d) instead of asking the AI", api.MinQuestXP, api.MaxQuestXP))
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"grind/internal/api"
+	"grind/internal/api/apitest"
+	"grind/internal/auth"
+)
+
+func TestParseChecklist(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+		want []checklistItem
+	}{
+		{"dash", "- [ ] ship it", []checklistItem{{"ship it", false}}},
+		{"star and plus", "* [ ] one\n+ [ ] two", []checklistItem{{"one", false}, {"two", false}}},
+		{"ticked", "- [x] done\n- [X] also done", []checklistItem{{"done", true}, {"also done", true}}},
+		{"numbered", "1. [ ] first\n2) [x] second", []checklistItem{{"first", false}, {"second", true}}},
+		{"indented", "- [ ] parent\n  - [ ] child\n\t- [x] tabbed", []checklistItem{{"parent", false}, {"child", false}, {"tabbed", true}}},
+		{"spaces collapsed", "- [ ]   ship   the  thing  ", []checklistItem{{"ship the thing", false}}},
+		{"CRLF", "- [ ] one\r\n- [ ] two\r\n", []checklistItem{{"one", false}, {"two", false}}},
+		{"plain bullets and prose ignored", "# Plan\n- not a task\nsome text\n- [ ] real", []checklistItem{{"real", false}}},
+		{"no text", "- [ ] \n- [ ]", nil},
+		{"no space after marker", "-[ ] cramped\n- [ ]cramped", nil},
+		{"other box", "- [-] skipped", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseChecklist([]byte(tt.doc)); !slices.Equal(got, tt.want) {
+				t.Errorf("parseChecklist(%q) = %+v, want %+v", tt.doc, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExistingTitles(t *testing.T) {
+	fake := apitest.NewFake()
+	fake.On("quests:list", func(args map[string]any) (any, error) {
+		switch args["status"] {
+		case "pending":
+			return []any{map[string]any{"title": "Ship  It", "status": "pending"}}, nil
+		case "in_progress":
+			return []any{map[string]any{"title": "review", "status": "in_progress"}}, nil
+		}
+		t.Errorf("quests:list without a status: %v", args)
+		return nil, nil
+	})
+	fake.Return("quests:listToday", []api.Quest{{Title: "Ran 5k", Status: "completed"}})
+	backend := newBackend
+	newBackend = func(string) api.ConvexAPI { return fake }
+	defer func() { newBackend = backend }()
+
+	titles, err := existingTitles(context.Background(), &auth.Config{UserID: "u1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"ship it", "review", "ran 5k"} {
+		if !titles[want] {
+			t.Errorf("titles = %v, missing %q", titles, want)
+		}
+	}
+}
@@ -1,13 +1,16 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
-	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"grind/internal/api"
 	"grind/internal/auth"
 	"grind/internal/tui"
+	"grind/internal/tui/components"
 )
 
 var joinCmd = &cobra.Command{
@@ -36,38 +39,52 @@ func runJoin(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	if cfg.HasGroup() {
-		fmt.Println(tui.ErrorStyle.Render("Already in a group: " + cfg.GroupName))
+	code, err := api.ValidateInviteCode(args[0])
+	if err != nil {
+		fmt.Println(tui.ErrorStyle.Render(fmt.Sprintf("Invalid invite code %q - codes look like ABC-123.", args[0])))
 		return nil
 	}
 
-	code := strings.ToUpper(strings.TrimSpace(args[0]))
+	client := clientFor(cfg)
+	ctx, cancel := requestContext(cmd.Context(), 10*time.Second)
+	defer cancel()
 
-	// Normalize code format (remove dashes, then add back)
-	code = strings.ReplaceAll(code, "-", "")
-	if len(code) == 6 {
-		code = code[:3] + "-" + code[3:]
+	if cfg.HasGroup() {
+		// A crew deleted out from under the user shouldn't block joining
+		// another
+		group, err := client.GetGroup(ctx, cfg.GroupID)
+		if err != nil || group != nil {
+			fmt.Println(tui.ErrorStyle.Render("Already in a group: " + cfg.GroupName + " - use 'grind group switch <code>' to move."))
+			return nil
+		}
+		if left, err := forgetMissingGroup(cmd.Context(), cfg, client); !left {
+			return err
+		}
 	}
 
-	// TODO: Validate with Convex backend
-	// For now, accept any code and save locally
-
 	fmt.Print(tui.MutedStyle.Render("  joining..."))
 
-	// Simulate API call delay
-	// time.Sleep(500 * time.Millisecond)
+	groupID, groupName, err := client.JoinGroup(ctx, cfg.UserID, code)
 
 	// Clear line
 	fmt.Print("\r\033[K")
 
+	if errors.Is(err, api.ErrGroupFull) {
+		fmt.Println(tui.ErrorStyle.Render("That crew is full - try another invite code."))
+		return nil
+	}
+	if err != nil {
+		fmt.Println(tui.ErrorStyle.Render("Failed to join: " + err.Error()))
+		return nil
+	}
+
 	// Save to config
-	cfg.GroupID = "group_" + code
-	cfg.GroupName = "Group " + code
+	cfg.SetGroup(groupID, groupName)
 	if err := auth.Save(cfg); err != nil {
 		return fmt.Errorf("failed to save config: %w", err)
 	}
 
-	fmt.Println(tui.SuccessStyle.Render("✓ joined " + cfg.GroupName))
+	fmt.Println(tui.SuccessStyle.Render(components.Glyphs.Check + " joined " + cfg.GroupName))
 	fmt.Println()
 	fmt.Println(tui.MutedStyle.Render("run 'grind' to start competing!"))
 
@@ -2,11 +2,17 @@ package cmd
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"grind/internal/api"
 	"grind/internal/auth"
+	"grind/internal/i18n"
 	"grind/internal/tui"
+	"grind/internal/tui/components"
 )
 
 var lsCmd = &cobra.Command{
@@ -14,13 +20,38 @@ var lsCmd = &cobra.Command{
 	Short: "List today's quests",
 	Long: `Show all pending and completed quests for today.
 
+--format takes a Go template applied to each quest. Fields:
+  .Number .Title .Notes .XP .Status .AIReasoning .CreatedAt .CompletedAt
+(.Number is the quest's number for 'grind done', today's list only;
+times are Unix milliseconds)
+
+At most --limit quests are shown (0 for no limit). --since takes a
+duration back from now, like 36h or 7d, or a date like 2026-10-01, and
+implies --all.
+
 Examples:
   grind ls           # List all today's quests
-  grind ls --all     # List all quests (not just today)`,
+  grind ls --all     # List all quests (not just today)
+  grind ls --since 7d --limit 50
+  grind ls --format '{{.Number}} {{.Title}} {{.XP}}'`,
 	RunE: runLs,
 }
 
-var lsAll bool
+var (
+	lsAll    bool
+	lsFormat string
+	lsLimit  int
+	lsSince  string
+)
+
+// defaultLsLimit is how many quests grind ls shows unless told otherwise
+const defaultLsLimit = 20
+
+// lsItem is a quest as exposed to --format
+type lsItem struct {
+	Number int
+	api.Quest
+}
 
 func runLs(cmd *cobra.Command, args []string) error {
 	cfg, err := auth.Load()
@@ -29,12 +60,61 @@ func runLs(cmd *cobra.Command, args []string) error {
 	}
 
 	if !cfg.IsLoggedIn() {
-		fmt.Println(tui.ErrorStyle.Render("Not logged in. Run 'grind' to set up."))
+		fmt.Println(tui.ErrorStyle.Render(i18n.T("cmd.notLoggedIn")))
 		return nil
 	}
 
-	// TODO: Fetch quests from Convex
-	// For now, show placeholder
+	if lsLimit < 0 {
+		return fmt.Errorf("--limit can't be negative")
+	}
+	var opts api.ListOptions
+	if lsSince != "" {
+		since, err := parseSince(lsSince, time.Now().In(cfg.Location()))
+		if err != nil {
+			return err
+		}
+		opts.Since = since.UnixMilli()
+		lsAll = true
+	}
+
+	client := clientFor(cfg)
+	ctx, cancel := requestContext(cmd.Context(), 10*time.Second)
+	defer cancel()
+
+	var quests []api.Quest
+	if lsAll {
+		// Ask for one past the limit to know whether there's more
+		if lsLimit > 0 {
+			opts.Limit = lsLimit + 1
+		}
+		quests, err = client.ListQuests(ctx, cfg.UserID, opts)
+	} else {
+		quests, err = client.ListTodayQuests(ctx, cfg.UserID)
+	}
+	if err != nil {
+		fmt.Println(tui.ErrorStyle.Render("Failed to load quests: " + err.Error()))
+		return nil
+	}
+
+	// Today's list is short and fetched whole, so what's cut is known
+	// exactly; --all only knows there's at least one more
+	hidden := 0
+	if lsLimit > 0 && len(quests) > lsLimit {
+		hidden = len(quests) - lsLimit
+		quests = quests[:lsLimit]
+	}
+
+	items := make([]lsItem, len(quests))
+	for i, q := range quests {
+		items[i] = lsItem{Quest: q}
+		if !lsAll {
+			items[i].Number = i + 1
+		}
+	}
+
+	if lsFormat != "" {
+		return printFormatted(lsFormat, items)
+	}
 
 	title := "today's quests"
 	if lsAll {
@@ -43,12 +123,71 @@ func runLs(cmd *cobra.Command, args []string) error {
 
 	fmt.Println(tui.TitleStyle.Render(title))
 	fmt.Println()
-	fmt.Println(tui.MutedStyle.Render("  No quests yet. Add some with 'grind add \"task\"'"))
+	if len(items) == 0 {
+		fmt.Println(tui.MutedStyle.Render("  No quests yet. Add some with 'grind add \"task\"'"))
+		fmt.Println()
+		return nil
+	}
+
+	for _, item := range items {
+		fmt.Println(renderLsItem(item))
+	}
+	if hidden > 0 && lsAll {
+		fmt.Println(tui.MutedStyle.Render("  " + components.Glyphs.Ellipsis + " and more (--limit 0 shows everything)"))
+	} else if hidden > 0 {
+		fmt.Println(tui.MutedStyle.Render(fmt.Sprintf("  %s and %d more", components.Glyphs.Ellipsis, hidden)))
+	}
 	fmt.Println()
 
 	return nil
 }
 
+// parseSince reads --since: a duration back from now, like 36h or 7d, or
+// a YYYY-MM-DD date, taken as midnight in now's time zone
+func parseSince(s string, now time.Time) (time.Time, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n >= 0 {
+			return now.AddDate(0, 0, -n), nil
+		}
+	}
+	if d, err := time.ParseDuration(s); err == nil && d >= 0 {
+		return now.Add(-d), nil
+	}
+	if t, err := time.ParseInLocation(time.DateOnly, s, now.Location()); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid --since %q: use a duration like 36h or 7d, or a date like 2026-10-01", s)
+}
+
+// renderLsItem renders "  1. [ ] title  +40 XP", dimmed once completed or abandoned
+func renderLsItem(item lsItem) string {
+	number := "   "
+	if item.Number > 0 {
+		number = fmt.Sprintf("%2d.", item.Number)
+	}
+	xp := fmt.Sprintf("+%d XP", item.XP) + components.SubtaskBadge(item.Quest)
+	if item.Status != "abandoned" {
+		xp += components.StatusLabel(item.Status, item.IsSnoozed()) // Dropped ones already say so
+	}
+
+	switch item.Status {
+	case "completed":
+		return fmt.Sprintf("  %s %s %s  %s", number, tui.SuccessStyle.Render("[x]"),
+			tui.QuestDoneStyle.Render(item.Title), tui.MutedStyle.Render(xp))
+	case "in_progress":
+		return fmt.Sprintf("  %s %s %s  %s", number, tui.InProgressStyle.Render("[>]"),
+			item.Title, tui.XPStyle.Render(xp))
+	case "abandoned":
+		return fmt.Sprintf("  %s %s %s  %s", number, tui.MutedStyle.Render("[-]"),
+			tui.QuestDoneStyle.Render(item.Title), tui.MutedStyle.Render("dropped"))
+	default:
+		return fmt.Sprintf("  %s [ ] %s  %s", number, item.Title, tui.XPStyle.Render(xp))
+	}
+}
+
 func init() {
 	lsCmd.Flags().BoolVarP(&lsAll, "all", "a", false, "Show all quests, not just today's")
+	lsCmd.Flags().StringVar(&lsFormat, "format", "", "Print each quest with a Go template (see --help for fields)")
+	lsCmd.Flags().IntVarP(&lsLimit, "limit", "l", defaultLsLimit, "Show at most this many quests (0 for no limit)")
+	lsCmd.Flags().StringVar(&lsSince, "since", "", "Only quests created since a duration ago (36h, 7d) or a date (2026-10-01); implies --all")
 }
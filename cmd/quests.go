@@ -0,0 +1,187 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/x/term"
+
+	"grind/internal/api"
+	"grind/internal/auth"
+	"grind/internal/tui"
+)
+
+// loadQuest fetches today's quests and resolves arg against them: a quest
+// number as shown in the dashboard, or words from the title. A title only
+// matches quests that eligible accepts (nil for any), e.g. unfinished ones
+// for 'grind done'. parent is the command's context rather than a request
+// one, since resolving may wait on the user to pick between matches. The
+// resolved quest is re-fetched so callers act on its current status.
+func loadQuest(parent context.Context, client *api.Client, cfg *auth.Config, arg string, eligible func(api.Quest) bool) (api.Quest, error) {
+	ctx, cancel := requestContext(parent, 10*time.Second)
+	quests, err := client.ListTodayQuests(ctx, cfg.UserID)
+	cancel()
+	if err != nil {
+		return api.Quest{}, fmt.Errorf("failed to load quests: %w", err)
+	}
+	var quest api.Quest
+	if _, err := strconv.Atoi(arg); err == nil {
+		quest, err = resolveQuestNumber(quests, arg)
+	} else {
+		quest, err = resolveQuestTitle(parent, quests, arg, eligible)
+	}
+	if err != nil {
+		return api.Quest{}, err
+	}
+	return refreshQuest(parent, client, quest)
+}
+
+// refreshQuest re-fetches quest so the caller checks its live status, not
+// the list's: the dashboard or another shell may have changed it since,
+// e.g. while the user was picking between matches
+func refreshQuest(parent context.Context, client *api.Client, quest api.Quest) (api.Quest, error) {
+	ctx, cancel := requestContext(parent, 10*time.Second)
+	defer cancel()
+	fresh, err := client.GetQuest(ctx, quest.ID)
+	if err != nil {
+		return api.Quest{}, fmt.Errorf("failed to load quest: %w", err)
+	}
+	if fresh == nil {
+		return api.Quest{}, fmt.Errorf("quest %q no longer exists", quest.Title)
+	}
+	return *fresh, nil
+}
+
+// questArg joins a command's arguments into one quest reference, so titles
+// don't need quoting: 'grind done fix login'
+func questArg(args []string) string {
+	return strings.Join(args, " ")
+}
+
+// resolveQuestNumber maps a 1-based quest number (as shown in the dashboard)
+// to the quest in today's list
+func resolveQuestNumber(quests []api.Quest, arg string) (api.Quest, error) {
+	n, err := strconv.Atoi(arg)
+	if err != nil {
+		return api.Quest{}, fmt.Errorf("invalid quest number: %s", arg)
+	}
+	if n < 1 || n > len(quests) {
+		if len(quests) == 0 {
+			return api.Quest{}, fmt.Errorf("no quests today")
+		}
+		return api.Quest{}, fmt.Errorf("no quest #%d (you have %d today)", n, len(quests))
+	}
+	return quests[n-1], nil
+}
+
+// resolveQuestTitle finds the eligible quest whose title matches query.
+// Several matches are listed with their numbers; on a terminal the user
+// picks one, otherwise it's an error asking for the number.
+func resolveQuestTitle(ctx context.Context, quests []api.Quest, query string, eligible func(api.Quest) bool) (api.Quest, error) {
+	var matches []int
+	for _, i := range api.MatchQuests(quests, query) {
+		if eligible == nil || eligible(quests[i]) {
+			matches = append(matches, i)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return api.Quest{}, fmt.Errorf("no quest today matches %q", query)
+	case 1:
+		return quests[matches[0]], nil
+	}
+
+	fmt.Printf("%d quests match %q:\n", len(matches), query)
+	for _, i := range matches {
+		fmt.Printf("  %s %s\n", tui.MutedStyle.Render(fmt.Sprintf("#%d", i+1)), quests[i].Title)
+	}
+	if !term.IsTerminal(os.Stdin.Fd()) {
+		return api.Quest{}, fmt.Errorf("pick one by number instead")
+	}
+
+	n, ok := askNumber(ctx, "Which one? ")
+	for _, i := range matches {
+		if ok && n == i+1 {
+			return quests[i], nil
+		}
+	}
+	return api.Quest{}, fmt.Errorf("no quest picked")
+}
+
+// askNumber reads a number from stdin. Ctrl-C or anything that isn't a
+// number returns false.
+func askNumber(ctx context.Context, question string) (int, bool) {
+	answer, ok := askLine(ctx, question)
+	if !ok {
+		return 0, false
+	}
+	n, err := strconv.Atoi(strings.TrimPrefix(answer, "#"))
+	return n, err == nil
+}
+
+// stdinLines carries lines from the one reader on stdin. Sharing it keeps
+// piped answers buffered past the first newline for the prompts after it,
+// and a prompt abandoned with Ctrl-C leaves its line for the next one
+// rather than a stray goroutine swallowing it.
+var (
+	stdinOnce  sync.Once
+	stdinLines chan string
+)
+
+// readStdin starts the stdin reader on first use. The channel is closed at
+// EOF.
+func readStdin() <-chan string {
+	stdinOnce.Do(func() {
+		stdinLines = make(chan string)
+		go func() {
+			r := bufio.NewReader(os.Stdin)
+			for {
+				line, err := r.ReadString('\n')
+				if line != "" {
+					stdinLines <- line
+				}
+				if err != nil {
+					close(stdinLines)
+					return
+				}
+			}
+		}()
+	})
+	return stdinLines
+}
+
+// askLine reads a trimmed line from stdin, or "" at EOF. Ctrl-C while
+// waiting returns false.
+func askLine(ctx context.Context, question string) (string, bool) {
+	fmt.Print(question)
+	if ctx.Err() != nil {
+		fmt.Println()
+		return "", false
+	}
+
+	select {
+	case answer := <-readStdin():
+		return strings.TrimSpace(answer), true
+	case <-ctx.Done():
+		fmt.Println()
+		return "", false
+	}
+}
+
+// isUnfinished reports whether a quest can still be completed, snoozed,
+// or abandoned
+func isUnfinished(q api.Quest) bool {
+	return q.IsOpen()
+}
+
+// isPending reports whether a quest hasn't been started yet
+func isPending(q api.Quest) bool {
+	return q.Status == "pending"
+}
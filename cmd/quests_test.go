@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"sync"
+	"testing"
+)
+
+// TestAskLine feeds several answers through one pipe, as a script piping
+// into a multi-prompt command would. It owns the shared stdin reader, so
+// it's the only test that may call askLine.
+func TestAskLine(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdin := os.Stdin
+	os.Stdin = r
+	stdinOnce = sync.Once{} // Start a reader on the pipe
+	defer func() { os.Stdin = stdin }()
+
+	// Everything arrives in one write, so a reader per prompt would buffer
+	// the later answers and lose them
+	if _, err := w.WriteString("y\n  edit title  \n#3\n"); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	if got, ok := askLine(ctx, ""); !ok || got != "y" {
+		t.Errorf("first answer = %q, %v; want \"y\"", got, ok)
+	}
+	if got, ok := askLine(ctx, ""); !ok || got != "edit title" {
+		t.Errorf("second answer = %q, %v; want \"edit title\"", got, ok)
+	}
+
+	// A cancelled prompt doesn't consume the next line
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, ok := askLine(cancelled, ""); ok {
+		t.Error("cancelled prompt answered")
+	}
+	if n, ok := askNumber(ctx, ""); !ok || n != 3 {
+		t.Errorf("number = %d, %v; want 3", n, ok)
+	}
+
+	// EOF answers blank
+	w.Close()
+	if got, ok := askLine(ctx, ""); !ok || got != "" {
+		t.Errorf("answer at EOF = %q, %v; want \"\"", got, ok)
+	}
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"grind/internal/api"
+	"grind/internal/auth"
+	"grind/internal/i18n"
+	"grind/internal/tui"
+	"grind/internal/tui/components"
+)
+
+var renameCmd = &cobra.Command{
+	Use:   "rename <new name>",
+	Short: "Change your display name",
+	Long: `Change the name your crew sees on the leaderboard and in the feed.
+
+Names are 1-32 characters. Activity from now on shows the new name.
+
+Examples:
+  grind rename neo
+  grind rename "the one"`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runRename,
+}
+
+func runRename(cmd *cobra.Command, args []string) error {
+	cfg, err := auth.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if !cfg.IsLoggedIn() {
+		fmt.Println(tui.ErrorStyle.Render(i18n.T("cmd.notLoggedIn")))
+		return nil
+	}
+
+	name, err := api.ValidateUserName(strings.Join(args, " "))
+	if err != nil {
+		fmt.Println(tui.ErrorStyle.Render("Invalid name: " + err.Error() + "."))
+		return nil
+	}
+	if name == cfg.UserName {
+		fmt.Println(tui.MutedStyle.Render("Already called " + name + "."))
+		return nil
+	}
+
+	ctx, cancel := requestContext(cmd.Context(), 10*time.Second)
+	defer cancel()
+
+	if err := clientFor(cfg).UpdateUserName(ctx, cfg.UserID, name); err != nil {
+		fmt.Println(tui.ErrorStyle.Render("Failed to rename: " + err.Error()))
+		return nil
+	}
+
+	cfg.UserName = name
+	if err := auth.Save(cfg); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	fmt.Println(tui.SuccessStyle.Render(components.Glyphs.Check + " you're now " + name))
+	return nil
+}
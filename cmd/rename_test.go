@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"grind/internal/api"
+	"grind/internal/api/apitest"
+	"grind/internal/auth"
+)
+
+func TestRunRename(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	if err := auth.Save(&auth.Config{UserID: "u1", UserName: "ada", ConvexURL: "https://example.convex.cloud"}); err != nil {
+		t.Fatal(err)
+	}
+
+	fake := apitest.NewFake()
+	fake.Return("users:update", nil)
+	backend := newBackend
+	newBackend = func(string) api.ConvexAPI { return fake }
+	defer func() { newBackend = backend }()
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	if err := runRename(cmd, []string{"grace", "hopper"}); err != nil {
+		t.Fatal(err)
+	}
+
+	calls := fake.CallsTo("users:update")
+	if len(calls) != 1 || calls[0].Kind != "mutation" || calls[0].Args["name"] != "grace hopper" {
+		t.Errorf("calls = %+v", calls)
+	}
+	cfg, err := auth.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.UserName != "grace hopper" {
+		t.Errorf("saved name = %q", cfg.UserName)
+	}
+}
@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+
+	"grind/internal/api"
+	"grind/internal/auth"
+	"grind/internal/i18n"
+	"grind/internal/tui"
+	"grind/internal/tui/components"
+)
+
+var rivalCmd = &cobra.Command{
+	Use:   "rival [name]",
+	Short: "Compare yourself head-to-head with a crew member",
+	Long: `Show a side-by-side comparison with a crew member.
+
+Without a name, picks the member just ahead of you on the weekly
+leaderboard (or just behind, if you're leading).
+
+Examples:
+  grind rival          # Auto-pick your closest rival
+  grind rival alice    # Compare against alice`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runRival,
+}
+
+func runRival(cmd *cobra.Command, args []string) error {
+	cfg, err := auth.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if !cfg.IsLoggedIn() {
+		fmt.Println(tui.ErrorStyle.Render(i18n.T("cmd.notLoggedIn")))
+		return nil
+	}
+
+	if !cfg.HasGroup() {
+		fmt.Println(tui.ErrorStyle.Render(i18n.T("cmd.noGroup")))
+		return nil
+	}
+
+	rivalName := ""
+	if len(args) > 0 {
+		rivalName = strings.TrimSpace(args[0])
+	}
+
+	client := clientFor(cfg)
+	ctx, cancel := requestContext(cmd.Context(), 10*time.Second)
+	defer cancel()
+
+	cmp, err := client.CompareRival(ctx, cfg.UserID, rivalName)
+	if err != nil {
+		fmt.Println(tui.ErrorStyle.Render("Failed to load rival: " + err.Error()))
+		return nil
+	}
+	if cmp == nil {
+		fmt.Println(tui.MutedStyle.Render("No rivals yet. Invite your crew to start competing!"))
+		return nil
+	}
+
+	fmt.Println(renderRival(cmp))
+	return nil
+}
+
+// renderRival renders a side-by-side comparison box
+func renderRival(cmp *api.RivalComparison) string {
+	title := tui.TitleStyle.Render(fmt.Sprintf("YOU vs %s", strings.ToUpper(cmp.Rival.UserName)))
+	separator := tui.MutedStyle.Render(strings.Repeat(components.Glyphs.Rule, 44))
+
+	row := func(label string, you, rival int) string {
+		youStyle, rivalStyle := tui.MutedStyle, tui.MutedStyle
+		if you > rival {
+			youStyle = tui.SuccessStyle
+		} else if rival > you {
+			rivalStyle = tui.ErrorStyle
+		}
+		return fmt.Sprintf("  %-14s %s  %s",
+			label,
+			youStyle.Render(fmt.Sprintf("%10d", you)),
+			rivalStyle.Render(fmt.Sprintf("%10d", rival)),
+		)
+	}
+
+	header := fmt.Sprintf("  %-14s %10s  %10s", "", "you", truncateName(cmp.Rival.UserName, 10))
+	rows := []string{
+		tui.MutedStyle.Render(header),
+		row("weekly XP", cmp.You.WeeklyXP, cmp.Rival.WeeklyXP),
+		row("quests", cmp.You.WeeklyQuests, cmp.Rival.WeeklyQuests),
+		row("level", cmp.You.Level, cmp.Rival.Level),
+	}
+
+	var verdict string
+	switch {
+	case cmp.Gap > 0:
+		verdict = tui.AlertStyle.Render(fmt.Sprintf("%d XP to close the gap", cmp.Gap))
+	case cmp.Gap < 0:
+		verdict = tui.SuccessStyle.Render(fmt.Sprintf("you're %d XP ahead", -cmp.Gap))
+	default:
+		verdict = tui.XPStyle.Render("dead even. break the tie.")
+	}
+
+	content := lipgloss.JoinVertical(
+		lipgloss.Left,
+		title,
+		separator,
+		"",
+		strings.Join(rows, "\n"),
+		"",
+		separator,
+		verdict,
+	)
+
+	return tui.BoxStyle.Width(55).Render(content)
+}
+
+// truncateName shortens a name to max cells with an ellipsis
+func truncateName(s string, max int) string {
+	return components.FitLine(s, max)
+}
@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestTruncateName(t *testing.T) {
+	tests := []struct {
+		s   string
+		max int
+	}{
+		{"ada", 12},
+		{"exactly12chr", 12},
+		{"a name far too long to fit", 12},
+		{"山田太郎さんのチーム", 12},
+		{"Zoë Ångström-Öberg", 8},
+		{"🔥🔥🔥🔥🔥🔥", 5},
+	}
+	for _, tt := range tests {
+		got := truncateName(tt.s, tt.max)
+		if w := lipgloss.Width(got); w > tt.max {
+			t.Errorf("truncateName(%q, %d) = %q, %d cells", tt.s, tt.max, got, w)
+		}
+		if lipgloss.Width(tt.s) <= tt.max && got != tt.s {
+			t.Errorf("truncateName changed %q, which fits", tt.s)
+		}
+	}
+}
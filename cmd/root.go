@@ -1,17 +1,38 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"os/signal"
+	"runtime/debug"
+	"time"
 
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
 	"github.com/spf13/cobra"
 
+	"grind/internal/api"
 	"grind/internal/auth"
+	"grind/internal/i18n"
+	"grind/internal/levels"
+	"grind/internal/logging"
+	"grind/internal/store"
 	"grind/internal/tui"
+	"grind/internal/tui/components"
 )
 
 var (
 	// Version is set at build time
 	Version = "dev"
+
+	asciiFlag       bool
+	compactFlag     bool
+	debugFlag       bool
+	localFlag       bool
+	noAltScreenFlag bool
+	noStyleFlag     bool
+	profileFlag     string
 )
 
 var rootCmd = &cobra.Command{
@@ -22,7 +43,8 @@ Add tasks in natural language, AI evaluates XP fairly, and everyone
 competes on a shared leaderboard.
 
 Run 'grind' without arguments to enter interactive mode.`,
-	RunE: runRoot,
+	PersistentPreRunE: setup,
+	RunE:              runRoot,
 }
 
 func runRoot(cmd *cobra.Command, args []string) error {
@@ -32,23 +54,197 @@ func runRoot(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
+	if noStyleFlag {
+		fmt.Fprintln(os.Stderr, "warning: --no-style is ignored in interactive mode")
+	}
+
+	if localFlag && !cfg.Local {
+		if cfg.IsLoggedIn() {
+			return fmt.Errorf("profile %q already has an online account - pick another with --profile", auth.Profile())
+		}
+		cfg.Local = true
+	}
+
 	// Launch interactive TUI
-	return tui.Run(cfg)
+	return tui.Run(cfg, compactFlag)
+}
+
+// setup runs before every command: it picks the profile, starts the debug
+// log if asked for and picks the glyph set
+func setup(cmd *cobra.Command, args []string) error {
+	profile := profileFlag
+	if localFlag && profile == "" {
+		profile = auth.LocalProfile
+	}
+	if err := auth.UseProfile(profile); err != nil {
+		return err
+	}
+	startLogging()
+	logging.Info("command", "cmd", cmd.CommandPath(), "args", args, "version", Version)
+	applyGlyphs(cmd, args)
+	applyLang()
+	applyLevelNames()
+	applyStyle(cmd)
+	tui.SetAltScreen(!noAltScreenFlag)
+	return nil
+}
+
+// startLogging opens the debug log at $GRIND_LOG, or ~/.grind/grind.log
+// with --debug. Without either, logging stays off.
+func startLogging() {
+	path := os.Getenv("GRIND_LOG")
+	if path == "" && debugFlag {
+		path, _ = auth.LogPath()
+	}
+	if path == "" {
+		return
+	}
+	if err := logging.Open(path); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: can't open log %s: %v\n", path, err)
+	}
+}
+
+// applyGlyphs picks the unicode or ASCII icon set before any command renders.
+// --ascii always wins; otherwise the "glyphs" setting decides, with "auto"
+// falling back to ASCII when the locale doesn't look like UTF-8. The
+// "colorSafe" labels and "xpTiers" colors are applied here too.
+func applyGlyphs(cmd *cobra.Command, args []string) {
+	mode := ""
+	if cfg, err := auth.Load(); err == nil {
+		mode = cfg.Glyphs
+		components.SetColorSafe(cfg.ColorSafe)
+		if len(cfg.XPTiers) == 2 {
+			components.SetXPTiers(cfg.XPTiers[0], cfg.XPTiers[1])
+		}
+	}
+
+	if asciiFlag {
+		components.SetASCII(true)
+	} else {
+		components.UseGlyphs(mode)
+	}
+	tui.ApplyGlyphs()
+}
+
+// applyStyle drops colors and text styling with --no-style, for piping or
+// plain-text preference. Non-TTY output is already plain (lipgloss detects
+// it), and the interactive TUI keeps its styling regardless.
+func applyStyle(cmd *cobra.Command) {
+	if noStyleFlag && cmd.HasParent() {
+		lipgloss.SetColorProfile(termenv.Ascii)
+	}
 }
 
-// Execute runs the root command
+// applyLang picks the UI language from $GRIND_LANG or the "lang" setting,
+// along with the "fullNumbers" number format
+func applyLang() {
+	lang := ""
+	full := false
+	if cfg, err := auth.Load(); err == nil {
+		lang = cfg.Lang
+		full = cfg.FullNumbers
+	}
+	i18n.Use(lang)
+	i18n.SetFullNumbers(full)
+}
+
+// applyLevelNames shows the crew's own level names, as last cached from
+// groups:get
+func applyLevelNames() {
+	if cfg, err := auth.Load(); err == nil {
+		levels.SetNames(cfg.LevelNames)
+	}
+}
+
+// newBackend builds the backend commands talk to for a deployment URL.
+// Tests can swap it for an apitest.Fake.
+var newBackend = func(url string) api.ConvexAPI {
+	return api.NewClient(url)
+}
+
+// newClient returns the typed client for the deployment at url
+func newClient(url string) *api.Client {
+	return api.ClientFor(newBackend(url))
+}
+
+// clientFor returns the client for cfg's account: the backend, or the
+// local store for a --local guest
+func clientFor(cfg *auth.Config) *api.Client {
+	if cfg.Local {
+		return api.NewClientFrom(store.Backend{Location: cfg.Location()})
+	}
+	return newClient(cfg.GetConvexURL())
+}
+
+// requestContext bounds a single API call. parent is the command's context
+// (cmd.Context()), so Ctrl-C aborts the request as well as the timeout.
+func requestContext(parent context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(parent, timeout)
+}
+
+// Execute runs the root command with a context that is cancelled when the
+// user hits Ctrl-C, so a hung request can be aborted cleanly
 func Execute() error {
-	return rootCmd.Execute()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	// After the first Ctrl-C, restore the default handler so a second one
+	// kills the process even if something isn't watching the context
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+
+	defer logging.Close()
+	defer func() {
+		if r := recover(); r != nil {
+			logging.Error("panic", "value", r, "stack", string(debug.Stack()))
+			logging.Close()
+			panic(r)
+		}
+	}()
+
+	err := rootCmd.ExecuteContext(ctx)
+	if err != nil {
+		logging.Error("command failed", "err", err)
+	}
+	return err
 }
 
 func init() {
+	rootCmd.PersistentFlags().BoolVar(&asciiFlag, "ascii", false, "Use plain ASCII instead of unicode borders and emoji")
+	rootCmd.PersistentFlags().BoolVar(&noStyleFlag, "no-style", false, "Print plain text without colors or styling")
+	rootCmd.PersistentFlags().BoolVar(&noStyleFlag, "raw", false, "Alias for --no-style")
+	_ = rootCmd.PersistentFlags().MarkHidden("raw")
+	rootCmd.PersistentFlags().StringVar(&profileFlag, "profile", "", "Use a separate account stored under ~/.grind/profiles (or $GRIND_PROFILE)")
+	rootCmd.PersistentFlags().BoolVar(&debugFlag, "debug", false, "Write a debug log to ~/.grind/grind.log (or $GRIND_LOG)")
+	rootCmd.Flags().BoolVar(&compactFlag, "compact", false, "Use the single-column dashboard layout")
+	rootCmd.PersistentFlags().BoolVar(&noAltScreenFlag, "no-altscreen", false, "Draw the dashboard inline instead of on the alternate screen")
+	rootCmd.Flags().BoolVar(&localFlag, "local", false, "Play solo as a guest, keeping everything on this machine")
+
 	// Add subcommands
 	rootCmd.AddCommand(addCmd)
+	rootCmd.AddCommand(startCmd)
 	rootCmd.AddCommand(doneCmd)
 	rootCmd.AddCommand(lsCmd)
 	rootCmd.AddCommand(boardCmd)
 	rootCmd.AddCommand(statsCmd)
+	rootCmd.AddCommand(statusCmd)
+	rootCmd.AddCommand(todayCmd)
 	rootCmd.AddCommand(joinCmd)
+	rootCmd.AddCommand(groupCmd)
+	rootCmd.AddCommand(rivalCmd)
+	rootCmd.AddCommand(doctorCmd)
+	rootCmd.AddCommand(configCmd)
+	rootCmd.AddCommand(editCmd)
+	rootCmd.AddCommand(snoozeCmd)
+	rootCmd.AddCommand(abandonCmd)
+	rootCmd.AddCommand(goalCmd)
+	rootCmd.AddCommand(capCmd)
+	rootCmd.AddCommand(templateCmd)
+	rootCmd.AddCommand(setupCmd)
+	rootCmd.AddCommand(renameCmd)
+	rootCmd.AddCommand(importCmd)
 	rootCmd.AddCommand(versionCmd)
 }
 
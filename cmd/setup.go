@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"grind/internal/auth"
+	"grind/internal/tui"
+)
+
+var setupCmd = &cobra.Command{
+	Use:   "setup",
+	Short: "Change your name or crew",
+	Long: `Re-run the onboarding steps for your account, pre-filled with your
+current name and crew. A new name is saved to your existing account;
+esc goes back to the dashboard without changing anything else.
+
+Without an account yet, this is the same as running 'grind'.
+
+Examples:
+  grind setup`,
+	Args: cobra.NoArgs,
+	RunE: runSetup,
+}
+
+func runSetup(cmd *cobra.Command, args []string) error {
+	cfg, err := auth.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if !cfg.IsLoggedIn() {
+		return tui.Run(cfg, compactFlag)
+	}
+	return tui.RunSetup(cfg)
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"grind/internal/auth"
+	"grind/internal/i18n"
+	"grind/internal/tui"
+	"grind/internal/tui/components"
+)
+
+var snoozeCmd = &cobra.Command{
+	Use:   "snooze <quest-number | title>",
+	Short: "Defer a quest to tomorrow",
+	Long: `Snooze a quest so it leaves today's list and comes back tomorrow.
+
+The quest keeps its XP and reappears at the start of the next day.
+
+Examples:
+  grind snooze 2         # Push quest #2 to tomorrow
+  grind snooze taxes     # Push the quest with "taxes" in its title`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runSnooze,
+}
+
+func runSnooze(cmd *cobra.Command, args []string) error {
+	cfg, err := auth.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if !cfg.IsLoggedIn() {
+		fmt.Println(tui.ErrorStyle.Render(i18n.T("cmd.notLoggedIn")))
+		return nil
+	}
+
+	client := clientFor(cfg)
+	quest, err := loadQuest(cmd.Context(), client, cfg, questArg(args), isUnfinished)
+	if err != nil {
+		fmt.Println(tui.ErrorStyle.Render(err.Error()))
+		return nil
+	}
+
+	ctx, cancel := requestContext(cmd.Context(), 10*time.Second)
+	defer cancel()
+
+	if !quest.IsOpen() {
+		fmt.Println(tui.ErrorStyle.Render("Quest already " + quest.Status + "."))
+		return nil
+	}
+
+	if err := client.SnoozeQuest(ctx, quest.ID); err != nil {
+		fmt.Println(tui.ErrorStyle.Render("Failed to snooze quest: " + err.Error()))
+		return nil
+	}
+
+	fmt.Println(tui.MutedStyle.Render(components.Glyphs.Snooze+"snoozed until tomorrow: ") + quest.Title)
+	return nil
+}
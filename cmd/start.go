@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"grind/internal/auth"
+	"grind/internal/i18n"
+	"grind/internal/tui"
+	"grind/internal/tui/components"
+)
+
+var startCmd = &cobra.Command{
+	Use:   "start <quest-number | title>",
+	Short: "Start working on a quest",
+	Long: `Mark a pending quest as in progress so your crew sees what you're on.
+
+Pick the quest by its number or by words from its title. If several
+pending quests match, they're listed to choose from.
+
+--timer time-boxes the quest: once it runs out, the dashboard asks
+whether you're done. Setting one on a quest that's already in progress
+just (re)sets the timer.
+
+Examples:
+  grind start 2          # Start quest #2
+  grind start refactor   # Start the quest with "refactor" in its title
+  grind start 2 --timer 30m`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runStart,
+}
+
+var startTimer time.Duration
+
+func runStart(cmd *cobra.Command, args []string) error {
+	cfg, err := auth.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if !cfg.IsLoggedIn() {
+		fmt.Println(tui.ErrorStyle.Render(i18n.T("cmd.notLoggedIn")))
+		return nil
+	}
+
+	if startTimer < 0 || startTimer > auth.MaxQuestTimer {
+		return fmt.Errorf("--timer must be between 0 and %s", auth.MaxQuestTimer)
+	}
+
+	client := clientFor(cfg)
+	match := isPending
+	if startTimer > 0 {
+		match = isUnfinished
+	}
+	quest, err := loadQuest(cmd.Context(), client, cfg, questArg(args), match)
+	if err != nil {
+		fmt.Println(tui.ErrorStyle.Render(err.Error()))
+		return nil
+	}
+
+	switch quest.Status {
+	case "in_progress":
+		if startTimer > 0 {
+			return setStartTimer(cfg, quest.ID)
+		}
+		fmt.Println(tui.MutedStyle.Render("Already in progress: ") + quest.Title)
+		return nil
+	case "completed":
+		fmt.Println(tui.ErrorStyle.Render("Quest already completed."))
+		return nil
+	case "abandoned":
+		fmt.Println(tui.ErrorStyle.Render("Quest was abandoned."))
+		return nil
+	}
+
+	ctx, cancel := requestContext(cmd.Context(), 10*time.Second)
+	defer cancel()
+
+	if err := client.StartQuest(ctx, quest.ID); err != nil {
+		fmt.Println(tui.ErrorStyle.Render("Failed to start quest: " + err.Error()))
+		return nil
+	}
+
+	fmt.Println(tui.InProgressStyle.Render(components.Glyphs.Working+" started: ") + quest.Title)
+	if startTimer > 0 {
+		return setStartTimer(cfg, quest.ID)
+	}
+	return nil
+}
+
+// setStartTimer saves a --timer for questID for the dashboard to pick up
+func setStartTimer(cfg *auth.Config, questID string) error {
+	cfg.SetQuestTimer(questID, time.Now().Add(startTimer))
+	if err := auth.Save(cfg); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+	fmt.Println(tui.MutedStyle.Render(fmt.Sprintf("timer set: the dashboard asks if you're done in %s", startTimer)))
+	return nil
+}
+
+func init() {
+	startCmd.Flags().DurationVar(&startTimer, "timer", 0, "Ask whether the quest is done after this long, e.g. 30m")
+}
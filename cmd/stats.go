@@ -3,13 +3,17 @@ package cmd
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/spf13/cobra"
 
 	"grind/internal/auth"
+	"grind/internal/i18n"
 	"grind/internal/levels"
+	"grind/internal/streaks"
 	"grind/internal/tui"
+	"grind/internal/tui/components"
 )
 
 var statsCmd = &cobra.Command{
@@ -21,10 +25,36 @@ Shows:
 - Current level and XP
 - Progress to next level
 - Weekly and total stats
-- Quest completion history`,
+- Daily streak and streak freezes left this week
+- Quest completion history
+
+--format takes a Go template. Fields:
+  .Name .Level .LevelName .TotalXP .WeeklyXP .Rank .TodayXP
+  .QuestsDone .QuestsTotal .Streak .FreezesLeft
+
+Examples:
+  grind stats
+  grind stats --format '{{.Name}} L{{.Level}} {{.WeeklyXP}} XP'`,
 	RunE: runStats,
 }
 
+var statsFormat string
+
+// statsItem is what --format templates see
+type statsItem struct {
+	Name        string
+	Level       int
+	LevelName   string
+	TotalXP     int
+	WeeklyXP    int
+	Rank        int
+	TodayXP     int
+	QuestsDone  int
+	QuestsTotal int
+	Streak      int
+	FreezesLeft int
+}
+
 func runStats(cmd *cobra.Command, args []string) error {
 	cfg, err := auth.Load()
 	if err != nil {
@@ -32,53 +62,94 @@ func runStats(cmd *cobra.Command, args []string) error {
 	}
 
 	if !cfg.IsLoggedIn() {
-		fmt.Println(tui.ErrorStyle.Render("Not logged in. Run 'grind' to set up."))
+		fmt.Println(tui.ErrorStyle.Render(i18n.T("cmd.notLoggedIn")))
 		return nil
 	}
 
-	// TODO: Fetch stats from Convex
-	// For now, show starter stats
+	client := clientFor(cfg)
+	ctx, cancel := requestContext(cmd.Context(), 10*time.Second)
+	defer cancel()
 
-	totalXP := 0
-	weeklyXP := 0
-	totalQuests := 0
-	weeklyQuests := 0
+	user, err := client.GetUser(ctx, cfg.UserID)
+	if err != nil {
+		fmt.Println(tui.ErrorStyle.Render("Failed to load stats: " + err.Error()))
+		return nil
+	}
+	if user == nil {
+		fmt.Println(tui.ErrorStyle.Render("Account not found. Run 'grind' to set up again."))
+		return nil
+	}
+	stats, err := client.GetStats(ctx, cfg.UserID)
+	if err != nil {
+		fmt.Println(tui.ErrorStyle.Render("Failed to load stats: " + err.Error()))
+		return nil
+	}
 
-	level := levels.GetLevel(totalXP)
+	level := levels.GetLevel(user.TotalXP)
 	nextLevel := levels.GetNextLevel(level)
+	streak := streaks.Compute(stats.RecentCompletions, cfg.GetStreakFreezes(), time.Now())
+
+	if statsFormat != "" {
+		return printFormatted(statsFormat, []statsItem{{
+			Name:        user.Name,
+			Level:       level.Number,
+			LevelName:   level.Name,
+			TotalXP:     user.TotalXP,
+			WeeklyXP:    stats.Week.XP,
+			Rank:        stats.Week.Rank,
+			TodayXP:     stats.Today.XP,
+			QuestsDone:  stats.Today.QuestsCompleted,
+			QuestsTotal: stats.Today.QuestsTotal,
+			Streak:      streak.Days,
+			FreezesLeft: streak.FreezesLeft,
+		}})
+	}
 
 	// Header
-	header := fmt.Sprintf("%s · Level %d · %s",
-		tui.TitleStyle.Render(strings.ToUpper(cfg.UserName)),
+	dot := components.Glyphs.Dot
+	header := fmt.Sprintf("%s %s Level %d %s %s",
+		tui.TitleStyle.Render(strings.ToUpper(user.Name)),
+		dot,
 		level.Number,
+		dot,
 		tui.LevelStyle.Render(level.Name),
 	)
 
-	separator := tui.MutedStyle.Render(strings.Repeat("═", 48))
+	separator := tui.MutedStyle.Render(strings.Repeat(components.Glyphs.Rule, 48))
 
 	// XP bar
 	var xpBar string
 	if nextLevel != nil {
-		progress := levels.LevelProgress(totalXP)
+		progress := levels.LevelProgress(user.TotalXP)
 		barWidth := 30
-		xpBar = fmt.Sprintf("%s %d / %d XP",
+		xpBar = fmt.Sprintf("%s %s / %s XP",
 			tui.ProgressBar(int(progress*float64(barWidth)), barWidth, barWidth),
-			totalXP,
-			nextLevel.MinXP,
+			i18n.Number(user.TotalXP),
+			i18n.Number(nextLevel.MinXP),
 		)
 	} else {
 		xpBar = tui.ProgressBar(30, 30, 30) + " MAX LEVEL"
 	}
 
 	// Stats grid
+	rank := "unranked"
+	if stats.Week.Rank > 0 {
+		rank = fmt.Sprintf("#%d", stats.Week.Rank)
+	}
 	statsGrid := fmt.Sprintf(`
-  total quests     %d
-  this week        %d quests · %d XP
-  avg quest        %d XP`,
-		totalQuests,
-		weeklyQuests, weeklyXP,
-		0, // avg XP per quest
+  today            %d/%d quests %s %s XP
+  this week        %s XP %s %s
+  total            %s XP`,
+		stats.Today.QuestsCompleted, stats.Today.QuestsTotal, dot, i18n.Number(stats.Today.XP),
+		i18n.Number(stats.Week.XP), dot, rank,
+		i18n.Number(user.TotalXP),
 	)
+	if left := levels.LevelsRemaining(user.TotalXP); left > 0 {
+		statsGrid += fmt.Sprintf("\n  road to max      %s to %s (%d%%)",
+			i18n.Plural("plural.level", left), levels.MaxLevel().Name,
+			int(levels.OverallProgress(user.TotalXP)*100))
+	}
+	statsGrid += "\n" + renderStreak(streak)
 
 	content := lipgloss.JoinVertical(
 		lipgloss.Left,
@@ -97,3 +168,16 @@ func runStats(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// renderStreak renders the stats grid rows for the daily streak
+func renderStreak(s streaks.Streak) string {
+	days := i18n.Plural("plural.day", s.Days)
+	if s.FreezesUsed > 0 {
+		days += tui.MutedStyle.Render(" (" + i18n.Plural("plural.forgiven", s.FreezesUsed) + ")")
+	}
+	return fmt.Sprintf("  streak           %s\n  freezes left     %d this week", days, s.FreezesLeft)
+}
+
+func init() {
+	statsCmd.Flags().StringVar(&statsFormat, "format", "", "Print stats with a Go template (see --help for fields)")
+}
@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"grind/internal/auth"
+	"grind/internal/levels"
+	"grind/internal/tui/components"
+)
+
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Print a one-line status for prompts and tmux",
+	Long: `Print your level, XP, and rank on a single unstyled line, for a shell
+prompt or tmux status bar:
+
+  L3 Builder · 320/600 XP · #2
+
+It reads the progress cached by the dashboard and other commands, and
+only asks the backend when that's more than a minute old, giving up
+after a second so it never holds up the prompt. On any error it prints
+nothing.
+
+--format takes a Go template. Fields:
+  .Level .LevelName .TotalXP .NextXP .Rank
+
+Examples:
+  set -g status-right '#(grind status)'
+  grind status --format '{{.TotalXP}}xp'`,
+	Args:         cobra.NoArgs,
+	RunE:         runStatus,
+	SilenceUsage: true, // Don't dump usage into a prompt over a bad --format
+}
+
+var statusFormat string
+
+const (
+	// statusMaxAge is how old cached progress can be before status refreshes it
+	statusMaxAge = time.Minute
+
+	// statusTimeout caps the refresh so a slow backend can't stall the prompt
+	statusTimeout = time.Second
+)
+
+// statusItem is what --format templates see
+type statusItem struct {
+	Level     int
+	LevelName string
+	TotalXP   int
+	NextXP    int // XP needed for the next level, 0 at max level
+	Rank      int // Weekly rank, 0 if unknown
+}
+
+func runStatus(cmd *cobra.Command, args []string) error {
+	cfg, err := auth.Load()
+	if err != nil || !cfg.IsLoggedIn() {
+		return nil
+	}
+
+	if time.Since(time.Unix(cfg.ProgressAt, 0)) > statusMaxAge {
+		refreshProgress(cmd.Context(), cfg)
+	}
+	if cfg.ProgressAt == 0 {
+		return nil // Never fetched and the backend didn't answer
+	}
+
+	level := levels.GetLevel(cfg.TotalXP)
+	item := statusItem{
+		Level:     level.Number,
+		LevelName: level.Name,
+		TotalXP:   cfg.TotalXP,
+		Rank:      cfg.Rank,
+	}
+	if next := levels.GetNextLevel(level); next != nil {
+		item.NextXP = next.MinXP
+	}
+
+	if statusFormat != "" {
+		return printFormatted(statusFormat, []statusItem{item})
+	}
+
+	fmt.Println(formatStatus(item))
+	return nil
+}
+
+// refreshProgress updates the cached progress in cfg from the backend,
+// leaving it untouched if the backend doesn't answer in time
+func refreshProgress(parent context.Context, cfg *auth.Config) {
+	client := clientFor(cfg)
+	ctx, cancel := requestContext(parent, statusTimeout)
+	defer cancel()
+
+	user, err := client.GetUser(ctx, cfg.UserID)
+	if err != nil || user == nil {
+		return
+	}
+	rank := cfg.Rank
+	if stats, err := client.GetStats(ctx, cfg.UserID); err == nil && stats != nil {
+		rank = stats.Week.Rank
+	}
+	progress := func(c *auth.Config) {
+		c.TotalXP = user.TotalXP
+		c.Rank = rank
+		c.ProgressAt = time.Now().Unix()
+	}
+	progress(cfg)
+	_ = auth.Update(progress) // Best effort - the next call just refreshes again
+}
+
+// formatStatus renders the default status line, e.g. "L3 Builder · 320/600 XP · #2"
+func formatStatus(s statusItem) string {
+	parts := []string{fmt.Sprintf("L%d %s", s.Level, s.LevelName)}
+	if s.NextXP > 0 {
+		parts = append(parts, fmt.Sprintf("%d/%d XP", s.TotalXP, s.NextXP))
+	} else {
+		parts = append(parts, fmt.Sprintf("%d XP", s.TotalXP))
+	}
+	if s.Rank > 0 {
+		parts = append(parts, fmt.Sprintf("#%d", s.Rank))
+	}
+	return strings.Join(parts, " "+components.Glyphs.Dot+" ")
+}
+
+func init() {
+	statusCmd.Flags().StringVar(&statusFormat, "format", "", "Print status with a Go template (see --help for fields)")
+}
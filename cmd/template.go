@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"grind/internal/auth"
+	"grind/internal/tui"
+	"grind/internal/tui/components"
+)
+
+var templateCmd = &cobra.Command{
+	Use:   "template",
+	Short: "Manage quest templates",
+	Long: `Save a set of quests you add often and add them all at once.
+
+Templates are stored in ~/.grind/config.json. Each quest in a template is
+evaluated by the AI when it's added, same as 'grind add'.
+
+Examples:
+  grind template add morning "gym, standup, code review"
+  grind template list
+  grind add --template morning     # Adds all three quests
+  grind template remove morning`,
+	Args: cobra.NoArgs,
+	RunE: runTemplateList,
+}
+
+var templateAddCmd = &cobra.Command{
+	Use:   "add <name> <quests>",
+	Short: "Save a template from a comma-separated list of quests",
+	Args:  cobra.ExactArgs(2),
+	RunE:  runTemplateAdd,
+}
+
+var templateListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List saved templates",
+	Args:  cobra.NoArgs,
+	RunE:  runTemplateList,
+}
+
+var templateRemoveCmd = &cobra.Command{
+	Use:   "remove <name>",
+	Short: "Delete a template",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runTemplateRemove,
+}
+
+// maxTemplateQuests keeps a template from flooding the quest list
+const maxTemplateQuests = 20
+
+// parseTemplate splits "gym, standup, code review" into quest titles,
+// dropping blanks
+func parseTemplate(list string) []string {
+	var titles []string
+	for _, item := range strings.Split(list, ",") {
+		if title := strings.TrimSpace(item); title != "" {
+			titles = append(titles, title)
+		}
+	}
+	return titles
+}
+
+func runTemplateAdd(cmd *cobra.Command, args []string) error {
+	cfg, err := auth.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	name := strings.TrimSpace(args[0])
+	if name == "" || strings.ContainsAny(name, " \t") {
+		return fmt.Errorf("template name must be a single word")
+	}
+	titles := parseTemplate(args[1])
+	if len(titles) == 0 {
+		return fmt.Errorf("template needs at least one quest")
+	}
+	if len(titles) > maxTemplateQuests {
+		return fmt.Errorf("template can have at most %d quests", maxTemplateQuests)
+	}
+
+	_, replaced := cfg.Templates[name]
+	if cfg.Templates == nil {
+		cfg.Templates = map[string][]string{}
+	}
+	cfg.Templates[name] = titles
+	if err := auth.Save(cfg); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	verb := "saved"
+	if replaced {
+		verb = "replaced"
+	}
+	fmt.Println(tui.SuccessStyle.Render(fmt.Sprintf("%s %s template %q (%d quests)", components.Glyphs.Check, verb, name, len(titles))))
+	return nil
+}
+
+func runTemplateList(cmd *cobra.Command, args []string) error {
+	cfg, err := auth.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	names := cfg.TemplateNames()
+	if len(names) == 0 {
+		fmt.Println(tui.MutedStyle.Render("No templates yet. Try 'grind template add morning \"gym, standup\"'."))
+		return nil
+	}
+
+	for _, name := range names {
+		fmt.Printf("%s %s\n",
+			tui.TitleStyle.Render(name),
+			tui.MutedStyle.Render(strings.Join(cfg.Templates[name], ", ")))
+	}
+	return nil
+}
+
+func runTemplateRemove(cmd *cobra.Command, args []string) error {
+	cfg, err := auth.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	name := args[0]
+	if _, ok := cfg.Templates[name]; !ok {
+		return fmt.Errorf("no template named %q", name)
+	}
+	delete(cfg.Templates, name)
+	if err := auth.Save(cfg); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	fmt.Println(tui.MutedStyle.Render(fmt.Sprintf("template %q removed", name)))
+	return nil
+}
+
+func init() {
+	templateCmd.AddCommand(templateAddCmd)
+	templateCmd.AddCommand(templateListCmd)
+	templateCmd.AddCommand(templateRemoveCmd)
+}
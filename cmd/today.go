@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+
+	"grind/internal/api"
+	"grind/internal/auth"
+	"grind/internal/i18n"
+	"grind/internal/levels"
+	"grind/internal/tui"
+	"grind/internal/tui/components"
+)
+
+var todayCmd = &cobra.Command{
+	Use:   "today",
+	Short: "Print a snapshot of today's dashboard",
+	Long: `Print the dashboard header, today's quests, and the latest crew activity
+once and exit, without starting the interactive TUI. Handy in a morning
+shell alias.
+
+Examples:
+  grind today
+  grind today --no-style   # Plain text
+  grind today --json       # Raw data for scripts`,
+	Args: cobra.NoArgs,
+	RunE: runToday,
+}
+
+var todayJSON bool
+
+// todaySnapshot is everything 'grind today' shows, and its --json output
+type todaySnapshot struct {
+	User        *api.User              `json:"user"`
+	Stats       *api.DashboardStats    `json:"stats"`
+	Quests      []api.Quest            `json:"quests"`
+	Activity    []api.Activity         `json:"activity"`
+	Leaderboard []api.LeaderboardEntry `json:"leaderboard"`
+}
+
+func runToday(cmd *cobra.Command, args []string) error {
+	cfg, err := auth.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if !cfg.IsLoggedIn() {
+		fmt.Println(tui.ErrorStyle.Render(i18n.T("cmd.notLoggedIn")))
+		return nil
+	}
+
+	client := clientFor(cfg)
+	ctx, cancel := requestContext(cmd.Context(), 10*time.Second)
+	defer cancel()
+
+	snap := todaySnapshot{}
+	if snap.User, err = client.GetUser(ctx, cfg.UserID); err == nil && snap.User == nil {
+		err = fmt.Errorf("account not found")
+	}
+	if err == nil {
+		snap.Stats, err = client.GetStats(ctx, cfg.UserID)
+	}
+	if err == nil {
+		snap.Quests, err = client.ListTodayQuests(ctx, cfg.UserID)
+	}
+	if err == nil {
+		snap.Activity, err = client.ListActivity(ctx, cfg.UserID, components.FeedItems)
+	}
+	if err == nil && cfg.HasGroup() {
+		snap.Leaderboard, err = client.GetLeaderboard(ctx, cfg.GroupID, cfg.LeaderboardAllTime)
+	}
+	if err != nil {
+		fmt.Println(tui.ErrorStyle.Render("Failed to load dashboard: " + err.Error()))
+		return nil
+	}
+
+	if todayJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(snap)
+	}
+
+	fmt.Println(renderToday(cfg, snap))
+	return nil
+}
+
+// renderToday lays out the snapshot like the dashboard's HUD, minus the
+// input and help lines
+func renderToday(cfg *auth.Config, snap todaySnapshot) string {
+	snap.User.Level = levels.GetLevel(snap.User.TotalXP).Number
+
+	header := components.NewHeader(snap.User, snap.Stats, 70)
+	header.WeeklyGoal = cfg.WeeklyGoal
+	header.StreakFreezes = cfg.GetStreakFreezes()
+
+	quests := components.NewQuestPanel(snap.Quests, 36, 14)
+
+	var insight, insightType string
+	if snap.Stats != nil {
+		insight = snap.Stats.CompetitiveInsight
+		insightType = snap.Stats.InsightType
+	}
+	intel := components.NewIntelFeed(snap.Activity, snap.Leaderboard, insight, snap.User.Name, 38, 14)
+	intel.InsightType = insightType
+	intel.AllTime = cfg.LeaderboardAllTime
+
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		header.View(),
+		"",
+		lipgloss.JoinHorizontal(lipgloss.Top, quests.View(), "  ", intel.View()),
+	)
+}
+
+func init() {
+	todayCmd.Flags().BoolVar(&todayJSON, "json", false, "Print the snapshot as JSON")
+}
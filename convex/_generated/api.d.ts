@@ -11,7 +11,9 @@
 import type * as activity from "../activity.js";
 import type * as ai from "../ai.js";
 import type * as dashboard from "../dashboard.js";
+import type * as events from "../events.js";
 import type * as groups from "../groups.js";
+import type * as leaderboard from "../leaderboard.js";
 import type * as quests from "../quests.js";
 import type * as users from "../users.js";
 
@@ -25,7 +27,9 @@ declare const fullApi: ApiFromModules<{
   activity: typeof activity;
   ai: typeof ai;
   dashboard: typeof dashboard;
+  events: typeof events;
   groups: typeof groups;
+  leaderboard: typeof leaderboard;
   quests: typeof quests;
   users: typeof users;
 }>;
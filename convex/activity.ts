@@ -1,5 +1,6 @@
 import { v } from "convex/values";
-import { query } from "./_generated/server";
+import { mutation, query } from "./_generated/server";
+import { reactionEmoji } from "./schema";
 
 // Get recent activity for a group
 export const getRecent = query({
@@ -85,3 +86,31 @@ export const getTodayCount = query({
     };
   },
 });
+
+// Toggle a reaction on a crew member's quest completion
+export const react = mutation({
+  args: {
+    activityId: v.id("activity"),
+    userId: v.id("users"),
+    emoji: reactionEmoji,
+  },
+  handler: async (ctx, { activityId, userId, emoji }) => {
+    const activity = await ctx.db.get(activityId);
+    if (!activity) throw new Error("Activity not found");
+    if (activity.type !== "quest_completed") throw new Error("Can only react to completed quests");
+    if (activity.userId === userId) throw new Error("Cannot react to your own quest");
+
+    const user = await ctx.db.get(userId);
+    if (!user || user.groupId !== activity.groupId) throw new Error("Not in this crew");
+
+    const reactions = activity.reactions ?? [];
+    const existing = reactions.findIndex((r) => r.userId === userId && r.emoji === emoji);
+    const updated =
+      existing >= 0
+        ? reactions.filter((_, i) => i !== existing)
+        : [...reactions, { userId, emoji }];
+
+    await ctx.db.patch(activityId, { reactions: updated });
+    return updated;
+  },
+});
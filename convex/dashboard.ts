@@ -1,6 +1,9 @@
 import { v } from "convex/values";
 import { query, action } from "./_generated/server";
 import { api } from "./_generated/api";
+import { Id } from "./_generated/dataModel";
+import { xpEarnedToday } from "./quests";
+import { activeEvent } from "./events";
 
 // Deep philosophical quotes for grinders
 const QUOTES = [
@@ -34,6 +37,9 @@ const QUOTES = [
   "The grind reveals, it doesn't conceal.",
 ];
 
+// How far back completions are sent for streak tracking
+const STREAK_WINDOW_DAYS = 60;
+
 // Get dashboard stats for a user (public query)
 export const getStats = query({
   args: { userId: v.id("users") },
@@ -55,7 +61,15 @@ export const getStats = query({
       .collect();
 
     const todayCompleted = todayQuests.filter((q) => q.status === "completed");
-    const todayXP = todayCompleted.reduce((sum, q) => sum + q.xp, 0);
+    const todayXP = todayCompleted.reduce((sum, q) => sum + (q.xpEarned ?? q.xp), 0);
+
+    // Daily XP cap, if the crew opted in
+    const group = user.groupId ? await ctx.db.get(user.groupId) : null;
+    const xpCap = group?.dailyXpCap ?? 0;
+    const capUsed = xpCap > 0 ? await xpEarnedToday(ctx, userId) : 0;
+
+    // XP event running right now, if any
+    const event = await activeEvent(ctx, user.groupId, Date.now());
 
     // Get group stats if user is in a group
     let groupStats = null;
@@ -98,6 +112,7 @@ export const getStats = query({
       const groupTodayXP = todayGroupActivity
         .filter((a) => a.type === "quest_completed" && a.xp)
         .reduce((sum, a) => sum + (a.xp ?? 0), 0);
+      const groupWeeklyXP = members.reduce((sum, m) => sum + m.weeklyXp, 0);
 
       groupStats = {
         memberCount: members.length,
@@ -107,6 +122,8 @@ export const getStats = query({
         leaderXP: leader?.weeklyXp ?? 0,
         isUserLeading: leader?._id === userId,
         groupTodayXP,
+        groupWeeklyXP,
+        maxMembers: group?.maxMembers ?? 0,
       };
 
       // Get today's completed quests for each member
@@ -121,7 +138,7 @@ export const getStats = query({
           (q) => q.status === "completed"
         );
         const memberTodayXP = memberTodayCompleted.reduce(
-          (sum, q) => sum + q.xp,
+          (sum, q) => sum + (q.xpEarned ?? q.xp),
           0
         );
 
@@ -136,6 +153,16 @@ export const getStats = query({
       }
     }
 
+    // Completion times for the streak window; the client buckets them into
+    // local days so streaks follow the user's timezone
+    const streakCutoff = Date.now() - STREAK_WINDOW_DAYS * 24 * 60 * 60 * 1000;
+    const recentCompleted = await ctx.db
+      .query("quests")
+      .withIndex("by_user_status", (q) => q.eq("userId", userId).eq("status", "completed"))
+      .filter((q) => q.gte(q.field("completedAt"), streakCutoff))
+      .collect();
+    const recentCompletions = recentCompleted.map((q) => q.completedAt!);
+
     // Pick a random quote each time
     const quote = QUOTES[Math.floor(Math.random() * QUOTES.length)];
 
@@ -144,6 +171,8 @@ export const getStats = query({
         xp: todayXP,
         questsCompleted: todayCompleted.length,
         questsTotal: todayQuests.length,
+        xpCap,
+        capUsed,
       },
       week: {
         xp: user.weeklyXp,
@@ -153,6 +182,68 @@ export const getStats = query({
       quote,
       memberStats,
       userName: user.name,
+      recentCompletions,
+      event: event
+        ? { name: event.name, multiplier: event.multiplier, endsAt: event.endsAt }
+        : null,
+    };
+  },
+});
+
+// How many members the catch-up summary names as top performers
+const CATCH_UP_TOP = 3;
+
+// What the user's crew did since they were last here (since, Unix ms), for
+// the summary shown on launch after an absence. Null without a crew.
+export const getCatchUp = query({
+  args: { userId: v.id("users"), since: v.number() },
+  handler: async (ctx, { userId, since }) => {
+    const user = await ctx.db.get(userId);
+    if (!user || !user.groupId) {
+      return null;
+    }
+
+    const members = await ctx.db
+      .query("users")
+      .withIndex("by_group", (q) => q.eq("groupId", user.groupId))
+      .collect();
+    const names = new Map(members.map((m) => [m._id, m.name]));
+    const sorted = [...members].sort((a, b) => b.weeklyXp - a.weeklyXp);
+
+    const activity = await ctx.db
+      .query("activity")
+      .withIndex("by_group_created", (q) => q.eq("groupId", user.groupId!).gte("createdAt", since))
+      .collect();
+
+    const earned = new Map<Id<"users">, { xp: number; quests: number }>();
+    const levelUps: Array<{ userName: string; newLevel: number }> = [];
+    let questsCompleted = 0;
+    let xp = 0;
+    for (const a of activity) {
+      if (a.type === "quest_completed") {
+        const member = earned.get(a.userId) ?? { xp: 0, quests: 0 };
+        member.xp += a.xp ?? 0;
+        member.quests++;
+        earned.set(a.userId, member);
+        questsCompleted++;
+        xp += a.xp ?? 0;
+      } else if (a.type === "level_up" && a.newLevel) {
+        levelUps.push({ userName: names.get(a.userId) ?? "Unknown", newLevel: a.newLevel });
+      }
+    }
+
+    const top = [...earned.entries()]
+      .sort(([, a], [, b]) => b.xp - a.xp)
+      .slice(0, CATCH_UP_TOP)
+      .map(([id, m]) => ({ userName: names.get(id) ?? "Unknown", xp: m.xp, quests: m.quests }));
+
+    return {
+      since,
+      questsCompleted,
+      xp,
+      top,
+      levelUps,
+      rank: sorted.findIndex((m) => m._id === userId) + 1,
     };
   },
 });
@@ -162,7 +253,13 @@ type InsightType = "rivalry" | "analyst" | "stoic";
 
 // Stats result type for action return
 type StatsWithInsight = {
-  today: { xp: number; questsCompleted: number; questsTotal: number };
+  today: {
+    xp: number;
+    questsCompleted: number;
+    questsTotal: number;
+    xpCap: number;
+    capUsed: number;
+  };
   week: { xp: number; rank: number };
   group: {
     memberCount: number;
@@ -172,6 +269,8 @@ type StatsWithInsight = {
     leaderXP: number;
     isUserLeading: boolean;
     groupTodayXP: number;
+    groupWeeklyXP: number;
+    maxMembers: number;
   } | null;
   quote: string;
   memberStats: Array<{
@@ -183,6 +282,8 @@ type StatsWithInsight = {
     isCurrentUser: boolean;
   }>;
   userName: string;
+  recentCompletions: number[];
+  event: { name: string; multiplier: number; endsAt: number } | null;
   competitiveInsight: string;
   insightType: InsightType;
 };
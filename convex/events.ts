@@ -0,0 +1,49 @@
+import { v } from "convex/values";
+import { mutation, QueryCtx } from "./_generated/server";
+import { Id } from "./_generated/dataModel";
+
+// The XP event running at now for a crew, counting global events too. When
+// several overlap the biggest multiplier wins.
+export async function activeEvent(ctx: QueryCtx, groupId: Id<"groups"> | undefined, now: number) {
+  const global = await ctx.db
+    .query("xpEvents")
+    .withIndex("by_group", (q) => q.eq("groupId", undefined))
+    .collect();
+  const crew = groupId
+    ? await ctx.db
+        .query("xpEvents")
+        .withIndex("by_group", (q) => q.eq("groupId", groupId))
+        .collect()
+    : [];
+
+  let best = null;
+  for (const event of [...global, ...crew]) {
+    if (event.startsAt > now || event.endsAt <= now) continue;
+    if (!best || event.multiplier > best.multiplier) best = event;
+  }
+  return best;
+}
+
+// Schedule an XP event for a crew. Only the crew's creator can run one;
+// events for every crew are inserted from the Convex dashboard.
+export const create = mutation({
+  args: {
+    groupId: v.id("groups"),
+    userId: v.id("users"),
+    name: v.string(),
+    multiplier: v.number(),
+    startsAt: v.number(),
+    endsAt: v.number(),
+  },
+  handler: async (ctx, { groupId, userId, name, multiplier, startsAt, endsAt }) => {
+    const group = await ctx.db.get(groupId);
+    if (!group) throw new Error("Group not found");
+    if (group.createdBy !== userId) throw new Error("Only the crew's creator can run XP events");
+    if (multiplier <= 1 || multiplier > 5) {
+      throw new Error("Multiplier must be above 1 and at most 5");
+    }
+    if (endsAt <= startsAt) throw new Error("Event must end after it starts");
+
+    return await ctx.db.insert("xpEvents", { groupId, name, multiplier, startsAt, endsAt });
+  },
+});
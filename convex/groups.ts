@@ -1,5 +1,13 @@
-import { v } from "convex/values";
-import { mutation, query } from "./_generated/server";
+import { v, ConvexError } from "convex/values";
+import { mutation, query, MutationCtx } from "./_generated/server";
+import { Doc, Id } from "./_generated/dataModel";
+
+// How many members a new crew can hold
+export const DEFAULT_MAX_MEMBERS = 8;
+
+// Limits on a crew's custom level names; there are ten levels
+const MAX_LEVEL_NAMES = 10;
+const MAX_LEVEL_NAME_LENGTH = 24;
 
 // Create a new group
 export const create = mutation({
@@ -16,6 +24,7 @@ export const create = mutation({
       inviteCode,
       createdBy,
       createdAt: now,
+      maxMembers: DEFAULT_MAX_MEMBERS,
     });
 
     // Update user's group
@@ -36,6 +45,54 @@ export const get = query({
   },
 });
 
+// Set or clear (cap omitted) the crew's daily XP cap. Only the crew's
+// creator can change it.
+export const setDailyCap = mutation({
+  args: {
+    groupId: v.id("groups"),
+    userId: v.id("users"),
+    cap: v.optional(v.number()),
+  },
+  handler: async (ctx, { groupId, userId, cap }) => {
+    const group = await ctx.db.get(groupId);
+    if (!group) throw new Error("Group not found");
+    if (group.createdBy !== userId) throw new Error("Only the crew's creator can change the daily cap");
+    if (cap !== undefined && (!Number.isInteger(cap) || cap < 1 || cap > 10000)) {
+      throw new Error("Daily cap must be between 1 and 10000 XP");
+    }
+
+    await ctx.db.patch(groupId, { dailyXpCap: cap });
+    return true;
+  },
+});
+
+// Rename the crew's levels for display, or restore the defaults (names
+// omitted). Thresholds are unchanged. Only the crew's creator can do it.
+export const setLevelNames = mutation({
+  args: {
+    groupId: v.id("groups"),
+    userId: v.id("users"),
+    names: v.optional(v.array(v.string())),
+  },
+  handler: async (ctx, { groupId, userId, names }) => {
+    const group = await ctx.db.get(groupId);
+    if (!group) throw new Error("Group not found");
+    if (group.createdBy !== userId) throw new Error("Only the crew's creator can rename levels");
+
+    const trimmed = names?.map((name) => name.trim());
+    if (trimmed && trimmed.length > MAX_LEVEL_NAMES) {
+      throw new Error(`At most ${MAX_LEVEL_NAMES} level names`);
+    }
+    if (trimmed?.some((name) => name.length > MAX_LEVEL_NAME_LENGTH)) {
+      throw new Error(`Level names must be at most ${MAX_LEVEL_NAME_LENGTH} characters`);
+    }
+
+    const levelNames = trimmed && trimmed.some((name) => name !== "") ? trimmed : undefined;
+    await ctx.db.patch(groupId, { levelNames });
+    return true;
+  },
+});
+
 // Get group by invite code
 export const getByInviteCode = query({
   args: { inviteCode: v.string() },
@@ -54,14 +111,7 @@ export const join = mutation({
     inviteCode: v.string(),
   },
   handler: async (ctx, { userId, inviteCode }) => {
-    const group = await ctx.db
-      .query("groups")
-      .withIndex("by_invite_code", (q) => q.eq("inviteCode", inviteCode.toUpperCase()))
-      .unique();
-
-    if (!group) {
-      throw new Error("Invalid invite code");
-    }
+    const group = await groupByCode(ctx, inviteCode);
 
     const user = await ctx.db.get(userId);
     if (!user) {
@@ -72,26 +122,91 @@ export const join = mutation({
       throw new Error("Already in a group");
     }
 
-    const now = Date.now();
+    return await enterGroup(ctx, userId, group);
+  },
+});
 
-    // Update user's group
-    await ctx.db.patch(userId, {
-      groupId: group._id,
-      lastActiveAt: now,
-    });
+// Move to another group by invite code in one step. A mutation is a single
+// transaction, so if joining fails the user stays in their current group.
+export const switchGroup = mutation({
+  args: {
+    userId: v.id("users"),
+    inviteCode: v.string(),
+  },
+  handler: async (ctx, { userId, inviteCode }) => {
+    const group = await groupByCode(ctx, inviteCode);
 
-    // Log activity
-    await ctx.db.insert("activity", {
-      groupId: group._id,
-      userId,
-      type: "joined_group",
-      createdAt: now,
-    });
+    const user = await ctx.db.get(userId);
+    if (!user) {
+      throw new Error("User not found");
+    }
+
+    if (user.groupId === group._id) {
+      throw new Error("Already in that group");
+    }
+
+    return await enterGroup(ctx, userId, group);
+  },
+});
+
+// Leave the user's group, including one that has since been deleted
+export const leave = mutation({
+  args: { userId: v.id("users") },
+  handler: async (ctx, { userId }) => {
+    const user = await ctx.db.get(userId);
+    if (!user) {
+      throw new Error("User not found");
+    }
 
-    return { groupId: group._id, groupName: group.name };
+    await ctx.db.patch(userId, { groupId: undefined });
+    return true;
   },
 });
 
+// Look up the group an invite code belongs to
+async function groupByCode(ctx: MutationCtx, inviteCode: string): Promise<Doc<"groups">> {
+  const group = await ctx.db
+    .query("groups")
+    .withIndex("by_invite_code", (q) => q.eq("inviteCode", inviteCode.toUpperCase()))
+    .unique();
+
+  if (!group) {
+    throw new Error("Invalid invite code");
+  }
+  return group;
+}
+
+// Put a user in a group, unless it's at its member limit
+async function enterGroup(ctx: MutationCtx, userId: Id<"users">, group: Doc<"groups">) {
+  if (group.maxMembers !== undefined) {
+    const members = await ctx.db
+      .query("users")
+      .withIndex("by_group", (q) => q.eq("groupId", group._id))
+      .collect();
+    if (members.length >= group.maxMembers) {
+      throw new ConvexError({ code: "GROUP_FULL", maxMembers: group.maxMembers });
+    }
+  }
+
+  const now = Date.now();
+
+  // Update user's group
+  await ctx.db.patch(userId, {
+    groupId: group._id,
+    lastActiveAt: now,
+  });
+
+  // Log activity
+  await ctx.db.insert("activity", {
+    groupId: group._id,
+    userId,
+    type: "joined_group",
+    createdAt: now,
+  });
+
+  return { groupId: group._id, groupName: group.name };
+}
+
 // Get group members
 export const getMembers = query({
   args: { groupId: v.id("groups") },
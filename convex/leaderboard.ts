@@ -0,0 +1,123 @@
+import { v } from "convex/values";
+import { query, QueryCtx } from "./_generated/server";
+import { Doc, Id } from "./_generated/dataModel";
+
+// Start of the current week (Monday 00:00 local server time)
+function startOfWeek(): number {
+  const d = new Date();
+  const day = (d.getDay() + 6) % 7; // Monday = 0
+  d.setDate(d.getDate() - day);
+  d.setHours(0, 0, 0, 0);
+  return d.getTime();
+}
+
+// Rank group members by the given XP field
+async function rankMembers(
+  ctx: QueryCtx,
+  groupId: Id<"groups">,
+  field: "weeklyXp" | "totalXp",
+  limit: number
+) {
+  const users = await ctx.db
+    .query("users")
+    .withIndex("by_group", (q) => q.eq("groupId", groupId))
+    .collect();
+
+  users.sort((a, b) => b[field] - a[field]);
+
+  return users.slice(0, limit).map((user, index) => ({
+    rank: index + 1,
+    userId: user._id,
+    userName: user.name,
+    level: user.level,
+    weeklyXp: user.weeklyXp,
+    totalXp: user.totalXp,
+    lastActiveAt: user.lastActiveAt,
+  }));
+}
+
+// Weekly leaderboard for a group (ranked by weekly XP)
+export const weekly = query({
+  args: {
+    groupId: v.id("groups"),
+    limit: v.optional(v.number()),
+  },
+  handler: async (ctx, { groupId, limit = 10 }) => {
+    return await rankMembers(ctx, groupId, "weeklyXp", limit);
+  },
+});
+
+// All-time leaderboard for a group (ranked by total XP)
+export const allTime = query({
+  args: {
+    groupId: v.id("groups"),
+    limit: v.optional(v.number()),
+  },
+  handler: async (ctx, { groupId, limit = 10 }) => {
+    return await rankMembers(ctx, groupId, "totalXp", limit);
+  },
+});
+
+// Head-to-head comparison between a user and a crew rival.
+// If rivalName is omitted, picks the member just ahead (or just behind if leading).
+export const compare = query({
+  args: {
+    userId: v.id("users"),
+    rivalName: v.optional(v.string()),
+  },
+  handler: async (ctx, { userId, rivalName }) => {
+    const user = await ctx.db.get(userId);
+    if (!user || !user.groupId) {
+      return null;
+    }
+
+    const members = await ctx.db
+      .query("users")
+      .withIndex("by_group", (q) => q.eq("groupId", user.groupId))
+      .collect();
+
+    const sorted = [...members].sort((a, b) => b.weeklyXp - a.weeklyXp);
+    const userIndex = sorted.findIndex((m) => m._id === userId);
+
+    let rival: Doc<"users"> | undefined;
+    if (rivalName) {
+      const wanted = rivalName.toLowerCase();
+      rival = sorted.find((m) => m._id !== userId && m.name.toLowerCase() === wanted);
+      if (!rival) throw new Error(`No crew member named "${rivalName}"`);
+    } else if (userIndex > 0) {
+      rival = sorted[userIndex - 1];
+    } else {
+      rival = sorted[userIndex + 1];
+    }
+    if (!rival) {
+      return null;
+    }
+
+    const weekStart = startOfWeek();
+    const summarize = async (member: Doc<"users">) => {
+      const completed = await ctx.db
+        .query("quests")
+        .withIndex("by_user_status", (q) => q.eq("userId", member._id).eq("status", "completed"))
+        .filter((q) => q.gte(q.field("completedAt"), weekStart))
+        .collect();
+
+      return {
+        userId: member._id,
+        userName: member.name,
+        level: member.level,
+        rank: sorted.findIndex((m) => m._id === member._id) + 1,
+        weeklyXp: member.weeklyXp,
+        weeklyQuests: completed.length,
+      };
+    };
+
+    const you = await summarize(user);
+    const them = await summarize(rival);
+
+    return {
+      you,
+      rival: them,
+      gap: them.weeklyXp - you.weeklyXp,
+    };
+  },
+});
@@ -1,28 +1,87 @@
 import { v } from "convex/values";
-import { mutation, query, action } from "./_generated/server";
+import { mutation, query, action, MutationCtx, QueryCtx } from "./_generated/server";
 import { api } from "./_generated/api";
+import { Doc, Id } from "./_generated/dataModel";
+import { activeEvent } from "./events";
 
-// Create a new quest (calls AI for XP evaluation)
+// XP a user has been credited since local midnight, for the daily cap:
+// sub-task payouts made today, plus the rest of each quest completed today.
+// Sub-task XP paid on an earlier day was counted against that day's cap.
+export async function xpEarnedToday(ctx: QueryCtx, userId: Id<"users">): Promise<number> {
+  const startOfDay = new Date();
+  startOfDay.setHours(0, 0, 0, 0);
+  const start = startOfDay.getTime();
+
+  const completed = await ctx.db
+    .query("quests")
+    .withIndex("by_user_status", (q) => q.eq("userId", userId).eq("status", "completed"))
+    .filter((q) => q.gte(q.field("completedAt"), start))
+    .collect();
+  let total = completed.reduce(
+    (sum, q) => sum + (q.xpEarned ?? q.xp) - paidBetween(q, 0, start),
+    0
+  );
+
+  // Open or abandoned quests with a sub-task ticked off today
+  const paidToday = await ctx.db
+    .query("quests")
+    .withIndex("by_user_subtask_paid", (q) => q.eq("userId", userId).gte("subtaskPaidAt", start))
+    .filter((q) => q.neq(q.field("status"), "completed"))
+    .collect();
+  total += paidToday.reduce((sum, q) => sum + paidBetween(q, start, Infinity), 0);
+  return total;
+}
+
+// What a quest's sub-tasks credited at or after from and before to (ms).
+// Quests from before payouts were recorded count it all at completion.
+function paidBetween(quest: Doc<"quests">, from: number, to: number): number {
+  return (quest.subtaskPayouts ?? [])
+    .filter((p) => p.at >= from && p.at < to)
+    .reduce((sum, p) => sum + p.xp, 0);
+}
+
+// Create a new quest (calls AI for XP evaluation). A retry with the same
+// idempotencyKey returns the quest the first attempt created instead of a
+// duplicate.
 export const create = mutation({
   args: {
     userId: v.id("users"),
     title: v.string(),
+    notes: v.optional(v.string()),
     xp: v.number(),
     aiReasoning: v.string(),
+    idempotencyKey: v.optional(v.string()),
+    subtasks: v.optional(v.array(v.string())),
   },
-  handler: async (ctx, { userId, title, xp, aiReasoning }) => {
+  handler: async (ctx, { userId, title, notes, xp, aiReasoning, idempotencyKey, subtasks }) => {
     const user = await ctx.db.get(userId);
     if (!user) throw new Error("User not found");
 
+    if (idempotencyKey) {
+      const existing = await ctx.db
+        .query("quests")
+        .withIndex("by_user_idempotency_key", (q) =>
+          q.eq("userId", userId).eq("idempotencyKey", idempotencyKey)
+        )
+        .first();
+      if (existing) {
+        return { questId: existing._id, xp: existing.xp, aiReasoning: existing.aiReasoning };
+      }
+    }
+
     const now = Date.now();
+    const steps = (subtasks ?? []).map((t) => t.trim()).filter(Boolean);
     const questId = await ctx.db.insert("quests", {
       userId,
       groupId: user.groupId,
       title,
+      notes,
       xp,
       aiReasoning,
       status: "pending",
       createdAt: now,
+      idempotencyKey,
+      ...(steps.length ? { subtasks: steps.map((t) => ({ title: t, done: false })) } : {}),
     });
 
     // Log activity if in a group
@@ -48,6 +107,7 @@ export const complete = mutation({
     const quest = await ctx.db.get(questId);
     if (!quest) throw new Error("Quest not found");
     if (quest.status === "completed") throw new Error("Quest already completed");
+    if (quest.status === "abandoned") throw new Error("Cannot complete abandoned quest");
     // Allow completing from both pending and in_progress
 
     const user = await ctx.db.get(quest.userId);
@@ -55,23 +115,17 @@ export const complete = mutation({
 
     const now = Date.now();
 
+    // Finished sub-tasks already paid out part of the quest's XP; the rest
+    // comes now. The quest keeps its base xp.
+    const award = await awardXp(ctx, user, Math.max(quest.xp - (quest.subtaskXp ?? 0), 0), now);
+    const xpEarned = award.xpEarned;
+    const totalEarned = xpEarned + (quest.subtaskXpEarned ?? 0);
+
     // Update quest status
     await ctx.db.patch(questId, {
       status: "completed",
       completedAt: now,
-    });
-
-    // Update user XP
-    const newTotalXp = user.totalXp + quest.xp;
-    const newWeeklyXp = user.weeklyXp + quest.xp;
-    const newLevel = calculateLevel(newTotalXp);
-    const leveledUp = newLevel > user.level;
-
-    await ctx.db.patch(user._id, {
-      totalXp: newTotalXp,
-      weeklyXp: newWeeklyXp,
-      level: newLevel,
-      lastActiveAt: now,
+      ...(totalEarned !== quest.xp ? { xpEarned: totalEarned } : {}),
     });
 
     // Log activity if in a group
@@ -81,31 +135,135 @@ export const complete = mutation({
         userId: user._id,
         type: "quest_completed",
         questTitle: quest.title,
-        xp: quest.xp,
+        xp: xpEarned,
         createdAt: now,
       });
+    }
+    await logLevelUp(ctx, user, award, now);
 
-      if (leveledUp) {
-        await ctx.db.insert("activity", {
-          groupId: user.groupId,
-          userId: user._id,
-          type: "level_up",
-          newLevel,
-          createdAt: now,
-        });
-      }
+    return award;
+  },
+});
+
+// Share of a quest's XP each sub-task pays when it's ticked off: together
+// they're worth half the quest, and completing it pays the rest
+export function subtaskShare(xp: number, count: number): number {
+  return count > 0 ? Math.floor(xp / 2 / count) : 0;
+}
+
+// Add a sub-task to an open quest
+export const addSubtask = mutation({
+  args: { questId: v.id("quests"), title: v.string() },
+  handler: async (ctx, { questId, title }) => {
+    const quest = await ctx.db.get(questId);
+    if (!quest) throw new Error("Quest not found");
+    if (quest.status === "completed" || quest.status === "abandoned") {
+      throw new Error(`Cannot add sub-tasks to ${quest.status} quest`);
     }
+    title = title.trim();
+    if (!title) throw new Error("Sub-task can't be blank");
 
-    return {
-      xpEarned: quest.xp,
-      newTotalXp,
-      newWeeklyXp,
-      leveledUp,
-      newLevel,
-    };
+    const subtasks = [...(quest.subtasks ?? []), { title, done: false }];
+    await ctx.db.patch(questId, { subtasks });
+    return { questId, subtasks };
   },
 });
 
+// Tick off one of an open quest's sub-tasks (by index) and pay its share
+// of the quest's XP
+export const completeSubtask = mutation({
+  args: { questId: v.id("quests"), index: v.number() },
+  handler: async (ctx, { questId, index }) => {
+    const quest = await ctx.db.get(questId);
+    if (!quest) throw new Error("Quest not found");
+    if (quest.status === "completed" || quest.status === "abandoned") {
+      throw new Error(`Quest already ${quest.status}`);
+    }
+    const subtasks = [...(quest.subtasks ?? [])];
+    if (!Number.isInteger(index) || index < 0 || index >= subtasks.length) {
+      throw new Error("Sub-task not found");
+    }
+    if (subtasks[index].done) throw new Error("Sub-task already done");
+
+    const user = await ctx.db.get(quest.userId);
+    if (!user) throw new Error("User not found");
+
+    const now = Date.now();
+    const paid = quest.subtaskXp ?? 0;
+    const share = Math.max(Math.min(subtaskShare(quest.xp, subtasks.length), quest.xp - paid), 0);
+    const award = await awardXp(ctx, user, share, now);
+
+    subtasks[index] = { ...subtasks[index], done: true };
+    await ctx.db.patch(questId, {
+      subtasks,
+      subtaskXp: paid + share,
+      subtaskXpEarned: (quest.subtaskXpEarned ?? 0) + award.xpEarned,
+      subtaskPayouts: [...(quest.subtaskPayouts ?? []), { at: now, xp: award.xpEarned }],
+      subtaskPaidAt: now,
+    });
+    await logLevelUp(ctx, user, award, now);
+
+    return award;
+  },
+});
+
+type Award = {
+  xpEarned: number;
+  capped: boolean;
+  multiplier: number;
+  newTotalXp: number;
+  newWeeklyXp: number;
+  leveledUp: boolean;
+  newLevel: number;
+};
+
+// Credit base XP to a user: boosted by any running XP event, then cut to
+// what's left under the crew's daily cap, if it has one
+async function awardXp(ctx: MutationCtx, user: Doc<"users">, xp: number, now: number): Promise<Award> {
+  const event = await activeEvent(ctx, user.groupId, now);
+  const multiplier = event?.multiplier ?? 1;
+  const boosted = Math.round(xp * multiplier);
+  let xpEarned = boosted;
+  const group = user.groupId ? await ctx.db.get(user.groupId) : null;
+  if (group?.dailyXpCap !== undefined) {
+    const remaining = Math.max(group.dailyXpCap - (await xpEarnedToday(ctx, user._id)), 0);
+    xpEarned = Math.min(boosted, remaining);
+  }
+
+  const newTotalXp = user.totalXp + xpEarned;
+  const newWeeklyXp = user.weeklyXp + xpEarned;
+  const newLevel = calculateLevel(newTotalXp);
+
+  await ctx.db.patch(user._id, {
+    totalXp: newTotalXp,
+    weeklyXp: newWeeklyXp,
+    level: newLevel,
+    lastActiveAt: now,
+  });
+
+  return {
+    xpEarned,
+    capped: xpEarned < boosted,
+    multiplier,
+    newTotalXp,
+    newWeeklyXp,
+    leveledUp: newLevel > user.level,
+    newLevel,
+  };
+}
+
+// Tell the crew about a level reached through an award
+async function logLevelUp(ctx: MutationCtx, user: Doc<"users">, award: Award, now: number) {
+  if (!user.groupId || !award.leveledUp) return;
+  await ctx.db.insert("activity", {
+    groupId: user.groupId,
+    userId: user._id,
+    type: "level_up",
+    newLevel: award.newLevel,
+    createdAt: now,
+  });
+}
+
 // Start a quest (pending → in_progress)
 export const start = mutation({
   args: { questId: v.id("quests") },
@@ -139,46 +297,193 @@ export const start = mutation({
   },
 });
 
+// Abandon a quest (pending/in_progress → abandoned). Unlike deleting, the
+// quest stays in history and the crew sees it was dropped.
+export const abandon = mutation({
+  args: { questId: v.id("quests") },
+  handler: async (ctx, { questId }) => {
+    const quest = await ctx.db.get(questId);
+    if (!quest) throw new Error("Quest not found");
+    if (quest.status === "completed") throw new Error("Cannot abandon completed quest");
+    if (quest.status === "abandoned") throw new Error("Quest already abandoned");
+
+    const user = await ctx.db.get(quest.userId);
+    if (!user) throw new Error("User not found");
+
+    const now = Date.now();
+
+    // Sub-task XP already paid is kept: the steps were done, and it stays
+    // in subtaskPayouts so today's cap still counts it
+    await ctx.db.patch(questId, {
+      status: "abandoned",
+      abandonedAt: now,
+    });
+
+    // Log activity if in a group
+    if (user.groupId) {
+      await ctx.db.insert("activity", {
+        groupId: user.groupId,
+        userId: user._id,
+        type: "quest_abandoned",
+        questTitle: quest.title,
+        createdAt: now,
+      });
+    }
+
+    return { questId, status: "abandoned" };
+  },
+});
+
+// Update a quest's title, notes, and/or XP (a manual XP override replaces
+// the AI reasoning so it's distinguishable)
+export const update = mutation({
+  args: {
+    questId: v.id("quests"),
+    title: v.optional(v.string()),
+    notes: v.optional(v.string()),
+    xp: v.optional(v.number()),
+  },
+  handler: async (ctx, { questId, title, notes, xp }) => {
+    const quest = await ctx.db.get(questId);
+    if (!quest) throw new Error("Quest not found");
+
+    const patch: { title?: string; notes?: string; xp?: number; aiReasoning?: string } = {};
+    if (title !== undefined) patch.title = title;
+    if (notes !== undefined) patch.notes = notes;
+    if (xp !== undefined) {
+      if (quest.status === "completed" || quest.status === "abandoned") {
+        throw new Error(`Cannot change XP of ${quest.status} quest`);
+      }
+      if (!Number.isInteger(xp) || xp < 0 || xp > 150) throw new Error("XP must be between 0 and 150");
+      patch.xp = xp;
+      patch.aiReasoning = "manually set";
+    }
+
+    await ctx.db.patch(questId, patch);
+    return { ...quest, ...patch };
+  },
+});
+
 // Get user's quests
 export const list = query({
   args: {
     userId: v.id("users"),
-    status: v.optional(v.union(v.literal("pending"), v.literal("in_progress"), v.literal("completed"))),
+    status: v.optional(
+      v.union(v.literal("pending"), v.literal("in_progress"), v.literal("completed"), v.literal("abandoned"))
+    ),
+    since: v.optional(v.number()), // Only quests created at or after this time
+    limit: v.optional(v.number()), // At most this many, newest first
   },
-  handler: async (ctx, { userId, status }) => {
-    let quests;
-    if (status) {
-      quests = await ctx.db
+  handler: async (ctx, { userId, status, since, limit }) => {
+    if (!status) {
+      // Newest first straight off the index, so a limit reads only what
+      // it returns
+      const newest = ctx.db
         .query("quests")
-        .withIndex("by_user_status", (q) => q.eq("userId", userId).eq("status", status))
-        .collect();
-    } else {
-      quests = await ctx.db
-        .query("quests")
-        .withIndex("by_user", (q) => q.eq("userId", userId))
-        .collect();
+        .withIndex("by_user_created", (q) => q.eq("userId", userId).gte("createdAt", since ?? 0))
+        .order("desc");
+      return limit ? await newest.take(limit) : await newest.collect();
     }
 
+    const quests = await ctx.db
+      .query("quests")
+      .withIndex("by_user_status", (q) => q.eq("userId", userId).eq("status", status))
+      .collect();
+
     // Sort by createdAt descending
-    return quests.sort((a, b) => b.createdAt - a.createdAt);
+    const sorted = quests
+      .filter((q) => q.createdAt >= (since ?? 0))
+      .sort((a, b) => b.createdAt - a.createdAt);
+    return limit ? sorted.slice(0, limit) : sorted;
   },
 });
 
-// Get today's quests
+// Get a single quest by ID, or null if it was deleted
+export const get = query({
+  args: { questId: v.id("quests") },
+  handler: async (ctx, { questId }) => {
+    return await ctx.db.get(questId);
+  },
+});
+
+// Get today's quests (including snoozed quests whose day has come)
 export const listToday = query({
   args: { userId: v.id("users") },
   handler: async (ctx, { userId }) => {
     const startOfDay = new Date();
     startOfDay.setHours(0, 0, 0, 0);
     const startTimestamp = startOfDay.getTime();
+    const now = Date.now();
 
-    const quests = await ctx.db
+    // Created today and never snoozed
+    const created = await ctx.db
+      .query("quests")
+      .withIndex("by_user_created", (q) =>
+        q.eq("userId", userId).gte("createdAt", startTimestamp)
+      )
+      .filter((q) => q.eq(q.field("snoozedUntil"), undefined))
+      .collect();
+
+    // Snoozed quests are hidden until the snooze expires, then shown for
+    // that whole day, whenever they were created
+    const woken = await ctx.db
       .query("quests")
-      .withIndex("by_user_created", (q) => q.eq("userId", userId))
-      .filter((q) => q.gte(q.field("createdAt"), startTimestamp))
+      .withIndex("by_user_snoozed", (q) =>
+        q.eq("userId", userId).gte("snoozedUntil", startTimestamp).lte("snoozedUntil", now)
+      )
       .collect();
 
-    return quests.sort((a, b) => a.createdAt - b.createdAt);
+    return [...created, ...woken].sort(byOrder);
+  },
+});
+
+// Sort by the user's manual order; quests never reordered keep creation
+// order and land after the ordered ones
+function byOrder(a: Doc<"quests">, b: Doc<"quests">): number {
+  const aOrdered = a.order !== undefined;
+  const bOrdered = b.order !== undefined;
+  if (aOrdered && bOrdered && a.order !== b.order) return a.order! - b.order!;
+  if (aOrdered !== bOrdered) return aOrdered ? -1 : 1;
+  return a.createdAt - b.createdAt;
+}
+
+// Reorder quests: questIds is the full list in its new display order
+export const reorder = mutation({
+  args: {
+    userId: v.id("users"),
+    questIds: v.array(v.id("quests")),
+  },
+  handler: async (ctx, { userId, questIds }) => {
+    for (const questId of questIds) {
+      const quest = await ctx.db.get(questId);
+      if (!quest) throw new Error("Quest not found");
+      if (quest.userId !== userId) throw new Error("Cannot reorder another user's quest");
+    }
+
+    for (let i = 0; i < questIds.length; i++) {
+      await ctx.db.patch(questIds[i], { order: i });
+    }
+    return true;
+  },
+});
+
+// Snooze a quest until the start of tomorrow
+export const snooze = mutation({
+  args: { questId: v.id("quests") },
+  handler: async (ctx, { questId }) => {
+    const quest = await ctx.db.get(questId);
+    if (!quest) throw new Error("Quest not found");
+    if (quest.status === "completed" || quest.status === "abandoned") {
+      throw new Error(`Cannot snooze ${quest.status} quest`);
+    }
+
+    const tomorrow = new Date();
+    tomorrow.setHours(0, 0, 0, 0);
+    tomorrow.setDate(tomorrow.getDate() + 1);
+    const snoozedUntil = tomorrow.getTime();
+
+    await ctx.db.patch(questId, { snoozedUntil });
+    return { questId, snoozedUntil };
   },
 });
 
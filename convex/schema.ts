@@ -1,6 +1,13 @@
 import { defineSchema, defineTable } from "convex/server";
 import { v } from "convex/values";
 
+// Reactions crew members can leave on each other's completions
+export const reactionEmoji = v.union(
+  v.literal("fire"),
+  v.literal("muscle"),
+  v.literal("clap")
+);
+
 export default defineSchema({
   users: defineTable({
     name: v.string(),
@@ -11,8 +18,10 @@ export default defineSchema({
     level: v.number(),
     createdAt: v.number(),
     lastActiveAt: v.number(),
+    signupKey: v.optional(v.string()), // Client key that makes a retried create idempotent
   })
     .index("by_email", ["email"])
+    .index("by_signup_key", ["signupKey"])
     .index("by_group", ["groupId"])
     .index("by_total_xp", ["totalXp"])
     .index("by_weekly_xp", ["groupId", "weeklyXp"]),
@@ -22,21 +31,43 @@ export default defineSchema({
     inviteCode: v.string(),
     createdBy: v.id("users"),
     createdAt: v.number(),
+    dailyXpCap: v.optional(v.number()), // Opt-in per crew; unset means no cap
+    maxMembers: v.optional(v.number()), // Unset (crews from before the limit) means no limit
+    levelNames: v.optional(v.array(v.string())), // Crew's own level names, in order; blank keeps the default
   }).index("by_invite_code", ["inviteCode"]),
 
   quests: defineTable({
     userId: v.id("users"),
     groupId: v.optional(v.id("groups")),
     title: v.string(),
+    notes: v.optional(v.string()),
     xp: v.number(),
     aiReasoning: v.string(),
-    status: v.union(v.literal("pending"), v.literal("in_progress"), v.literal("completed")),
+    status: v.union(
+      v.literal("pending"),
+      v.literal("in_progress"),
+      v.literal("completed"),
+      v.literal("abandoned") // Dropped on purpose; kept for history, earns nothing more (paid sub-tasks are kept)
+    ),
     createdAt: v.number(),
     completedAt: v.optional(v.number()),
+    abandonedAt: v.optional(v.number()),
+    xpEarned: v.optional(v.number()), // Set when an event multiplier or the daily cap changed the award from xp
+    snoozedUntil: v.optional(v.number()),
+    order: v.optional(v.number()),
+    idempotencyKey: v.optional(v.string()), // Client key that makes a retried create idempotent
+    subtasks: v.optional(v.array(v.object({ title: v.string(), done: v.boolean() }))),
+    subtaskXp: v.optional(v.number()), // Base XP already paid out by finished sub-tasks
+    subtaskXpEarned: v.optional(v.number()), // What those payouts credited after events and the cap
+    subtaskPayouts: v.optional(v.array(v.object({ at: v.number(), xp: v.number() }))), // Each payout's credit and time, so the daily cap counts it on the day it was paid
+    subtaskPaidAt: v.optional(v.number()), // Time of the latest payout, to find quests paid today
   })
     .index("by_user", ["userId"])
     .index("by_user_status", ["userId", "status"])
     .index("by_user_created", ["userId", "createdAt"])
+    .index("by_user_snoozed", ["userId", "snoozedUntil"])
+    .index("by_user_idempotency_key", ["userId", "idempotencyKey"])
+    .index("by_user_subtask_paid", ["userId", "subtaskPaidAt"])
     .index("by_group", ["groupId"]),
 
   activity: defineTable({
@@ -46,14 +77,27 @@ export default defineSchema({
       v.literal("quest_created"),
       v.literal("quest_started"),
       v.literal("quest_completed"),
+      v.literal("quest_abandoned"),
       v.literal("level_up"),
       v.literal("joined_group")
     ),
     questTitle: v.optional(v.string()),
     xp: v.optional(v.number()),
     newLevel: v.optional(v.number()),
+    reactions: v.optional(
+      v.array(v.object({ userId: v.id("users"), emoji: reactionEmoji }))
+    ),
     createdAt: v.number(),
   })
     .index("by_group", ["groupId"])
     .index("by_group_created", ["groupId", "createdAt"]),
+
+  // Time-bounded XP multipliers ("happy hours")
+  xpEvents: defineTable({
+    groupId: v.optional(v.id("groups")), // Unset runs for every crew
+    name: v.string(),
+    multiplier: v.number(),
+    startsAt: v.number(),
+    endsAt: v.number(),
+  }).index("by_group", ["groupId"]),
 });
@@ -1,13 +1,25 @@
 import { v } from "convex/values";
 import { mutation, query } from "./_generated/server";
 
-// Create a new user
+// Create a new user. A retry with the same signupKey returns the user the
+// first attempt created instead of a duplicate.
 export const create = mutation({
   args: {
     name: v.string(),
     email: v.optional(v.string()),
+    signupKey: v.optional(v.string()),
   },
-  handler: async (ctx, { name, email }) => {
+  handler: async (ctx, { name, email, signupKey }) => {
+    if (signupKey) {
+      const existing = await ctx.db
+        .query("users")
+        .withIndex("by_signup_key", (q) => q.eq("signupKey", signupKey))
+        .first();
+      if (existing) {
+        return existing._id;
+      }
+    }
+
     const now = Date.now();
     const userId = await ctx.db.insert("users", {
       name,
@@ -17,6 +29,7 @@ export const create = mutation({
       level: 1,
       createdAt: now,
       lastActiveAt: now,
+      signupKey,
     });
     return userId;
   },
@@ -30,6 +43,28 @@ export const get = query({
   },
 });
 
+// Update a user's profile. Activity and leaderboards look names up live,
+// so a new name shows everywhere right away.
+export const update = mutation({
+  args: {
+    userId: v.id("users"),
+    name: v.optional(v.string()),
+  },
+  handler: async (ctx, { userId, name }) => {
+    const user = await ctx.db.get(userId);
+    if (!user) throw new Error("User not found");
+
+    if (name !== undefined) {
+      const trimmed = name.trim();
+      if (trimmed.length === 0 || trimmed.length > 32) {
+        throw new Error("Name must be 1-32 characters");
+      }
+      await ctx.db.patch(userId, { name: trimmed });
+    }
+    return await ctx.db.get(userId);
+  },
+});
+
 // Get user by email
 export const getByEmail = query({
   args: { email: v.string() },
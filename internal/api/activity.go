@@ -0,0 +1,70 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"slices"
+)
+
+// ReactionEmoji lists the reactions crew members can leave, in display order
+var ReactionEmoji = []string{"fire", "muscle", "clap"}
+
+// ListActivity fetches the user's crew activity feed, newest first, via
+// activity:getUserActivity
+func (c *Client) ListActivity(ctx context.Context, userID string, limit int) ([]Activity, error) {
+	result, err := c.Query(ctx, "activity:getUserActivity", map[string]any{
+		"userId": userID,
+		"limit":  limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var activities []Activity
+	if result == nil {
+		return activities, nil
+	}
+	if err := DecodeInto(result, &activities); err != nil {
+		return nil, fmt.Errorf("decode activity: %w", err)
+	}
+	return activities, nil
+}
+
+// React toggles userID's emoji reaction on a crew member's quest completion
+// via activity:react
+func (c *Client) React(ctx context.Context, activityID, userID, emoji string) error {
+	_, err := c.Mutation(ctx, "activity:react", map[string]any{
+		"activityId": activityID,
+		"userId":     userID,
+		"emoji":      emoji,
+	})
+	return err
+}
+
+// CanReact reports whether userID may react to the activity: only other
+// people's quest completions take reactions
+func (a Activity) CanReact(userID string) bool {
+	return a.Type == "quest_completed" && a.UserID != userID
+}
+
+// ToggleReaction adds or removes userID's emoji locally, mirroring what
+// activity:react does on the backend
+func (a *Activity) ToggleReaction(userID, emoji string) {
+	i := slices.IndexFunc(a.Reactions, func(r Reaction) bool {
+		return r.UserID == userID && r.Emoji == emoji
+	})
+	if i >= 0 {
+		a.Reactions = slices.Delete(a.Reactions, i, i+1)
+		return
+	}
+	a.Reactions = append(a.Reactions, Reaction{UserID: userID, Emoji: emoji})
+}
+
+// ReactionCounts tallies reactions by emoji
+func (a Activity) ReactionCounts() map[string]int {
+	counts := make(map[string]int, len(ReactionEmoji))
+	for _, r := range a.Reactions {
+		counts[r.Emoji]++
+	}
+	return counts
+}
@@ -0,0 +1,131 @@
+// Package apitest provides stand-ins for the Convex backend so models and
+// commands can be exercised without a deployment: Fake answers calls
+// in-process through api.NewClientFrom, and Server serves the same answers
+// over HTTP to cover the real client's request and response handling.
+package apitest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"grind/internal/api"
+)
+
+// Handler answers one Convex function call
+type Handler func(args map[string]any) (any, error)
+
+// Call records a function call received by a Fake
+type Call struct {
+	Kind string // "query", "mutation" or "action"
+	Path string // e.g. "quests:listToday"
+	Args map[string]any
+}
+
+// Fake is an api.ConvexAPI that answers calls from registered handlers.
+// It is safe for concurrent use, since TUI commands run in goroutines.
+type Fake struct {
+	mu       sync.Mutex
+	handlers map[string]Handler
+	calls    []Call
+}
+
+var _ api.ConvexAPI = (*Fake)(nil)
+
+// NewFake creates a Fake with no responses registered. Calls to a path
+// without a handler fail.
+func NewFake() *Fake {
+	return &Fake{handlers: make(map[string]Handler)}
+}
+
+// Client returns an api.Client backed by the fake
+func (f *Fake) Client() *api.Client {
+	return api.NewClientFrom(f)
+}
+
+// On registers h to answer calls to path
+func (f *Fake) On(path string, h Handler) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.handlers[path] = h
+}
+
+// Return makes calls to path return value. The value is round-tripped
+// through JSON, so typed structs like []api.Quest come back as the maps
+// and float64s a real deployment returns.
+func (f *Fake) Return(path string, value any) {
+	f.On(path, func(map[string]any) (any, error) {
+		return roundTrip(value)
+	})
+}
+
+// Fail makes calls to path throw a ConvexError, as a function that calls
+// `throw new ConvexError(data)` would. data may be nil for a plain Error.
+func (f *Fake) Fail(path, message string, data any) {
+	f.On(path, func(map[string]any) (any, error) {
+		return nil, &api.ConvexError{Message: message, Data: data}
+	})
+}
+
+// Calls returns the calls received so far, oldest first
+func (f *Fake) Calls() []Call {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Call(nil), f.calls...)
+}
+
+// CallsTo returns the calls received for path, oldest first
+func (f *Fake) CallsTo(path string) []Call {
+	var matched []Call
+	for _, c := range f.Calls() {
+		if c.Path == path {
+			matched = append(matched, c)
+		}
+	}
+	return matched
+}
+
+// Query answers a query call
+func (f *Fake) Query(ctx context.Context, path string, args map[string]any) (any, error) {
+	return f.call(ctx, "query", path, args)
+}
+
+// Mutation answers a mutation call
+func (f *Fake) Mutation(ctx context.Context, path string, args map[string]any) (any, error) {
+	return f.call(ctx, "mutation", path, args)
+}
+
+// Action answers an action call
+func (f *Fake) Action(ctx context.Context, path string, args map[string]any) (any, error) {
+	return f.call(ctx, "action", path, args)
+}
+
+func (f *Fake) call(ctx context.Context, kind, path string, args map[string]any) (any, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	f.calls = append(f.calls, Call{Kind: kind, Path: path, Args: args})
+	h, ok := f.handlers[path]
+	f.mu.Unlock()
+
+	if !ok {
+		return nil, fmt.Errorf("apitest: no response for %s %s", kind, path)
+	}
+	return h(args)
+}
+
+// roundTrip converts v to the generic JSON shape a response decodes to
+func roundTrip(v any) (any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("apitest: marshal response: %w", err)
+	}
+	var out any
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, fmt.Errorf("apitest: unmarshal response: %w", err)
+	}
+	return out, nil
+}
@@ -0,0 +1,94 @@
+package apitest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"grind/internal/api"
+)
+
+// Server is an HTTP Convex deployment backed by a Fake. Point api.NewClient
+// at URL to exercise the real client's encoding, status handling and error
+// unwrapping.
+type Server struct {
+	*httptest.Server
+	Fake *Fake
+
+	mu       sync.Mutex
+	statuses map[string]rawResponse
+}
+
+// rawResponse is a canned HTTP reply that bypasses the Fake
+type rawResponse struct {
+	status int
+	body   string
+}
+
+// NewServer starts a server answering from fake, or from a new Fake if nil.
+// Callers should Close it when done.
+func NewServer(fake *Fake) *Server {
+	if fake == nil {
+		fake = NewFake()
+	}
+	s := &Server{Fake: fake, statuses: make(map[string]rawResponse)}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	return s
+}
+
+// Client returns an api.Client pointed at the server
+func (s *Server) Client() *api.Client {
+	return api.NewClient(s.URL)
+}
+
+// Status makes calls to path reply with an HTTP status and raw body
+// instead of a Convex response, e.g. 502 from a proxy or malformed JSON
+// with 200
+func (s *Server) Status(path string, status int, body string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.statuses[path] = rawResponse{status: status, body: body}
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	kind := strings.TrimPrefix(r.URL.Path, "/api/")
+	if r.Method != http.MethodPost || (kind != "query" && kind != "mutation" && kind != "action") {
+		http.NotFound(w, r)
+		return
+	}
+
+	var req api.ConvexRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "bad request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	raw, ok := s.statuses[req.Path]
+	s.mu.Unlock()
+	if ok {
+		w.WriteHeader(raw.status)
+		_, _ = w.Write([]byte(raw.body))
+		return
+	}
+
+	value, err := s.Fake.call(r.Context(), kind, req.Path, req.Args)
+	resp := api.ConvexResponse{Status: "success", Value: value}
+	var convexErr *api.ConvexError
+	switch {
+	case errors.As(err, &convexErr):
+		resp = api.ConvexResponse{Status: "error", ErrorMessage: convexErr.Message, ErrorData: convexErr.Data}
+	case errors.Is(err, context.Canceled):
+		return
+	case err != nil:
+		// Convex reports unknown functions and server faults as errors too
+		resp = api.ConvexResponse{Status: "error", ErrorMessage: err.Error()}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
@@ -4,19 +4,47 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"math"
+	"net"
 	"net/http"
+	"net/url"
 	"time"
+
+	"grind/internal/logging"
 )
 
+// ConvexAPI calls Convex functions by path and returns their raw JSON
+// values. *Client satisfies it over HTTP; apitest.Fake satisfies it with
+// canned responses.
+type ConvexAPI interface {
+	Query(ctx context.Context, path string, args map[string]any) (any, error)
+	Mutation(ctx context.Context, path string, args map[string]any) (any, error)
+	Action(ctx context.Context, path string, args map[string]any) (any, error)
+}
+
+var _ ConvexAPI = (*Client)(nil)
+
 // Client wraps the Convex HTTP API
 type Client struct {
 	baseURL    string
 	httpClient *http.Client
 	token      string
+	backend    ConvexAPI // Replaces HTTP when set, see NewClientFrom
+
+	// onRetry is told before each retry of a failed call, see SetRetryHook
+	onRetry func(attempt, max int)
 }
 
+// MaxAttempts is how many times a call is tried before a transient failure
+// is returned
+const MaxAttempts = 3
+
+// retryDelay is the wait before the first retry; it doubles each time
+const retryDelay = 500 * time.Millisecond
+
 // NewClient creates a new Convex API client
 func NewClient(deploymentURL string) *Client {
 	return &Client{
@@ -25,11 +53,37 @@ func NewClient(deploymentURL string) *Client {
 	}
 }
 
+// NewClientFrom creates a client that sends every call to backend instead
+// of a deployment, so models and commands can run against a fake
+func NewClientFrom(backend ConvexAPI) *Client {
+	return &Client{backend: backend}
+}
+
+// ClientFor returns the typed client for backend: backend itself if it is
+// already a *Client, otherwise one wrapping it. A nil backend (local mode)
+// gives a nil client.
+func ClientFor(backend ConvexAPI) *Client {
+	if backend == nil {
+		return nil
+	}
+	if c, ok := backend.(*Client); ok {
+		return c
+	}
+	return NewClientFrom(backend)
+}
+
 // SetToken sets the auth token for API calls
 func (c *Client) SetToken(token string) {
 	c.token = token
 }
 
+// SetRetryHook sets fn to be called before each retry of a failed call,
+// with the attempt about to be made (2 for the first retry) and
+// MaxAttempts. It may be called from any goroutine.
+func (c *Client) SetRetryHook(fn func(attempt, max int)) {
+	c.onRetry = fn
+}
+
 // ConvexRequest represents a request to the Convex API
 type ConvexRequest struct {
 	Path   string         `json:"path"`
@@ -47,20 +101,106 @@ type ConvexResponse struct {
 
 // Query executes a Convex query function
 func (c *Client) Query(ctx context.Context, path string, args map[string]any) (any, error) {
+	if c.backend != nil {
+		return c.backend.Query(ctx, path, args)
+	}
 	return c.call(ctx, "/api/query", path, args)
 }
 
 // Mutation executes a Convex mutation function
 func (c *Client) Mutation(ctx context.Context, path string, args map[string]any) (any, error) {
+	if c.backend != nil {
+		return c.backend.Mutation(ctx, path, args)
+	}
 	return c.call(ctx, "/api/mutation", path, args)
 }
 
 // Action executes a Convex action function
 func (c *Client) Action(ctx context.Context, path string, args map[string]any) (any, error) {
+	if c.backend != nil {
+		return c.backend.Action(ctx, path, args)
+	}
 	return c.call(ctx, "/api/action", path, args)
 }
 
+// call sends a request, retrying transient failures with a backoff. Only
+// queries are retried once a request may have reached the backend;
+// mutations and actions are retried only when the connection itself
+// failed, so they never run twice.
 func (c *Client) call(ctx context.Context, endpoint, path string, args map[string]any) (any, error) {
+	for attempt := 1; ; attempt++ {
+		value, err := c.logged(ctx, endpoint, path, args)
+		if err == nil || attempt == MaxAttempts || !retryable(endpoint, err) {
+			return value, err
+		}
+
+		if c.onRetry != nil {
+			c.onRetry(attempt+1, MaxAttempts)
+		}
+		logging.Info("api retry", "path", path, "attempt", attempt+1, "err", err)
+
+		select {
+		case <-ctx.Done():
+			return nil, err
+		case <-time.After(retryDelay << (attempt - 1)):
+		}
+	}
+}
+
+// logged sends a single request, logging it when the debug log is on
+func (c *Client) logged(ctx context.Context, endpoint, path string, args map[string]any) (any, error) {
+	if logging.Enabled() {
+		start := time.Now()
+		value, err := c.do(ctx, endpoint, path, args)
+		if err != nil {
+			logging.Error("api call failed", "path", path, "duration", time.Since(start), "err", err)
+		} else {
+			logging.Debug("api call", "path", path, "duration", time.Since(start))
+		}
+		return value, err
+	}
+	return c.do(ctx, endpoint, path, args)
+}
+
+// retryable reports whether a failed call to endpoint is worth trying
+// again: a connection that never opened, or for queries any network
+// failure, server error, or rate limit
+func retryable(endpoint string, err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+	if endpoint != "/api/query" {
+		return false
+	}
+
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return true
+	}
+	var statusErr *httpError
+	if errors.As(err, &statusErr) {
+		return statusErr.status >= 500 || statusErr.status == http.StatusTooManyRequests
+	}
+	return false
+}
+
+// httpError is a non-200 response from the backend
+type httpError struct {
+	status int
+	body   string
+}
+
+func (e *httpError) Error() string {
+	return fmt.Sprintf("http error %d: %s", e.status, e.body)
+}
+
+// do sends a single request to a Convex endpoint and unwraps the response
+func (c *Client) do(ctx context.Context, endpoint, path string, args map[string]any) (any, error) {
 	if args == nil {
 		args = make(map[string]any)
 	}
@@ -98,7 +238,7 @@ func (c *Client) call(ctx context.Context, endpoint, path string, args map[strin
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("http error %d: %s", resp.StatusCode, string(respBody))
+		return nil, &httpError{status: resp.StatusCode, body: string(respBody)}
 	}
 
 	var result ConvexResponse
@@ -107,12 +247,30 @@ func (c *Client) call(ctx context.Context, endpoint, path string, args map[strin
 	}
 
 	if result.Status == "error" {
-		return nil, fmt.Errorf("convex error: %s", result.ErrorMessage)
+		return nil, &ConvexError{Message: result.ErrorMessage, Data: result.ErrorData}
 	}
 
 	return result.Value, nil
 }
 
+// ConvexError is an error thrown by a Convex function. Functions that throw
+// a ConvexError with a {code} object let callers tell failures apart.
+type ConvexError struct {
+	Message string
+	Data    any
+}
+
+func (e *ConvexError) Error() string {
+	return "convex error: " + e.Message
+}
+
+// Code returns the error's machine-readable code, or "" for a plain error
+func (e *ConvexError) Code() string {
+	data, _ := e.Data.(map[string]any)
+	code, _ := data["code"].(string)
+	return code
+}
+
 // User represents a user in the system
 type User struct {
 	ID          string `json:"_id"`
@@ -133,6 +291,9 @@ type Group struct {
 	InviteCode string `json:"inviteCode"`
 	CreatedBy  string `json:"createdBy"`
 	CreatedAt  int64  `json:"createdAt"`
+	MaxMembers int    `json:"maxMembers,omitempty"` // 0 means no limit
+
+	LevelNames []string `json:"levelNames,omitempty"` // The crew's own level names; empty means the defaults
 }
 
 // Quest represents a task/quest
@@ -141,24 +302,87 @@ type Quest struct {
 	UserID      string `json:"userId"`
 	GroupID     string `json:"groupId,omitempty"`
 	Title       string `json:"title"`
+	Notes       string `json:"notes,omitempty"`
 	XP          int    `json:"xp"`
 	AIReasoning string `json:"aiReasoning"`
 	Status      string `json:"status"`
 	CreatedAt   int64  `json:"createdAt"`
 	CompletedAt int64  `json:"completedAt,omitempty"`
+	AbandonedAt int64  `json:"abandonedAt,omitempty"`
+	// SnoozedUntil hides the quest from today's list until this time (ms)
+	SnoozedUntil int64 `json:"snoozedUntil,omitempty"`
+	// Order is the user's manual position; listToday sorts by it
+	Order int `json:"order,omitempty"`
+	// IdempotencyKey is the client key it was created with, so a retried
+	// quests:create returns this quest instead of adding another
+	IdempotencyKey string `json:"idempotencyKey,omitempty"`
+	// Subtasks are the optional steps of a bigger quest
+	Subtasks []SubTask `json:"subtasks,omitempty"`
+	// SubtaskXP is the base XP finished sub-tasks have already paid out
+	SubtaskXP int `json:"subtaskXp,omitempty"`
+	// SubtaskPayouts is what each of those payouts credited and when, so
+	// the XP counts toward the day it was paid rather than the completion's
+	SubtaskPayouts []Payout `json:"subtaskPayouts,omitempty"`
+}
+
+// SubTask is one step of a quest
+type SubTask struct {
+	Title string `json:"title"`
+	Done  bool   `json:"done"`
+}
+
+// Payout is XP credited at a point in time (Unix ms)
+type Payout struct {
+	At int64 `json:"at"`
+	XP int   `json:"xp"`
+}
+
+// IsOpen returns true if the quest can still be started, completed, or
+// dropped: not completed and not abandoned
+func (q Quest) IsOpen() bool {
+	return q.Status != "completed" && q.Status != "abandoned"
+}
+
+// IsSnoozed returns true if the quest is deferred to a later day
+func (q Quest) IsSnoozed() bool {
+	return q.SnoozedUntil > time.Now().UnixMilli()
+}
+
+// QuestEvaluation is returned by ai:evaluateQuest
+type QuestEvaluation struct {
+	XP        int    `json:"xp"`
+	Reasoning string `json:"reasoning"`
+}
+
+// CompleteResult is returned by quests:complete
+type CompleteResult struct {
+	XPEarned    int     `json:"xpEarned"`
+	Capped      bool    `json:"capped"`     // The crew's daily cap cut XPEarned below the boosted XP
+	Multiplier  float64 `json:"multiplier"` // XP event multiplier applied, 1 with no event
+	NewTotalXP  int     `json:"newTotalXp"`
+	NewWeeklyXP int     `json:"newWeeklyXp"`
+	LeveledUp   bool    `json:"leveledUp"`
+	NewLevel    int     `json:"newLevel"`
 }
 
 // Activity represents an activity feed item
 type Activity struct {
-	ID         string `json:"_id"`
-	GroupID    string `json:"groupId"`
-	UserID     string `json:"userId"`
-	UserName   string `json:"userName,omitempty"`
-	Type       string `json:"type"`
-	QuestTitle string `json:"questTitle,omitempty"`
-	XP         int    `json:"xp,omitempty"`
-	NewLevel   int    `json:"newLevel,omitempty"`
-	CreatedAt  int64  `json:"createdAt"`
+	ID         string     `json:"_id"`
+	GroupID    string     `json:"groupId"`
+	UserID     string     `json:"userId"`
+	UserName   string     `json:"userName,omitempty"`
+	Type       string     `json:"type"`
+	QuestTitle string     `json:"questTitle,omitempty"`
+	XP         int        `json:"xp,omitempty"`
+	NewLevel   int        `json:"newLevel,omitempty"`
+	Reactions  []Reaction `json:"reactions,omitempty"`
+	CreatedAt  int64      `json:"createdAt"`
+}
+
+// Reaction is one crew member's emoji on a quest completion
+type Reaction struct {
+	UserID string `json:"userId"`
+	Emoji  string `json:"emoji"` // One of ReactionEmoji
 }
 
 // LeaderboardEntry represents a user's position on the leaderboard
@@ -169,6 +393,10 @@ type LeaderboardEntry struct {
 	Level    int    `json:"level"`
 	WeeklyXP int    `json:"weeklyXp"`
 	TotalXP  int    `json:"totalXp"`
+	// LastActiveAt is when the member last added or finished a quest (Unix ms)
+	LastActiveAt int64 `json:"lastActiveAt"`
+	// RankDelta is positions moved since the last refresh (positive = climbed)
+	RankDelta int `json:"-"`
 }
 
 // DashboardStats contains aggregated stats for the dashboard header
@@ -178,7 +406,37 @@ type DashboardStats struct {
 	Group              *GroupStats `json:"group"`
 	Quote              string      `json:"quote"`
 	CompetitiveInsight string      `json:"competitiveInsight"`
-	InsightType        string      `json:"insightType"` // "rivalry", "analyst", or "stoic"
+	InsightType        string      `json:"insightType"`       // "rivalry", "analyst", or "stoic"
+	RecentCompletions  []int64     `json:"recentCompletions"` // Completion times (Unix ms) for streaks
+	Event              *XPEvent    `json:"event"`             // XP event running when fetched, nil if none
+}
+
+// ActiveEvent returns the XP event still running at now, or nil
+func (s *DashboardStats) ActiveEvent(now time.Time) *XPEvent {
+	if s == nil || s.Event == nil || now.UnixMilli() >= s.Event.EndsAt {
+		return nil
+	}
+	return s.Event
+}
+
+// XPEvent is a time-bounded XP multiplier, like a crew happy hour
+type XPEvent struct {
+	Name       string  `json:"name"`
+	Multiplier float64 `json:"multiplier"`
+	EndsAt     int64   `json:"endsAt"` // Unix ms
+}
+
+// Apply returns xp boosted by the event; a nil event leaves it unchanged
+func (e *XPEvent) Apply(xp int) int {
+	if e == nil {
+		return xp
+	}
+	return Boost(xp, e.Multiplier)
+}
+
+// Boost multiplies xp, rounded like the backend does
+func Boost(xp int, multiplier float64) int {
+	return int(math.Round(float64(xp) * multiplier))
 }
 
 // TodayStats contains today's activity stats
@@ -186,6 +444,17 @@ type TodayStats struct {
 	XP              int `json:"xp"`
 	QuestsCompleted int `json:"questsCompleted"`
 	QuestsTotal     int `json:"questsTotal"`
+	XPCap           int `json:"xpCap"`   // Crew's daily XP cap, 0 if none
+	CapUsed         int `json:"capUsed"` // XP earned today toward the cap
+}
+
+// CapRemaining returns how much XP can still be earned today under the
+// crew's daily cap, or -1 when there is no cap
+func (t TodayStats) CapRemaining() int {
+	if t.XPCap <= 0 {
+		return -1
+	}
+	return max(t.XPCap-t.CapUsed, 0)
 }
 
 // WeekStats contains this week's stats
@@ -202,5 +471,50 @@ type GroupStats struct {
 	LeaderName    string `json:"leaderName"`
 	LeaderXP      int    `json:"leaderXP"`
 	IsUserLeading bool   `json:"isUserLeading"`
-	GroupTodayXP  int    `json:"groupTodayXP"`
+	GroupTodayXP  int    `json:"groupTodayXP"`  // Combined XP from the crew's completions today
+	GroupWeeklyXP int    `json:"groupWeeklyXP"` // Sum of the members' weekly XP
+	MaxMembers    int    `json:"maxMembers"` // 0 means no limit
+}
+
+// RivalStats contains one side of a head-to-head comparison
+type RivalStats struct {
+	UserID       string `json:"userId"`
+	UserName     string `json:"userName"`
+	Level        int    `json:"level"`
+	Rank         int    `json:"rank"`
+	WeeklyXP     int    `json:"weeklyXp"`
+	WeeklyQuests int    `json:"weeklyQuests"`
+}
+
+// CatchUp summarizes what a crew did while a member was away, from
+// dashboard:getCatchUp
+type CatchUp struct {
+	Since           int64            `json:"since"` // Unix ms
+	QuestsCompleted int              `json:"questsCompleted"`
+	XP              int              `json:"xp"`
+	Top             []CatchUpMember  `json:"top"` // Most XP earned since, best first
+	LevelUps        []CatchUpLevelUp `json:"levelUps"`
+	Rank            int              `json:"rank"` // Your weekly rank now
+	// PrevRank is the last rank this machine saw, 0 if unknown
+	PrevRank int `json:"-"`
+}
+
+// CatchUpMember is a crew member's haul while you were away
+type CatchUpMember struct {
+	UserName string `json:"userName"`
+	XP       int    `json:"xp"`
+	Quests   int    `json:"quests"`
+}
+
+// CatchUpLevelUp is a level someone reached while you were away
+type CatchUpLevelUp struct {
+	UserName string `json:"userName"`
+	NewLevel int    `json:"newLevel"`
+}
+
+// RivalComparison is a head-to-head comparison against a crew member
+type RivalComparison struct {
+	You   RivalStats `json:"you"`
+	Rival RivalStats `json:"rival"`
+	Gap   int        `json:"gap"` // Rival's weekly XP minus yours
 }
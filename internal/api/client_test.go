@@ -0,0 +1,90 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+)
+
+func TestRetryable(t *testing.T) {
+	dial := &url.Error{Op: "Post", URL: "https://x.convex.cloud/api/mutation", Err: &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}}
+	reset := &url.Error{Op: "Post", URL: "https://x.convex.cloud/api/query", Err: &net.OpError{Op: "read", Net: "tcp", Err: errors.New("connection reset by peer")}}
+
+	tests := []struct {
+		name     string
+		endpoint string
+		err      error
+		want     bool
+	}{
+		{"query dial failure", "/api/query", dial, true},
+		{"mutation dial failure", "/api/mutation", dial, true},
+		{"action dial failure", "/api/action", dial, true},
+		{"query reset mid-request", "/api/query", reset, true},
+		{"mutation reset mid-request", "/api/mutation", reset, false},
+		{"query 502", "/api/query", &httpError{status: 502}, true},
+		{"query 503 wrapped", "/api/query", fmt.Errorf("call: %w", &httpError{status: 503}), true},
+		{"query 429", "/api/query", &httpError{status: 429}, true},
+		{"query 404", "/api/query", &httpError{status: 404}, false},
+		{"query 400", "/api/query", &httpError{status: 400}, false},
+		{"mutation 502", "/api/mutation", &httpError{status: 502}, false},
+		{"convex error", "/api/query", &ConvexError{Message: "not found"}, false},
+		{"canceled", "/api/query", context.Canceled, false},
+		{"deadline", "/api/query", &url.Error{Op: "Post", Err: context.DeadlineExceeded}, false},
+		{"other", "/api/query", errors.New("decode failed"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := retryable(tt.endpoint, tt.err); got != tt.want {
+				t.Errorf("retryable(%q, %v) = %v, want %v", tt.endpoint, tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCallRetries(t *testing.T) {
+	tests := []struct {
+		name     string
+		endpoint string
+		failures int // 502s before a success
+		wantOK   bool
+		want     int32 // Requests the server sees
+	}{
+		{"query recovers", "query", 1, true, 2},
+		{"query gives up", "query", MaxAttempts, false, MaxAttempts},
+		{"mutation not retried", "mutation", 1, false, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if atomic.AddInt32(&requests, 1) <= int32(tt.failures) {
+					http.Error(w, "bad gateway", http.StatusBadGateway)
+					return
+				}
+				_, _ = w.Write([]byte(`{"status": "success", "value": "ok"}`))
+			}))
+			defer srv.Close()
+
+			c := NewClient(srv.URL)
+			var retries []int
+			c.SetRetryHook(func(attempt, max int) { retries = append(retries, attempt) })
+
+			value, err := c.call(context.Background(), "/api/"+tt.endpoint, "test:fn", nil)
+			if ok := err == nil && value == "ok"; ok != tt.wantOK {
+				t.Errorf("call = %v, %v; want success %v", value, err, tt.wantOK)
+			}
+			if got := atomic.LoadInt32(&requests); got != tt.want {
+				t.Errorf("server saw %d requests, want %d", got, tt.want)
+			}
+			if len(retries) != int(tt.want)-1 {
+				t.Errorf("retry hook ran for attempts %v", retries)
+			}
+		})
+	}
+}
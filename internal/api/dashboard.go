@@ -0,0 +1,78 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"grind/internal/logging"
+)
+
+// GetStats fetches the user's dashboard stats via dashboard:getStats.
+// Returns nil without error if the user doesn't exist.
+func (c *Client) GetStats(ctx context.Context, userID string) (*DashboardStats, error) {
+	result, err := c.Query(ctx, "dashboard:getStats", map[string]any{
+		"userId": userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return nil, nil
+	}
+	return DecodeStats(result)
+}
+
+// GetCatchUp fetches what the user's crew did since (Unix ms) via
+// dashboard:getCatchUp. Returns nil without error for a user with no crew.
+func (c *Client) GetCatchUp(ctx context.Context, userID string, since int64) (*CatchUp, error) {
+	result, err := c.Query(ctx, "dashboard:getCatchUp", map[string]any{
+		"userId": userID,
+		"since":  since,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return nil, nil
+	}
+
+	var catchUp CatchUp
+	if err := DecodeInto(result, &catchUp); err != nil {
+		return nil, fmt.Errorf("decode catch-up: %w", err)
+	}
+	return &catchUp, nil
+}
+
+// requiredGroupFields are the group stats the crew column can't be shown
+// without
+var requiredGroupFields = []string{"memberCount", "activeToday", "userRank", "leaderName", "leaderXP", "isUserLeading"}
+
+// DecodeStats decodes a dashboard stats result, tolerating a partial one
+// as a deploy or a groupless user can produce: missing fields and fields of
+// the wrong type keep their zero values, and the group block is dropped
+// unless every required field is there, so the header shows no crew rather
+// than a made-up one
+func DecodeStats(result any) (*DashboardStats, error) {
+	var stats DashboardStats
+	if err := DecodeInto(result, &stats); err != nil {
+		// A mismatch with no field is the whole result, e.g. an array
+		var typeErr *json.UnmarshalTypeError
+		if !errors.As(err, &typeErr) || typeErr.Field == "" {
+			return nil, fmt.Errorf("decode stats: %w", err)
+		}
+		// The rest of the stats decoded fine; only this field is skipped
+		logging.Error("stats field has the wrong type", "field", typeErr.Field, "err", err)
+	}
+
+	raw, _ := result.(map[string]any)
+	group, _ := raw["group"].(map[string]any)
+	for _, field := range requiredGroupFields {
+		if _, ok := group[field]; !ok {
+			stats.Group = nil
+			break
+		}
+	}
+	return &stats, nil
+}
@@ -0,0 +1,104 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// decodeJSON turns a JSON literal into the generic shape Query returns
+func decodeJSON(t *testing.T, s string) any {
+	t.Helper()
+	var v any
+	if err := json.Unmarshal([]byte(s), &v); err != nil {
+		t.Fatalf("bad fixture %s: %v", s, err)
+	}
+	return v
+}
+
+const fullGroup = `{"memberCount": 5, "activeToday": 3, "userRank": 2, "leaderName": "ada",
+	"leaderXP": 420, "isUserLeading": false, "groupTodayXP": 150, "groupWeeklyXP": 2100}`
+
+func TestDecodeStats(t *testing.T) {
+	tests := []struct {
+		name      string
+		payload   string
+		wantGroup bool
+		check     func(t *testing.T, s *DashboardStats)
+	}{
+		{
+			name:    "empty",
+			payload: `{}`,
+		},
+		{
+			name:      "full group",
+			payload:   `{"today": {"xp": 40}, "group": ` + fullGroup + `}`,
+			wantGroup: true,
+			check: func(t *testing.T, s *DashboardStats) {
+				if s.Group.MemberCount != 5 || s.Group.LeaderName != "ada" || s.Group.GroupTodayXP != 150 || s.Group.GroupWeeklyXP != 2100 {
+					t.Errorf("group = %+v", *s.Group)
+				}
+			},
+		},
+		{
+			name:    "missing group block",
+			payload: `{"today": {"xp": 40, "questsCompleted": 2}, "week": {"xp": 300, "rank": 1}}`,
+			check: func(t *testing.T, s *DashboardStats) {
+				if s.Today.XP != 40 || s.Week.Rank != 1 {
+					t.Errorf("today = %+v, week = %+v", s.Today, s.Week)
+				}
+			},
+		},
+		{
+			name:    "null group",
+			payload: `{"group": null}`,
+		},
+		{
+			name:    "group missing a required field",
+			payload: `{"group": {"memberCount": 5, "activeToday": 3, "userRank": 2, "leaderXP": 420, "isUserLeading": false}}`,
+		},
+		{
+			name:      "group missing an optional field",
+			payload:   `{"group": {"memberCount": 5, "activeToday": 3, "userRank": 2, "leaderName": "ada", "leaderXP": 420, "isUserLeading": false}}`,
+			wantGroup: true,
+		},
+		{
+			name:    "wrong-typed field",
+			payload: `{"today": {"xp": "lots", "questsCompleted": 2}, "quote": "keep going"}`,
+			check: func(t *testing.T, s *DashboardStats) {
+				if s.Today.XP != 0 || s.Today.QuestsCompleted != 2 || s.Quote != "keep going" {
+					t.Errorf("today = %+v, quote = %q", s.Today, s.Quote)
+				}
+			},
+		},
+		{
+			name:      "wrong-typed group field",
+			payload:   `{"group": {"memberCount": "five", "activeToday": 3, "userRank": 2, "leaderName": "ada", "leaderXP": 420, "isUserLeading": false}}`,
+			wantGroup: true,
+			check: func(t *testing.T, s *DashboardStats) {
+				if s.Group.MemberCount != 0 || s.Group.ActiveToday != 3 {
+					t.Errorf("group = %+v", *s.Group)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stats, err := DecodeStats(decodeJSON(t, tt.payload))
+			if err != nil {
+				t.Fatalf("DecodeStats: %v", err)
+			}
+			if got := stats.Group != nil; got != tt.wantGroup {
+				t.Fatalf("group present = %v, want %v", got, tt.wantGroup)
+			}
+			if tt.check != nil {
+				tt.check(t, stats)
+			}
+		})
+	}
+}
+
+func TestDecodeStatsNotAnObject(t *testing.T) {
+	if _, err := DecodeStats(decodeJSON(t, `[1, 2]`)); err == nil {
+		t.Error("DecodeStats of an array succeeded")
+	}
+}
@@ -0,0 +1,57 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// DecodeInto converts a raw Convex result (the maps, slices and float64s
+// Query, Mutation and Action return) into out via the JSON tags on the
+// target type. Fields missing from the result keep their zero values, so
+// optional fields need no special handling. A nil result leaves out as is.
+func DecodeInto[T any](v any, out *T) error {
+	if v == nil {
+		return nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
+
+// QueryAs runs a Convex query and decodes its result into T. A null result
+// returns T's zero value without error.
+//
+//	quests, err := api.QueryAs[[]api.Quest](ctx, client, "quests:listToday", args)
+func QueryAs[T any](ctx context.Context, c ConvexAPI, path string, args map[string]any) (T, error) {
+	result, err := c.Query(ctx, path, args)
+	return decodeAs[T](path, result, err)
+}
+
+// MutationAs runs a Convex mutation and decodes its result into T, like
+// QueryAs
+func MutationAs[T any](ctx context.Context, c ConvexAPI, path string, args map[string]any) (T, error) {
+	result, err := c.Mutation(ctx, path, args)
+	return decodeAs[T](path, result, err)
+}
+
+// ActionAs runs a Convex action and decodes its result into T, like QueryAs
+func ActionAs[T any](ctx context.Context, c ConvexAPI, path string, args map[string]any) (T, error) {
+	result, err := c.Action(ctx, path, args)
+	return decodeAs[T](path, result, err)
+}
+
+// decodeAs finishes a typed call: it passes through the call's error and
+// otherwise decodes result, naming path in decode errors
+func decodeAs[T any](path string, result any, err error) (T, error) {
+	var out T
+	if err != nil {
+		return out, err
+	}
+	if err := DecodeInto(result, &out); err != nil {
+		return out, fmt.Errorf("decode %s: %w", path, err)
+	}
+	return out, nil
+}
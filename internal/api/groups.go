@@ -0,0 +1,171 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// MaxDailyXPCap is the highest daily XP cap a crew can set
+const MaxDailyXPCap = 10000
+
+// ErrGroupFull is returned when joining a crew that's at its member limit
+var ErrGroupFull = errors.New("crew is full")
+
+// ErrGroupNotFound is returned when the user's crew has been deleted but
+// their config or profile still points at it
+var ErrGroupNotFound = errors.New("your crew no longer exists")
+
+// ErrInvalidInviteCode is returned for codes that can't be a real invite
+// code, so callers can reject them before any network call
+var ErrInvalidInviteCode = errors.New("invite codes look like ABC-123")
+
+// InviteCodeChars is the invite-code alphabet used by convex/groups.ts. It
+// leaves out I, O, 0 and 1 so codes survive being read aloud.
+const InviteCodeChars = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// ValidateInviteCode normalizes code to the ABC-123 form the backend stores,
+// accepting any case, surrounding spaces and a missing or misplaced dash.
+// It returns ErrInvalidInviteCode unless the result is six letters or
+// digits; whether the code exists is left to the backend.
+func ValidateInviteCode(code string) (string, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	code = strings.ReplaceAll(code, "-", "")
+	if len(code) != 6 {
+		return "", ErrInvalidInviteCode
+	}
+	for _, r := range code {
+		if (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
+			return "", ErrInvalidInviteCode
+		}
+	}
+	return code[:3] + "-" + code[3:], nil
+}
+
+// SetDailyCap sets the crew's daily XP cap via groups:setDailyCap, or
+// removes it when xpCap is 0. Only the crew's creator may change it.
+func (c *Client) SetDailyCap(ctx context.Context, groupID, userID string, xpCap int) error {
+	args := map[string]any{
+		"groupId": groupID,
+		"userId":  userID,
+	}
+	if xpCap > 0 {
+		args["cap"] = xpCap
+	}
+	_, err := c.Mutation(ctx, "groups:setDailyCap", args)
+	return err
+}
+
+// MaxLevelNameLength matches the limit groups:setLevelNames enforces
+const MaxLevelNameLength = 24
+
+// SetLevelNames renames the crew's levels via groups:setLevelNames, or
+// restores the defaults when names is empty. Only the crew's creator may
+// change them.
+func (c *Client) SetLevelNames(ctx context.Context, groupID, userID string, names []string) error {
+	args := map[string]any{
+		"groupId": groupID,
+		"userId":  userID,
+	}
+	if len(names) > 0 {
+		args["names"] = names
+	}
+	_, err := c.Mutation(ctx, "groups:setLevelNames", args)
+	return err
+}
+
+// GetGroup fetches a crew via groups:get. Returns nil without error if the
+// crew doesn't exist.
+func (c *Client) GetGroup(ctx context.Context, groupID string) (*Group, error) {
+	result, err := c.Query(ctx, "groups:get", map[string]any{
+		"groupId": groupID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return nil, nil
+	}
+
+	var group Group
+	if err := DecodeInto(result, &group); err != nil {
+		return nil, fmt.Errorf("decode group: %w", err)
+	}
+	return &group, nil
+}
+
+// GetMembers lists the crew's members via groups:getMembers
+func (c *Client) GetMembers(ctx context.Context, groupID string) ([]User, error) {
+	return QueryAs[[]User](ctx, c, "groups:getMembers", map[string]any{
+		"groupId": groupID,
+	})
+}
+
+// JoinGroup adds the user to the crew with the invite code via groups:join
+// and returns the crew's ID and name. A crew at its member limit returns
+// ErrGroupFull.
+func (c *Client) JoinGroup(ctx context.Context, userID, inviteCode string) (groupID, groupName string, err error) {
+	return c.enterGroup(ctx, "groups:join", userID, inviteCode)
+}
+
+// SwitchGroup moves the user straight from their crew to the one with the
+// invite code via groups:switchGroup. It's one transaction: if the join
+// fails, ErrGroupFull included, the user is still in their old crew.
+func (c *Client) SwitchGroup(ctx context.Context, userID, inviteCode string) (groupID, groupName string, err error) {
+	return c.enterGroup(ctx, "groups:switchGroup", userID, inviteCode)
+}
+
+// LeaveGroup takes the user out of their crew via groups:leave. It also
+// works when the crew itself is gone, to clear a dangling reference.
+func (c *Client) LeaveGroup(ctx context.Context, userID string) error {
+	_, err := c.Mutation(ctx, "groups:leave", map[string]any{
+		"userId": userID,
+	})
+	return err
+}
+
+// GetGroupByInviteCode looks up a crew via groups:getByInviteCode. Returns
+// nil without error if no crew has the code.
+func (c *Client) GetGroupByInviteCode(ctx context.Context, inviteCode string) (*Group, error) {
+	result, err := c.Query(ctx, "groups:getByInviteCode", map[string]any{
+		"inviteCode": inviteCode,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return nil, nil
+	}
+
+	var group Group
+	if err := DecodeInto(result, &group); err != nil {
+		return nil, fmt.Errorf("decode group: %w", err)
+	}
+	return &group, nil
+}
+
+// enterGroup runs a join-style mutation and decodes the crew it put the
+// user in
+func (c *Client) enterGroup(ctx context.Context, path, userID, inviteCode string) (groupID, groupName string, err error) {
+	result, err := c.Mutation(ctx, path, map[string]any{
+		"userId":     userID,
+		"inviteCode": inviteCode,
+	})
+	var convexErr *ConvexError
+	if errors.As(err, &convexErr) && convexErr.Code() == "GROUP_FULL" {
+		return "", "", ErrGroupFull
+	}
+	if err != nil {
+		return "", "", err
+	}
+
+	var joined struct {
+		GroupID   string `json:"groupId"`
+		GroupName string `json:"groupName"`
+	}
+	if err := DecodeInto(result, &joined); err != nil {
+		return "", "", fmt.Errorf("decode join: %w", err)
+	}
+	return joined.GroupID, joined.GroupName, nil
+}
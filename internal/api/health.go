@@ -0,0 +1,108 @@
+package api
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net"
+	"time"
+)
+
+// HealthKind names the layer a health check failed at
+type HealthKind string
+
+const (
+	HealthDNS     HealthKind = "dns"     // The deployment's host didn't resolve
+	HealthConnect HealthKind = "connect" // No connection: refused, unreachable, reset
+	HealthTLS     HealthKind = "tls"     // The connection opened but TLS failed
+	HealthTimeout HealthKind = "timeout" // No answer before the deadline
+	HealthHTTP    HealthKind = "http"    // A non-200 response, e.g. a wrong URL
+	HealthConvex  HealthKind = "convex"  // The backend answered with a function error
+)
+
+// HealthError is returned by HealthCheck, saying which layer failed so
+// callers can point at the likely fix
+type HealthError struct {
+	Kind HealthKind
+	Err  error
+}
+
+func (e *HealthError) Error() string {
+	return string(e.Kind) + ": " + e.Err.Error()
+}
+
+func (e *HealthError) Unwrap() error {
+	return e.Err
+}
+
+// Hint suggests what to check for this kind of failure
+func (e *HealthError) Hint() string {
+	switch e.Kind {
+	case HealthDNS:
+		return "check the host in convexUrl and your DNS"
+	case HealthConnect:
+		return "check your network, VPN, or proxy"
+	case HealthTLS:
+		return "check your system clock and any proxy that intercepts HTTPS"
+	case HealthTimeout:
+		return "the backend is slow or unreachable; check your network"
+	case HealthHTTP:
+		return "check that convexUrl points at a Convex deployment"
+	case HealthConvex:
+		return "the backend is up but rejected the check; it may be running an older version"
+	}
+	return ""
+}
+
+// HealthCheck makes one cheap read-only call to the deployment and returns
+// how long the round trip took. Unlike other calls it isn't retried, so the
+// latency is a single request's. A failure is a *HealthError.
+func (c *Client) HealthCheck(ctx context.Context) (time.Duration, error) {
+	args := map[string]any{"inviteCode": ""}
+
+	start := time.Now()
+	var err error
+	if c.backend != nil {
+		_, err = c.backend.Query(ctx, "groups:getByInviteCode", args)
+	} else {
+		_, err = c.logged(ctx, "/api/query", "groups:getByInviteCode", args)
+	}
+	latency := time.Since(start)
+
+	if err != nil {
+		return latency, &HealthError{Kind: healthKind(err), Err: err}
+	}
+	return latency, nil
+}
+
+// healthKind works out which layer err came from
+func healthKind(err error) HealthKind {
+	var (
+		convexErr  *ConvexError
+		statusErr  *httpError
+		dnsErr     *net.DNSError
+		certErr    *tls.CertificateVerificationError
+		authErr    x509.UnknownAuthorityError
+		hostErr    x509.HostnameError
+		invalidErr x509.CertificateInvalidError
+		recordErr  tls.RecordHeaderError
+		netErr     net.Error
+	)
+	switch {
+	case errors.As(err, &convexErr):
+		return HealthConvex
+	case errors.As(err, &statusErr):
+		return HealthHTTP
+	case errors.Is(err, context.DeadlineExceeded):
+		return HealthTimeout
+	case errors.As(err, &dnsErr):
+		return HealthDNS
+	case errors.As(err, &certErr), errors.As(err, &authErr), errors.As(err, &hostErr),
+		errors.As(err, &invalidErr), errors.As(err, &recordErr):
+		return HealthTLS
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return HealthTimeout
+	}
+	return HealthConnect
+}
@@ -0,0 +1,100 @@
+package api
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+// timeoutErr is a net.Error that timed out
+type timeoutErr struct{}
+
+func (timeoutErr) Error() string   { return "i/o timeout" }
+func (timeoutErr) Timeout() bool   { return true }
+func (timeoutErr) Temporary() bool { return true }
+
+func TestHealthKind(t *testing.T) {
+	wrap := func(err error) error {
+		return &url.Error{Op: "Post", URL: "https://x.convex.cloud/api/query", Err: err}
+	}
+	tests := []struct {
+		name string
+		err  error
+		want HealthKind
+	}{
+		{"dns", wrap(&net.OpError{Op: "dial", Err: &net.DNSError{Err: "no such host", Name: "x.convex.cloud"}}), HealthDNS},
+		{"refused", wrap(&net.OpError{Op: "dial", Err: errors.New("connection refused")}), HealthConnect},
+		{"reset", wrap(&net.OpError{Op: "read", Err: errors.New("connection reset by peer")}), HealthConnect},
+		{"unknown authority", wrap(x509.UnknownAuthorityError{}), HealthTLS},
+		{"wrong host", wrap(x509.HostnameError{Certificate: &x509.Certificate{}, Host: "x"}), HealthTLS},
+		{"expired", wrap(x509.CertificateInvalidError{Reason: x509.Expired}), HealthTLS},
+		{"verification", wrap(&tls.CertificateVerificationError{Err: errors.New("bad cert")}), HealthTLS},
+		{"not tls", wrap(tls.RecordHeaderError{Msg: "first record does not look like a TLS handshake"}), HealthTLS},
+		{"deadline", wrap(context.DeadlineExceeded), HealthTimeout},
+		{"net timeout", wrap(timeoutErr{}), HealthTimeout},
+		{"http 404", &httpError{status: 404}, HealthHTTP},
+		{"http 502", &httpError{status: 502}, HealthHTTP},
+		{"convex", &ConvexError{Message: "Could not find public function"}, HealthConvex},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := healthKind(tt.err); got != tt.want {
+				t.Errorf("healthKind(%v) = %q, want %q", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHealthCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		want    HealthKind // "" for healthy
+	}{
+		{"healthy", func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"status": "success", "value": null}`))
+		}, ""},
+		{"not a deployment", http.NotFound, HealthHTTP},
+		{"function error", func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"status": "error", "errorMessage": "Could not find public function"}`))
+		}, HealthConvex},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(tt.handler)
+			defer srv.Close()
+
+			_, err := NewClient(srv.URL).HealthCheck(context.Background())
+			var healthErr *HealthError
+			switch {
+			case tt.want == "" && err != nil:
+				t.Errorf("HealthCheck = %v, want healthy", err)
+			case tt.want != "" && !errors.As(err, &healthErr):
+				t.Errorf("HealthCheck = %v, want a %s HealthError", err, tt.want)
+			case tt.want != "" && healthErr.Kind != tt.want:
+				t.Errorf("HealthCheck kind = %q, want %q", healthErr.Kind, tt.want)
+			}
+		})
+	}
+}
+
+func TestHealthCheckUnreachable(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	addr := srv.URL
+	srv.Close()
+
+	_, err := NewClient(addr).HealthCheck(context.Background())
+	var healthErr *HealthError
+	if !errors.As(err, &healthErr) || healthErr.Kind != HealthConnect {
+		t.Errorf("HealthCheck = %v, want a connect HealthError", err)
+	}
+	if healthErr != nil && healthErr.Hint() == "" {
+		t.Error("connect failure has no hint")
+	}
+}
@@ -0,0 +1,54 @@
+package api
+
+import (
+	"context"
+	"fmt"
+)
+
+// GetLeaderboard fetches the group's rankings via leaderboard:weekly, or
+// leaderboard:allTime when allTime is set
+func (c *Client) GetLeaderboard(ctx context.Context, groupID string, allTime bool) ([]LeaderboardEntry, error) {
+	path := "leaderboard:weekly"
+	if allTime {
+		path = "leaderboard:allTime"
+	}
+	result, err := c.Query(ctx, path, map[string]any{
+		"groupId": groupID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []LeaderboardEntry
+	if result == nil {
+		return entries, nil
+	}
+	if err := DecodeInto(result, &entries); err != nil {
+		return nil, fmt.Errorf("decode leaderboard: %w", err)
+	}
+	return entries, nil
+}
+
+// CompareRival fetches a head-to-head comparison via leaderboard:compare.
+// An empty rivalName auto-selects the crew member just ahead (or behind).
+// Returns nil without error when there's no one to compare against.
+func (c *Client) CompareRival(ctx context.Context, userID, rivalName string) (*RivalComparison, error) {
+	args := map[string]any{"userId": userID}
+	if rivalName != "" {
+		args["rivalName"] = rivalName
+	}
+
+	result, err := c.Query(ctx, "leaderboard:compare", args)
+	if err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return nil, nil
+	}
+
+	var cmp RivalComparison
+	if err := DecodeInto(result, &cmp); err != nil {
+		return nil, fmt.Errorf("decode comparison: %w", err)
+	}
+	return &cmp, nil
+}
@@ -0,0 +1,292 @@
+package api
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// NewIdempotencyKey returns a fresh key for one logical quests:create.
+// Sending the same key again, on a retry or a replay, gets back the quest
+// the first call made instead of a duplicate.
+func NewIdempotencyKey() string {
+	b := make([]byte, 12)
+	if _, err := rand.Read(b); err != nil {
+		// Should never happen with crypto/rand; a clock-based key is still
+		// unique enough to tell two adds apart
+		return fmt.Sprintf("create_%x", time.Now().UnixNano())
+	}
+	return "create_" + hex.EncodeToString(b)
+}
+
+// ListTodayQuests fetches the user's quests for today via quests:listToday
+func (c *Client) ListTodayQuests(ctx context.Context, userID string) ([]Quest, error) {
+	result, err := c.Query(ctx, "quests:listToday", map[string]any{
+		"userId": userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var quests []Quest
+	if result == nil {
+		return quests, nil
+	}
+	if err := DecodeInto(result, &quests); err != nil {
+		return nil, fmt.Errorf("decode quests: %w", err)
+	}
+	return quests, nil
+}
+
+// ListOptions narrows quests:list. The zero value lists everything.
+type ListOptions struct {
+	Since  int64  // Only quests created at or after this Unix ms time, 0 for all
+	Limit  int    // At most this many quests, 0 for no limit
+	Status string // Only quests in this status, "" for any
+}
+
+// ListQuests fetches the user's quests, newest first, via quests:list
+func (c *Client) ListQuests(ctx context.Context, userID string, opts ListOptions) ([]Quest, error) {
+	args := map[string]any{
+		"userId": userID,
+	}
+	if opts.Since > 0 {
+		args["since"] = opts.Since
+	}
+	if opts.Limit > 0 {
+		args["limit"] = opts.Limit
+	}
+	if opts.Status != "" {
+		args["status"] = opts.Status
+	}
+	result, err := c.Query(ctx, "quests:list", args)
+	if err != nil {
+		return nil, err
+	}
+
+	var quests []Quest
+	if result == nil {
+		return quests, nil
+	}
+	if err := DecodeInto(result, &quests); err != nil {
+		return nil, fmt.Errorf("decode quests: %w", err)
+	}
+	return quests, nil
+}
+
+// GetQuest fetches one quest by ID via quests:get, so callers can check its
+// current status before acting on it. Returns nil without error if the
+// quest no longer exists.
+func (c *Client) GetQuest(ctx context.Context, questID string) (*Quest, error) {
+	return QueryAs[*Quest](ctx, c, "quests:get", map[string]any{
+		"questId": questID,
+	})
+}
+
+// ReorderQuests saves a new display order via quests:reorder. questIDs is
+// the full list of today's quests, top first.
+func (c *Client) ReorderQuests(ctx context.Context, userID string, questIDs []string) error {
+	_, err := c.Mutation(ctx, "quests:reorder", map[string]any{
+		"userId":   userID,
+		"questIds": questIDs,
+	})
+	return err
+}
+
+// CompleteQuest completes a quest via quests:complete and returns the XP earned
+func (c *Client) CompleteQuest(ctx context.Context, questID string) (*CompleteResult, error) {
+	result, err := c.Mutation(ctx, "quests:complete", map[string]any{
+		"questId": questID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var out CompleteResult
+	if err := DecodeInto(result, &out); err != nil {
+		return nil, fmt.Errorf("decode completion: %w", err)
+	}
+	return &out, nil
+}
+
+// Quest XP bounds, matching the range the AI evaluator clamps to
+const (
+	MinQuestXP = 0
+	MaxQuestXP = 150
+)
+
+// ManualXPReasoning marks a quest whose XP was set by hand instead of the AI
+const ManualXPReasoning = "manually set"
+
+// ValidateQuestXP rejects XP values outside MinQuestXP-MaxQuestXP
+func ValidateQuestXP(xp int) error {
+	if xp < MinQuestXP {
+		return fmt.Errorf("XP can't be negative")
+	}
+	if xp > MaxQuestXP {
+		return fmt.Errorf("XP must be at most %d", MaxQuestXP)
+	}
+	return nil
+}
+
+// SetQuestXP overrides a quest's XP via quests:update, marking it manually set
+func (c *Client) SetQuestXP(ctx context.Context, questID string, xp int) error {
+	_, err := c.Mutation(ctx, "quests:update", map[string]any{
+		"questId": questID,
+		"xp":      xp,
+	})
+	return err
+}
+
+// QuestUpdate holds the quest fields to change; nil fields are left as is
+type QuestUpdate struct {
+	Title *string
+	Notes *string
+	XP    *int
+}
+
+// UpdateQuest edits a quest via quests:update. Setting XP marks it manually
+// set, like SetQuestXP.
+func (c *Client) UpdateQuest(ctx context.Context, questID string, update QuestUpdate) error {
+	args := map[string]any{"questId": questID}
+	if update.Title != nil {
+		args["title"] = *update.Title
+	}
+	if update.Notes != nil {
+		args["notes"] = *update.Notes
+	}
+	if update.XP != nil {
+		args["xp"] = *update.XP
+	}
+	_, err := c.Mutation(ctx, "quests:update", args)
+	return err
+}
+
+// SubtaskShare is the base XP each sub-task of a quest worth xp pays when
+// it's ticked off: together they're worth half the quest, and completing
+// the quest pays the rest. Matches subtaskShare in convex/quests.ts.
+func SubtaskShare(xp, count int) int {
+	if count <= 0 {
+		return 0
+	}
+	return xp / 2 / count
+}
+
+// SubtaskProgress returns how many of the quest's sub-tasks are done, and
+// how many it has
+func (q Quest) SubtaskProgress() (done, total int) {
+	for _, s := range q.Subtasks {
+		if s.Done {
+			done++
+		}
+	}
+	return done, len(q.Subtasks)
+}
+
+// NextSubtask returns the index of the first unfinished sub-task, or -1
+func (q Quest) NextSubtask() int {
+	for i, s := range q.Subtasks {
+		if !s.Done {
+			return i
+		}
+	}
+	return -1
+}
+
+// RemainingXP is the base XP completing the quest still pays, after what
+// its finished sub-tasks already paid out
+func (q Quest) RemainingXP() int {
+	return max(q.XP-q.SubtaskXP, 0)
+}
+
+// AddSubtask appends a step to an open quest via quests:addSubtask
+func (c *Client) AddSubtask(ctx context.Context, questID, title string) error {
+	_, err := c.Mutation(ctx, "quests:addSubtask", map[string]any{
+		"questId": questID,
+		"title":   title,
+	})
+	return err
+}
+
+// CompleteSubtask ticks off sub-task index of a quest via
+// quests:completeSubtask and returns the XP its share earned
+func (c *Client) CompleteSubtask(ctx context.Context, questID string, index int) (*CompleteResult, error) {
+	result, err := c.Mutation(ctx, "quests:completeSubtask", map[string]any{
+		"questId": questID,
+		"index":   index,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var out CompleteResult
+	if err := DecodeInto(result, &out); err != nil {
+		return nil, fmt.Errorf("decode completion: %w", err)
+	}
+	return &out, nil
+}
+
+// Unfinished returns the quests that are still pending or in progress
+func Unfinished(quests []Quest) []Quest {
+	var out []Quest
+	for _, q := range quests {
+		if q.IsOpen() {
+			out = append(out, q)
+		}
+	}
+	return out
+}
+
+// MatchQuests returns the indexes of quests whose title contains every
+// word of query, ignoring case and word order, so "login fix" finds
+// "Fix the login redirect"
+func MatchQuests(quests []Quest, query string) []int {
+	words := strings.Fields(strings.ToLower(query))
+	if len(words) == 0 {
+		return nil
+	}
+
+	var matches []int
+	for i, q := range quests {
+		title := strings.ToLower(q.Title)
+		all := true
+		for _, w := range words {
+			if !strings.Contains(title, w) {
+				all = false
+				break
+			}
+		}
+		if all {
+			matches = append(matches, i)
+		}
+	}
+	return matches
+}
+
+// StartQuest moves a pending quest to in progress via quests:start
+func (c *Client) StartQuest(ctx context.Context, questID string) error {
+	_, err := c.Mutation(ctx, "quests:start", map[string]any{
+		"questId": questID,
+	})
+	return err
+}
+
+// SnoozeQuest defers a quest to tomorrow via quests:snooze
+func (c *Client) SnoozeQuest(ctx context.Context, questID string) error {
+	_, err := c.Mutation(ctx, "quests:snooze", map[string]any{
+		"questId": questID,
+	})
+	return err
+}
+
+// AbandonQuest drops a quest via quests:abandon. It stays in history but
+// earns nothing.
+func (c *Client) AbandonQuest(ctx context.Context, questID string) error {
+	_, err := c.Mutation(ctx, "quests:abandon", map[string]any{
+		"questId": questID,
+	})
+	return err
+}
@@ -0,0 +1,84 @@
+package api_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"grind/internal/api"
+	"grind/internal/api/apitest"
+)
+
+// TestResponseErrors covers how the client reports each kind of failed
+// response. Mutations aren't retried, so each case is a single request.
+func TestResponseErrors(t *testing.T) {
+	srv := apitest.NewServer(nil)
+	defer srv.Close()
+	client := srv.Client()
+	ctx := context.Background()
+
+	srv.Status("users:update", http.StatusBadGateway, "upstream unavailable")
+	err := client.UpdateUserName(ctx, "u1", "ada")
+	if err == nil || err.Error() != "http error 502: upstream unavailable" {
+		t.Errorf("502: err = %v", err)
+	}
+
+	srv.Status("users:update", http.StatusOK, "<html>not json</html>")
+	err = client.UpdateUserName(ctx, "u1", "ada")
+	if err == nil || !strings.HasPrefix(err.Error(), "decode response: ") {
+		t.Errorf("malformed body: err = %v", err)
+	}
+
+	srv.Fake.Fail("quests:complete", "Quest already completed", map[string]any{"code": "already_completed"})
+	_, err = client.Mutation(ctx, "quests:complete", map[string]any{"questId": "q1"})
+	var convexErr *api.ConvexError
+	if !errors.As(err, &convexErr) {
+		t.Fatalf("thrown error: err = %v, want a ConvexError", err)
+	}
+	if convexErr.Message != "Quest already completed" || convexErr.Code() != "already_completed" {
+		t.Errorf("thrown error = %q, code %q", convexErr.Message, convexErr.Code())
+	}
+
+	_, err = client.Mutation(ctx, "quests:unknown", nil)
+	if !errors.As(err, &convexErr) || convexErr.Code() != "" {
+		t.Errorf("unknown function: err = %v, want a plain ConvexError", err)
+	}
+}
+
+func TestResponseDecoded(t *testing.T) {
+	srv := apitest.NewServer(nil)
+	defer srv.Close()
+	srv.Fake.Return("quests:listToday", []api.Quest{{ID: "q1", Title: "ship", XP: 20}})
+
+	quests, err := api.QueryAs[[]api.Quest](context.Background(), srv.Client(), "quests:listToday", map[string]any{"userId": "u1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(quests) != 1 || quests[0].ID != "q1" || quests[0].XP != 20 {
+		t.Errorf("quests = %+v", quests)
+	}
+
+	calls := srv.Fake.CallsTo("quests:listToday")
+	if len(calls) != 1 || calls[0].Kind != "query" || calls[0].Args["userId"] != "u1" {
+		t.Errorf("calls = %+v", calls)
+	}
+}
+
+func TestClientFor(t *testing.T) {
+	if api.ClientFor(nil) != nil {
+		t.Error("nil backend gave a client")
+	}
+	client := api.NewClient("https://example.convex.cloud")
+	if api.ClientFor(client) != client {
+		t.Error("a *Client was wrapped again")
+	}
+
+	fake := apitest.NewFake()
+	fake.Return("users:get", map[string]any{"_id": "u1", "name": "ada"})
+	user, err := api.ClientFor(fake).GetUser(context.Background(), "u1")
+	if err != nil || user == nil || user.Name != "ada" {
+		t.Errorf("GetUser through a fake = %+v, %v", user, err)
+	}
+}
@@ -0,0 +1,80 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"grind/internal/levels"
+	"grind/internal/logging"
+)
+
+// MaxUserNameLength is the longest display name users:create and
+// users:update accept, in characters
+const MaxUserNameLength = 32
+
+// ValidateUserName trims a display name and checks it fits the backend's
+// limits. Control characters would break the header and feed, so they're
+// rejected too.
+func ValidateUserName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", fmt.Errorf("name can't be empty")
+	}
+	if utf8.RuneCountInString(name) > MaxUserNameLength {
+		return "", fmt.Errorf("name must be at most %d characters", MaxUserNameLength)
+	}
+	if strings.IndexFunc(name, unicode.IsControl) >= 0 {
+		return "", fmt.Errorf("name can't contain control characters")
+	}
+	return name, nil
+}
+
+// GetUser fetches a user via users:get. Returns nil without error if the
+// user doesn't exist.
+func (c *Client) GetUser(ctx context.Context, userID string) (*User, error) {
+	result, err := c.Query(ctx, "users:get", map[string]any{
+		"userId": userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return nil, nil
+	}
+	return DecodeUser(result)
+}
+
+// DecodeUser decodes a users:get result, tolerating a brand-new or partial
+// user: XP fields that are missing, null or of the wrong type count as 0,
+// and the level is worked out from total XP rather than trusted, so it's
+// never 0 or out of step with the XP shown next to it
+func DecodeUser(result any) (*User, error) {
+	var user User
+	if err := DecodeInto(result, &user); err != nil {
+		// A mismatch with no field is the whole result, e.g. an array
+		var typeErr *json.UnmarshalTypeError
+		if !errors.As(err, &typeErr) || typeErr.Field == "" {
+			return nil, fmt.Errorf("decode user: %w", err)
+		}
+		// The rest of the user decoded fine; only this field is skipped
+		logging.Error("user field has the wrong type", "field", typeErr.Field, "err", err)
+	}
+	user.TotalXP = max(user.TotalXP, 0)
+	user.WeeklyXP = max(user.WeeklyXP, 0)
+	user.Level = levels.GetLevel(user.TotalXP).Number
+	return &user, nil
+}
+
+// UpdateUserName changes a user's display name via users:update
+func (c *Client) UpdateUserName(ctx context.Context, userID, name string) error {
+	_, err := c.Mutation(ctx, "users:update", map[string]any{
+		"userId": userID,
+		"name":   name,
+	})
+	return err
+}
@@ -0,0 +1,41 @@
+package api
+
+import "testing"
+
+func TestDecodeUser(t *testing.T) {
+	tests := []struct {
+		name      string
+		payload   string
+		wantName  string
+		wantTotal int
+		wantWeek  int
+		wantLevel int
+	}{
+		{"no XP fields", `{"_id": "u1", "name": "ada"}`, "ada", 0, 0, 1},
+		{"null XP", `{"name": "ada", "totalXp": null, "weeklyXp": null, "level": null}`, "ada", 0, 0, 1},
+		{"wrong-typed XP", `{"name": "ada", "totalXp": "lots", "weeklyXp": 40}`, "ada", 0, 40, 1},
+		{"negative XP", `{"name": "ada", "totalXp": -20, "weeklyXp": -5}`, "ada", 0, 0, 1},
+		{"level from XP", `{"name": "ada", "totalXp": 1000, "level": 1}`, "ada", 1000, 0, 5},
+		{"level zero ignored", `{"name": "ada", "totalXp": 150, "level": 0}`, "ada", 150, 0, 2},
+		{"empty", `{}`, "", 0, 0, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user, err := DecodeUser(decodeJSON(t, tt.payload))
+			if err != nil {
+				t.Fatalf("DecodeUser: %v", err)
+			}
+			if user.Name != tt.wantName || user.TotalXP != tt.wantTotal || user.WeeklyXP != tt.wantWeek || user.Level != tt.wantLevel {
+				t.Errorf("got name %q, total %d, weekly %d, level %d; want %q, %d, %d, %d",
+					user.Name, user.TotalXP, user.WeeklyXP, user.Level,
+					tt.wantName, tt.wantTotal, tt.wantWeek, tt.wantLevel)
+			}
+		})
+	}
+}
+
+func TestDecodeUserNotAnObject(t *testing.T) {
+	if _, err := DecodeUser(decodeJSON(t, `"ada"`)); err == nil {
+		t.Error("DecodeUser of a string succeeded")
+	}
+}
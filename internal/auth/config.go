@@ -3,8 +3,15 @@ package auth
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"grind/internal/streaks"
+	"grind/internal/xp"
 )
 
 // Config holds the user's local configuration
@@ -14,23 +21,146 @@ type Config struct {
 	GroupID     string `json:"groupId,omitempty"`
 	GroupName   string `json:"groupName,omitempty"`
 	ConvexURL   string `json:"convexUrl,omitempty"`
+
+	// Local marks a guest account that lives only on this machine: the
+	// dashboard never talks to the backend and keeps quests in DataPath
+	Local bool `json:"local,omitempty"`
+
+	// Preferences
+	PollInterval       string `json:"pollInterval,omitempty"` // e.g. "10s"
+	IdleAfter          string `json:"idleAfter,omitempty"`    // Pause polling after this long without input, e.g. "30m", or "off"
+	LeaderboardAllTime bool   `json:"leaderboardAllTime,omitempty"`
+	Glyphs             string `json:"glyphs,omitempty"`         // "auto", "unicode" or "ascii"
+	Layout             string `json:"layout,omitempty"`         // "cyber" (default), "classic" or "compact"
+	Lang               string `json:"lang,omitempty"`           // UI language code; $GRIND_LANG overrides it
+	Timezone           string `json:"timezone,omitempty"`       // IANA name, e.g. "Europe/Berlin"; system zone if unset
+	FullNumbers        bool   `json:"fullNumbers,omitempty"`    // Show 12,345 XP instead of 12.3k
+	WrapNavigation     bool   `json:"wrapNavigation,omitempty"` // Up/down wrap around at the ends of lists
+	ColorSafe          bool   `json:"colorSafe,omitempty"`      // Spell out states shown by color, for color-blind users
+	XPTiers            []int  `json:"xpTiers,omitempty"`        // [mid, high] XP where quest badges turn gold, then orange
+
+	// Weekly XP goal; GoalHitWeek is the week (goals.WeekKey) it was last
+	// celebrated so the celebration happens once per week
+	WeeklyGoal  int    `json:"weeklyGoal,omitempty"`
+	GoalHitWeek string `json:"goalHitWeek,omitempty"`
+
+	// Missed days forgiven per week before a streak breaks; nil means the
+	// default, so 0 can turn freezes off
+	StreakFreezes *int `json:"streakFreezes,omitempty"`
+
+	// Completing a quest worth more XP than this asks first; nil means
+	// DefaultConfirmDoneXP and 0 never asks
+	ConfirmDoneXP *int `json:"confirmDoneXp,omitempty"`
+
+	// Keyword lists and weights for the local XP estimate, used when the
+	// AI is unavailable; anything unset keeps the built-in default
+	XPKeywords *xp.Rules `json:"xpKeywords,omitempty"`
+
+	// Evaluator scores new quests: "ai" (default, the backend's), "llm"
+	// (an OpenAI-compatible endpoint at LLMURL running LLMModel) or
+	// "keywords" (the local estimate)
+	Evaluator string `json:"evaluator,omitempty"`
+	LLMURL    string `json:"llmUrl,omitempty"`
+	LLMModel  string `json:"llmModel,omitempty"`
+
+	// The crew's own level names from groups:get, cached so commands that
+	// don't fetch the crew still show them; empty means the defaults
+	LevelNames []string `json:"levelNames,omitempty"`
+
+	// Quest templates: name → quest titles added together by
+	// 'grind add --template' or T in the dashboard
+	Templates map[string][]string `json:"templates,omitempty"`
+
+	// Sent with users:create so a retried or interrupted signup gets back
+	// the same account instead of a duplicate; cleared when onboarding ends
+	SignupKey string `json:"signupKey,omitempty"`
+
+	// Unsubmitted quest input, restored on next launch after an interrupted session
+	DraftQuest string `json:"draftQuest,omitempty"`
+
+	// Last-known progress, used to seed the dashboard when the backend is
+	// unreachable and by 'grind status'. ProgressAt is when it was last
+	// refreshed, in Unix seconds.
+	TotalXP    int   `json:"totalXp,omitempty"`
+	Rank       int   `json:"rank,omitempty"`
+	ProgressAt int64 `json:"progressAt,omitempty"`
+
+	// When the dashboard was last opened, in Unix seconds; a launch a day
+	// or more later shows what the crew did in between
+	LastSeenAt int64 `json:"lastSeenAt,omitempty"`
+
+	// Timers set with 'grind start --timer': quest ID → when the dashboard
+	// asks about completing it, in Unix seconds
+	QuestTimers map[string]int64 `json:"questTimers,omitempty"`
 }
 
 // DefaultConvexURL is the default Convex deployment URL
 const DefaultConvexURL = "https://flippant-okapi-339.convex.cloud"
 
+// DefaultPollInterval is how often the dashboard refreshes activity and stats
+const DefaultPollInterval = 5 * time.Second
+
+// DefaultIdleAfter is how long the dashboard goes without input before it
+// stops polling until the next key
+const DefaultIdleAfter = 30 * time.Minute
+
+// MinIdleAfter is the shortest allowed idle time
+const MinIdleAfter = time.Minute
+
+// MinPollInterval is the fastest allowed refresh, to avoid hammering the backend
+const MinPollInterval = 2 * time.Second
+
+// DefaultConfirmDoneXP is the XP above which completing a quest asks for
+// confirmation, unless configured otherwise
+const DefaultConfirmDoneXP = 75
+
 // ErrNotLoggedIn indicates the user hasn't set up their profile
 var ErrNotLoggedIn = errors.New("not logged in - run 'grind' to set up")
 
 // ErrNoGroup indicates the user hasn't joined a group
 var ErrNoGroup = errors.New("not in a group - run 'grind join <code>' to join one")
 
-// configDir returns the config directory path
+// DefaultProfile is the profile used unless --profile or $GRIND_PROFILE
+// picks another. Its config lives directly in ~/.grind.
+const DefaultProfile = "default"
+
+// profile is the active profile, set once at startup by UseProfile
+var profile = DefaultProfile
+
+// UseProfile picks the active profile: name (the --profile flag) if set,
+// otherwise $GRIND_PROFILE, otherwise DefaultProfile. Other profiles keep
+// their config in ~/.grind/profiles/<name>, so one machine can hold
+// several accounts.
+func UseProfile(name string) error {
+	if name == "" {
+		name = os.Getenv("GRIND_PROFILE")
+	}
+	if name == "" {
+		name = DefaultProfile
+	}
+	for _, r := range name {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_') {
+			return fmt.Errorf("profile names may only use letters, digits, - and _")
+		}
+	}
+	profile = name
+	return nil
+}
+
+// Profile returns the active profile's name
+func Profile() string {
+	return profile
+}
+
+// configDir returns the config directory path for the active profile
 func configDir() (string, error) {
 	home, err := os.UserHomeDir()
 	if err != nil {
 		return "", err
 	}
+	if profile != DefaultProfile {
+		return filepath.Join(home, ".grind", "profiles", profile), nil
+	}
 	return filepath.Join(home, ".grind"), nil
 }
 
@@ -43,6 +173,33 @@ func configPath() (string, error) {
 	return filepath.Join(dir, "config.json"), nil
 }
 
+// Path returns the config file path
+func Path() (string, error) {
+	return configPath()
+}
+
+// LogPath returns where --debug writes its log
+func LogPath() (string, error) {
+	dir, err := configDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "grind.log"), nil
+}
+
+// DataPath returns where local mode keeps quests
+func DataPath() (string, error) {
+	dir, err := configDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "data.json"), nil
+}
+
+// LocalProfile is the profile --local uses unless --profile names another,
+// so a guest account never mixes with a real one
+const LocalProfile = "local"
+
 // Load reads the config from disk
 func Load() (*Config, error) {
 	path, err := configPath()
@@ -87,7 +244,69 @@ func Save(cfg *Config) error {
 		return err
 	}
 
-	return os.WriteFile(path, data, 0600)
+	// Write a temp file and rename it over the config, so a crash or full
+	// disk mid-write can't leave a truncated config behind
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// updateMu serializes Update within this process
+var updateMu sync.Mutex
+
+// Update reloads the config from disk, applies fn and saves the result, so
+// a long-running session can change a few fields without overwriting what
+// other commands saved since it loaded the config
+func Update(fn func(*Config)) error {
+	updateMu.Lock()
+	defer updateMu.Unlock()
+
+	cfg, err := Load()
+	if err != nil {
+		return err
+	}
+	fn(cfg)
+	return Save(cfg)
+}
+
+// Backup copies the config file to config.json.bak before a risky change,
+// returning the backup's path. Having no config yet isn't an error.
+func Backup() (string, error) {
+	path, err := configPath()
+	if err != nil {
+		return "", err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	backup := path + ".bak"
+	return backup, os.WriteFile(backup, data, 0600)
+}
+
+// RestoreBackup puts the copy made by Backup back in place of the config,
+// undoing a change that turned out to be bad
+func RestoreBackup() error {
+	path, err := configPath()
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(path + ".bak")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, path)
 }
 
 // IsLoggedIn returns true if the user has set up their profile
@@ -95,6 +314,48 @@ func (c *Config) IsLoggedIn() bool {
 	return c.UserID != "" && c.UserName != ""
 }
 
+// MaxQuestTimer is the longest timer 'grind start --timer' accepts
+const MaxQuestTimer = 12 * time.Hour
+
+// SetQuestTimer asks about completing questID once due passes
+func (c *Config) SetQuestTimer(questID string, due time.Time) {
+	if c.QuestTimers == nil {
+		c.QuestTimers = map[string]int64{}
+	}
+	c.QuestTimers[questID] = due.Unix()
+}
+
+// ClearQuestTimer drops questID's timer, reporting whether it had one
+func (c *Config) ClearQuestTimer(questID string) bool {
+	if _, ok := c.QuestTimers[questID]; !ok {
+		return false
+	}
+	delete(c.QuestTimers, questID)
+	return true
+}
+
+// NextQuestTimer returns the timer due first, or ok false when none are set
+func (c *Config) NextQuestTimer() (questID string, due int64, ok bool) {
+	for id, at := range c.QuestTimers {
+		if !ok || at < due || (at == due && id < questID) {
+			questID, due, ok = id, at, true
+		}
+	}
+	return questID, due, ok
+}
+
+// ClearGroup forgets the user's crew, e.g. after it was deleted
+func (c *Config) ClearGroup() {
+	c.SetGroup("", "")
+}
+
+// SetGroup records the user's crew, dropping the old crew's level names
+func (c *Config) SetGroup(id, name string) {
+	c.GroupID = id
+	c.GroupName = name
+	c.LevelNames = nil
+}
+
 // HasGroup returns true if the user is in a group
 func (c *Config) HasGroup() bool {
 	return c.GroupID != ""
@@ -108,6 +369,158 @@ func (c *Config) GetConvexURL() string {
 	return DefaultConvexURL
 }
 
+// GetPollInterval returns the dashboard refresh interval, using the default
+// if unset or invalid and never going below MinPollInterval
+func (c *Config) GetPollInterval() time.Duration {
+	if c.PollInterval == "" {
+		return DefaultPollInterval
+	}
+	d, err := time.ParseDuration(c.PollInterval)
+	if err != nil {
+		return DefaultPollInterval
+	}
+	if d < MinPollInterval {
+		return MinPollInterval
+	}
+	return d
+}
+
+// GetIdleAfter returns how long the dashboard waits without input before
+// it stops polling, or 0 if it never does
+func (c *Config) GetIdleAfter() time.Duration {
+	if c.IdleAfter == "off" {
+		return 0
+	}
+	d, err := time.ParseDuration(c.IdleAfter)
+	if err != nil {
+		return DefaultIdleAfter
+	}
+	return max(d, MinIdleAfter)
+}
+
+// GetStreakFreezes returns how many missed days a week the streak forgives
+func (c *Config) GetStreakFreezes() int {
+	if c.StreakFreezes == nil {
+		return streaks.DefaultFreezesPerWeek
+	}
+	return *c.StreakFreezes
+}
+
+// GetConfirmDoneXP returns the XP above which completing a quest asks
+// first, 0 if it never does
+func (c *Config) GetConfirmDoneXP() int {
+	if c.ConfirmDoneXP == nil {
+		return DefaultConfirmDoneXP
+	}
+	return *c.ConfirmDoneXP
+}
+
+// GetEvaluator returns the evaluator that scores new quests, "ai" if unset
+func (c *Config) GetEvaluator() string {
+	if c.Evaluator == "" {
+		return "ai"
+	}
+	return c.Evaluator
+}
+
+// ConfirmsDone reports whether completing a quest worth xp should ask first
+func (c *Config) ConfirmsDone(xp int) bool {
+	threshold := c.GetConfirmDoneXP()
+	return threshold > 0 && xp > threshold
+}
+
+// TemplateNames returns the saved template names in sorted order
+func (c *Config) TemplateNames() []string {
+	names := make([]string, 0, len(c.Templates))
+	for name := range c.Templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ClaimGoalCelebration reports whether weeklyXP has reached the weekly goal
+// for the first time in week, and records it so it's only celebrated once
+func (c *Config) ClaimGoalCelebration(weeklyXP int, week string) bool {
+	if c.WeeklyGoal <= 0 || weeklyXP < c.WeeklyGoal || c.GoalHitWeek == week {
+		return false
+	}
+	c.GoalHitWeek = week
+	return true
+}
+
+// Location returns the configured timezone, falling back to the system's
+// local zone if unset or unknown
+func (c *Config) Location() *time.Location {
+	if c.Timezone == "" {
+		return time.Local
+	}
+	loc, err := time.LoadLocation(c.Timezone)
+	if err != nil {
+		return time.Local
+	}
+	return loc
+}
+
+// ValidateTimezone checks that name is a timezone Location can load
+func ValidateTimezone(name string) error {
+	if _, err := time.LoadLocation(name); err != nil || name == "" {
+		return fmt.Errorf("unknown timezone %q (try Europe/Berlin or America/New_York)", name)
+	}
+	return nil
+}
+
+// ValidatePollInterval checks that s is a duration no shorter than MinPollInterval
+func ValidatePollInterval(s string) error {
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return fmt.Errorf("invalid duration %q (try 10s or 1m)", s)
+	}
+	if d < MinPollInterval {
+		return fmt.Errorf("poll interval must be at least %s", MinPollInterval)
+	}
+	return nil
+}
+
+// ValidateIdleAfter checks that s is "off" or a duration no shorter than
+// MinIdleAfter
+func ValidateIdleAfter(s string) error {
+	if s == "off" {
+		return nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return fmt.Errorf("invalid duration %q (try 30m, 2h or off)", s)
+	}
+	if d < MinIdleAfter {
+		return fmt.Errorf("idle time must be at least %s", MinIdleAfter)
+	}
+	return nil
+}
+
+// ResetPreferences puts every setting back to its default while keeping
+// the account: who the user is, their crew, the backend it lives on, and
+// what they've saved (templates, timers, a draft quest, cached progress)
+func (c *Config) ResetPreferences() {
+	*c = Config{
+		UserID:      c.UserID,
+		UserName:    c.UserName,
+		GroupID:     c.GroupID,
+		GroupName:   c.GroupName,
+		ConvexURL:   c.ConvexURL,
+		Local:       c.Local,
+		LevelNames:  c.LevelNames,
+		Templates:   c.Templates,
+		SignupKey:   c.SignupKey,
+		DraftQuest:  c.DraftQuest,
+		TotalXP:     c.TotalXP,
+		Rank:        c.Rank,
+		ProgressAt:  c.ProgressAt,
+		LastSeenAt:  c.LastSeenAt,
+		QuestTimers: c.QuestTimers,
+	}
+}
+
 // Clear removes all stored credentials
 func Clear() error {
 	path, err := configPath()
@@ -0,0 +1,80 @@
+package auth
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+// fill sets every field of v to a non-zero value
+func fill(t *testing.T, v reflect.Value) {
+	t.Helper()
+	for i := range v.NumField() {
+		f := v.Field(i)
+		switch f.Kind() {
+		case reflect.String:
+			f.SetString("x")
+		case reflect.Bool:
+			f.SetBool(true)
+		case reflect.Int, reflect.Int64:
+			f.SetInt(7)
+		case reflect.Slice:
+			f.Set(reflect.MakeSlice(f.Type(), 1, 1))
+		case reflect.Map:
+			f.Set(reflect.MakeMap(f.Type()))
+		case reflect.Pointer:
+			f.Set(reflect.New(f.Type().Elem()))
+		default:
+			t.Fatalf("fill: unhandled field %s of kind %s", v.Type().Field(i).Name, f.Kind())
+		}
+	}
+}
+
+func TestResetPreferences(t *testing.T) {
+	kept := []string{
+		"UserID", "UserName", "GroupID", "GroupName", "ConvexURL", "Local",
+		"LevelNames", "Templates", "SignupKey", "DraftQuest",
+		"TotalXP", "Rank", "ProgressAt", "LastSeenAt", "QuestTimers",
+	}
+
+	var cfg Config
+	fill(t, reflect.ValueOf(&cfg).Elem())
+	before := cfg
+	cfg.ResetPreferences()
+
+	after := reflect.ValueOf(cfg)
+	orig := reflect.ValueOf(before)
+	for i := range after.NumField() {
+		name := after.Type().Field(i).Name
+		if slices.Contains(kept, name) {
+			if !reflect.DeepEqual(after.Field(i).Interface(), orig.Field(i).Interface()) {
+				t.Errorf("%s was reset, want it kept", name)
+			}
+		} else if !after.Field(i).IsZero() {
+			t.Errorf("%s = %v, want it reset", name, after.Field(i).Interface())
+		}
+	}
+}
+
+func TestUpdateKeepsOtherChanges(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	if err := Save(&Config{UserID: "u1", UserName: "ada"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Two commands each change only their own field
+	if err := Update(func(c *Config) { c.UserName = "grace" }); err != nil {
+		t.Fatal(err)
+	}
+	if err := Update(func(c *Config) { c.DraftQuest = "ship it" }); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.UserName != "grace" || cfg.DraftQuest != "ship it" || cfg.UserID != "u1" {
+		t.Errorf("config = %+v", cfg)
+	}
+}
@@ -0,0 +1,93 @@
+// Package evaluator scores a quest's XP from its title. The backend's AI
+// action is the default; a local LLM endpoint or the keyword estimate can
+// be picked instead with the "evaluator" setting.
+package evaluator
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"grind/internal/api"
+	"grind/internal/auth"
+	"grind/internal/xp"
+)
+
+// Evaluator scores a quest title, returning its XP and a short reason
+type Evaluator interface {
+	Evaluate(ctx context.Context, title string) (xp int, reasoning string, err error)
+}
+
+// Evaluator names accepted by the "evaluator" setting
+const (
+	NameAI       = "ai"
+	NameLLM      = "llm"
+	NameKeywords = "keywords"
+)
+
+// Names lists the evaluators in the order 'grind config' shows them
+func Names() []string {
+	return []string{NameAI, NameLLM, NameKeywords}
+}
+
+// Valid reports whether name is a known evaluator
+func Valid(name string) bool {
+	for _, n := range Names() {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// For returns the evaluator cfg picks. backend may be nil in local mode, in
+// which case the default AI falls back to the keyword estimate.
+func For(cfg *auth.Config, backend api.ConvexAPI) Evaluator {
+	switch cfg.GetEvaluator() {
+	case NameLLM:
+		return &LLM{
+			URL:    cfg.LLMURL,
+			Model:  cfg.LLMModel,
+			APIKey: os.Getenv("GRIND_LLM_KEY"),
+		}
+	case NameKeywords:
+		return Keywords{Rules: cfg.XPKeywords}
+	}
+	client := api.ClientFor(backend)
+	if client == nil {
+		return Keywords{Rules: cfg.XPKeywords}
+	}
+	return Convex{Client: client}
+}
+
+// Convex asks the backend's ai:evaluateQuest action
+type Convex struct {
+	Client *api.Client
+}
+
+// Evaluate implements Evaluator
+func (c Convex) Evaluate(ctx context.Context, title string) (int, string, error) {
+	result, err := c.Client.Action(ctx, "ai:evaluateQuest", map[string]any{
+		"title": title,
+	})
+	if err != nil {
+		return 0, "", err
+	}
+
+	var eval api.QuestEvaluation
+	if err := api.DecodeInto(result, &eval); err != nil || result == nil {
+		return 0, "", fmt.Errorf("unexpected response format")
+	}
+	return eval.XP, eval.Reasoning, nil
+}
+
+// Keywords is the built-in estimate from keywords in the title. It never
+// fails, so it's also the fallback when another evaluator does.
+type Keywords struct {
+	Rules *xp.Rules
+}
+
+// Evaluate implements Evaluator
+func (k Keywords) Evaluate(ctx context.Context, title string) (int, string, error) {
+	return xp.Estimate(title, k.Rules), "local estimate", nil
+}
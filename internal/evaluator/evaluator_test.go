@@ -0,0 +1,116 @@
+package evaluator
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"grind/internal/api"
+	"grind/internal/api/apitest"
+	"grind/internal/auth"
+)
+
+func TestParseEvaluation(t *testing.T) {
+	tests := []struct {
+		name      string
+		text      string
+		xp        int
+		reasoning string
+		ok        bool
+	}{
+		{"bare JSON", `{"xp": 40, "reasoning": "routine workout"}`, 40, "routine workout", true},
+		{"prose around it", `Sure! Here you go: {"xp": 75, "reasoning": "shipped"} Hope that helps.`, 75, "shipped", true},
+		{"code fence", "```json\n{\"xp\": 20, \"reasoning\": \"small effort\"}\n```", 20, "small effort", true},
+		{"fractional XP", `{"xp": 42.9, "reasoning": "close"}`, 42, "close", true},
+		{"clamped high", `{"xp": 900, "reasoning": "epic"}`, api.MaxQuestXP, "epic", true},
+		{"clamped low", `{"xp": -10, "reasoning": "nap"}`, api.MinQuestXP, "nap", true},
+		{"missing reasoning", `{"xp": 10}`, 10, "LLM evaluated", true},
+		{"no object", "I can't score that", 0, "", false},
+		{"braces reversed", "} nope {", 0, "", false},
+		{"invalid JSON", `{"xp": forty}`, 0, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			xp, reasoning, err := parseEvaluation(tt.text)
+			if (err == nil) != tt.ok {
+				t.Fatalf("parseEvaluation(%q) err = %v", tt.text, err)
+			}
+			if xp != tt.xp || reasoning != tt.reasoning {
+				t.Errorf("parseEvaluation(%q) = %d, %q; want %d, %q", tt.text, xp, reasoning, tt.xp, tt.reasoning)
+			}
+		})
+	}
+}
+
+func TestLLMEvaluate(t *testing.T) {
+	var got chatRequest
+	var authHeader string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authHeader = r.Header.Get("Authorization")
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decode request: %v", err)
+		}
+		switch {
+		case strings.Contains(got.Messages[1].Content, "down"):
+			http.Error(w, "model not loaded", http.StatusServiceUnavailable)
+		case strings.Contains(got.Messages[1].Content, "empty"):
+			w.Write([]byte(`{"choices": []}`))
+		default:
+			var resp chatResponse
+			resp.Choices = append(resp.Choices, struct {
+				Message chatMessage `json:"message"`
+			}{chatMessage{Role: "assistant", Content: "```json\n{\"xp\": 80, \"reasoning\": \"shipped a feature\"}\n```"}})
+			json.NewEncoder(w).Encode(resp)
+		}
+	}))
+	defer srv.Close()
+
+	l := &LLM{URL: srv.URL, APIKey: "secret"}
+	xp, reasoning, err := l.Evaluate(context.Background(), "ship the feature")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if xp != 80 || reasoning != "shipped a feature" {
+		t.Errorf("Evaluate = %d, %q", xp, reasoning)
+	}
+	if got.Model != DefaultLLMModel || len(got.Messages) != 2 || got.Messages[0].Role != "system" {
+		t.Errorf("request = %+v", got)
+	}
+	if authHeader != "Bearer secret" {
+		t.Errorf("Authorization = %q", authHeader)
+	}
+
+	if _, _, err := l.Evaluate(context.Background(), "server down"); err == nil || !strings.Contains(err.Error(), "LLM error 503: model not loaded") {
+		t.Errorf("503: err = %v", err)
+	}
+	if _, _, err := l.Evaluate(context.Background(), "empty reply"); err == nil {
+		t.Error("no choices: no error")
+	}
+}
+
+func TestFor(t *testing.T) {
+	cfg := &auth.Config{}
+	if _, ok := For(cfg, nil).(Keywords); !ok {
+		t.Error("no backend: want the keyword estimate")
+	}
+	var noClient *api.Client
+	if _, ok := For(cfg, noClient).(Keywords); !ok {
+		t.Error("nil *api.Client: want the keyword estimate")
+	}
+	if _, ok := For(&auth.Config{Evaluator: NameLLM}, nil).(*LLM); !ok {
+		t.Error("llm setting: want an LLM")
+	}
+
+	fake := apitest.NewFake()
+	fake.Return("ai:evaluateQuest", api.QuestEvaluation{XP: 60, Reasoning: "deep work"})
+	xp, reasoning, err := For(cfg, fake).Evaluate(context.Background(), "write the design doc")
+	if err != nil || xp != 60 || reasoning != "deep work" {
+		t.Errorf("Evaluate through the backend = %d, %q, %v", xp, reasoning, err)
+	}
+	if calls := fake.CallsTo("ai:evaluateQuest"); len(calls) != 1 || calls[0].Kind != "action" || calls[0].Args["title"] != "write the design doc" {
+		t.Errorf("calls = %+v", calls)
+	}
+}
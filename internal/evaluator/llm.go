@@ -0,0 +1,139 @@
+package evaluator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"grind/internal/api"
+)
+
+// DefaultLLMURL is an OpenAI-compatible chat endpoint as served locally by
+// Ollama, llama.cpp and LM Studio
+const DefaultLLMURL = "http://localhost:11434/v1/chat/completions"
+
+// DefaultLLMModel is sent when no model is configured
+const DefaultLLMModel = "llama3.2"
+
+// llmPrompt is the scoring rubric, kept in line with convex/ai.ts
+const llmPrompt = `You are an XP evaluator for a competitive productivity tracker. Users earn XP for ACTIVE effort that makes them better — coding, sports, learning, building, creating.
+
+SCORING GUIDELINES:
+- 0 XP: Passive/recovery (sleep, rest, nap, relax, chill, watching TV, scrolling, eating, showering)
+- 5-15 XP: Trivial active tasks (reply to email, quick fix, short call)
+- 20-40 XP: Small effort (reading 10-30 pages, routine workout, code review)
+- 45-70 XP: Medium effort (deep work session, learning new skill, gym 1hr+)
+- 75-100 XP: Large effort (ship feature, run 10km+, intense training)
+- 100-150 XP: Epic (launch product, marathon, mass achievements)
+
+Reply with JSON only: {"xp": <number 0-150>, "reasoning": "<brief explanation, 5-10 words>"}`
+
+// LLM asks an OpenAI-compatible chat completions endpoint directly, so
+// quests can be scored by a local model without the backend
+type LLM struct {
+	URL    string // DefaultLLMURL if empty
+	Model  string // DefaultLLMModel if empty
+	APIKey string // Sent as a bearer token if set
+
+	HTTPClient *http.Client // http.DefaultClient if nil
+}
+
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type chatRequest struct {
+	Model       string        `json:"model"`
+	Messages    []chatMessage `json:"messages"`
+	Temperature float64       `json:"temperature"`
+}
+
+type chatResponse struct {
+	Choices []struct {
+		Message chatMessage `json:"message"`
+	} `json:"choices"`
+}
+
+// Evaluate implements Evaluator
+func (l *LLM) Evaluate(ctx context.Context, title string) (int, string, error) {
+	url := l.URL
+	if url == "" {
+		url = DefaultLLMURL
+	}
+	model := l.Model
+	if model == "" {
+		model = DefaultLLMModel
+	}
+
+	body, err := json.Marshal(chatRequest{
+		Model: model,
+		Messages: []chatMessage{
+			{Role: "system", Content: llmPrompt},
+			{Role: "user", Content: fmt.Sprintf("Task: %q", title)},
+		},
+	})
+	if err != nil {
+		return 0, "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if l.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+l.APIKey)
+	}
+
+	httpClient := l.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return 0, "", fmt.Errorf("LLM request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return 0, "", fmt.Errorf("LLM error %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+
+	var chat chatResponse
+	if err := json.Unmarshal(respBody, &chat); err != nil || len(chat.Choices) == 0 {
+		return 0, "", fmt.Errorf("unexpected response format")
+	}
+	return parseEvaluation(chat.Choices[0].Message.Content)
+}
+
+// parseEvaluation pulls the {"xp", "reasoning"} object out of a model's
+// reply, which may wrap it in prose or a code fence
+func parseEvaluation(text string) (int, string, error) {
+	start, end := strings.Index(text, "{"), strings.LastIndex(text, "}")
+	if start < 0 || end < start {
+		return 0, "", fmt.Errorf("LLM returned invalid response: %s", text)
+	}
+
+	var result struct {
+		XP        float64 `json:"xp"`
+		Reasoning string  `json:"reasoning"`
+	}
+	if err := json.Unmarshal([]byte(text[start:end+1]), &result); err != nil {
+		return 0, "", fmt.Errorf("LLM returned invalid response: %s", text)
+	}
+
+	xp := min(api.MaxQuestXP, max(api.MinQuestXP, int(result.XP)))
+	if result.Reasoning == "" {
+		result.Reasoning = "LLM evaluated"
+	}
+	return xp, result.Reasoning, nil
+}
@@ -0,0 +1,57 @@
+package goals
+
+import (
+	"fmt"
+	"time"
+)
+
+// MaxWeeklyGoal caps the weekly XP goal at something a person could plausibly hit
+const MaxWeeklyGoal = 10000
+
+// WeekStart returns Monday 00:00 of the week containing t, matching the
+// backend's weekly reset
+func WeekStart(t time.Time) time.Time {
+	day := (int(t.Weekday()) + 6) % 7 // Monday = 0
+	y, m, d := t.AddDate(0, 0, -day).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
+
+// WeekKey identifies the week containing t, e.g. "2026-W42"
+func WeekKey(t time.Time) string {
+	year, week := WeekStart(t).ISOWeek()
+	return fmt.Sprintf("%d-W%02d", year, week)
+}
+
+// WeekElapsed returns how far through the week t is, from 0 to 1
+func WeekElapsed(t time.Time) float64 {
+	elapsed := t.Sub(WeekStart(t))
+	frac := float64(elapsed) / float64(7*24*time.Hour)
+	if frac > 1 {
+		return 1
+	}
+	return frac
+}
+
+// Behind returns how many XP the user is behind an even pace toward goal
+// at time t, or 0 if on pace, ahead, or no goal is set
+func Behind(weeklyXP, goal int, t time.Time) int {
+	if goal <= 0 || weeklyXP >= goal {
+		return 0
+	}
+	expected := int(float64(goal) * WeekElapsed(t))
+	if weeklyXP >= expected {
+		return 0
+	}
+	return expected - weeklyXP
+}
+
+// Validate rejects goals outside 1-MaxWeeklyGoal
+func Validate(goal int) error {
+	if goal <= 0 {
+		return fmt.Errorf("weekly goal must be positive")
+	}
+	if goal > MaxWeeklyGoal {
+		return fmt.Errorf("weekly goal must be at most %d XP", MaxWeeklyGoal)
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package i18n
+
+// en is the English catalog and the fallback for every other language.
+// Keys are "<area>.<message>"; values with verbs are formatted with Tf.
+var en = map[string]string{
+	// Numbers and plurals (see Number and Plural)
+	"number.thousands":       ",",
+	"number.decimal":         ".",
+	"plural.member.one":      "%s member",
+	"plural.member.other":    "%s members",
+	"plural.day.one":         "%s day",
+	"plural.day.other":       "%s days",
+	"plural.dayStreak.one":   "%s Day Streak",
+	"plural.dayStreak.other": "%s Day Streak",
+	"plural.forgiven.one":    "%s missed day forgiven",
+	"plural.forgiven.other":  "%s missed days forgiven",
+	"plural.level.one":       "%s level",
+	"plural.level.other":     "%s levels",
+	"plural.change.one":      "%s change",
+	"plural.change.other":    "%s changes",
+	"plural.quest.one":       "%s quest",
+	"plural.quest.other":     "%s quests",
+
+	// Commands
+	"cmd.notLoggedIn": "Not logged in. Run 'grind' to set up.",
+	"cmd.noGroup":     "Not in a group. Run 'grind join <code>' to join one.",
+
+	// Onboarding
+	"onboarding.subtitle":         "competitive task tracking",
+	"onboarding.tagline":          "for hackers",
+	"onboarding.pressStart":       "press enter to start",
+	"onboarding.setUp":            "first time? let's set up.",
+	"onboarding.yourName":         "your name: ",
+	"onboarding.namePlaceholder":  "your name",
+	"onboarding.creatingAccount":  "creating account...",
+	"onboarding.retry":            "press enter to retry",
+	"onboarding.error":            "error: %v",
+	"onboarding.hey":              "hey %s!",
+	"onboarding.groupQuestion":    "join existing group or create new?",
+	"onboarding.createGroup":      "create new group",
+	"onboarding.joinGroup":        "join with invite code",
+	"onboarding.selectHelp":       "%s to select, enter to confirm",
+	"onboarding.createTitle":      "create your group",
+	"onboarding.groupName":        "group name: ",
+	"onboarding.groupPlaceholder": "group name",
+	"onboarding.creatingGroup":    "creating group...",
+	"onboarding.joinTitle":        "join a group",
+	"onboarding.inviteCode":       "invite code: ",
+	"onboarding.joiningGroup":     "joining group...",
+	"onboarding.groupFull":        "that group is full - try another code",
+	"onboarding.badCode":          "codes look like ABC-123",
+	"onboarding.allSet":           "%s you're all set!",
+	"onboarding.inviteFriends":    "invite your friends:",
+	"onboarding.joined":           "joined: %s",
+	"onboarding.localOnly":        "local mode - quests stay on this machine",
+	"onboarding.startGrinding":    "press enter to start grinding...",
+	"onboarding.editTitle":        "edit your profile",
+	"onboarding.savingName":       "saving name...",
+	"onboarding.keepGroup":        "stay in %s",
+	"onboarding.profileUpdated":   "%s profile updated",
+	"onboarding.stillIn":          "still in: %s",
+
+	// Dashboard greetings by time of day, "|"-separated variants
+	"greeting.midnight":  "burning the midnight oil|still up|night shift",
+	"greeting.early":     "early grind|up before the sun",
+	"greeting.morning":   "gm|morning|rise and grind",
+	"greeting.afternoon": "afternoon|hey|back at it",
+	"greeting.evening":   "evening|evening grind|hey",
+	"greeting.late":      "late night grind|one more quest",
+
+	// Header stats line
+	"header.crewToday": "Crew: %s Active %s %s XP Today",
+
+	// Dashboard header
+	"dashboard.today":      "today",
+	"dashboard.thisWeek":   "this week",
+	"dashboard.crew":       "crew",
+	"dashboard.noQuests":   "no quests",
+	"dashboard.questsDone": "%d/%d done",
+	"dashboard.rank":       "#%d rank",
+	"dashboard.noGroup":    "no group",
+	"dashboard.joinOne":    "join one!",
+	"dashboard.active":     "%d/%d active",
+	"dashboard.crewWeek":   "crew %s XP",
+	"dashboard.youLead":    "you're leading!",
+	"dashboard.leading":    "%s leading",
+	"dashboard.localMode":  "local mode",
+
+	// Dashboard quests
+	"dashboard.todaysQuests": "today's quests",
+	"dashboard.legend":       "%s todo  %s working  %s done",
+	"dashboard.noQuestsYet":  "no quests yet",
+	"dashboard.typeToAdd":    "type below to add one",
+	"dashboard.potential":    "potential: %s",
+	"dashboard.earned":       "earned: %s",
+	"dashboard.stillSyncing": "still syncing %[1]s press q again to quit",
+	"dashboard.idle":         "idle %[1]s refreshes paused %[1]s press any key to resume",
+	"dashboard.retrying":     "retrying (%d/%d)%s",
+
+	// Dashboard help lines, formatted by helpf: %[1]s is the separator
+	// between keys and %[2]s the up/down arrows
+	"dashboard.helpInput":  "enter add task %[1]s tab/shift+tab switch panels %[1]s G crew %[1]s q quit",
+	"dashboard.helpFeed":   "%[2]s select %[1]s f %[3]s %[1]s m %[4]s %[1]s c %[5]s react to crew completions %[1]s i new insight %[1]s A/+/- filter board %[1]s tab/shift+tab switch panels %[1]s h more keys %[1]s q quit",
+	"dashboard.helpQuests": "enter start/done %[1]s %[2]s select %[1]s J/K move %[1]s C complete all %[1]s T template %[1]s x set XP %[1]s n sub-task %[1]s space tick sub-task %[1]s d details %[1]s z snooze %[1]s X abandon %[1]s i new insight %[1]s G crew %[1]s R rival %[1]s L all-time %[1]s A/+/- filter board %[1]s tab/shift+tab switch panels %[1]s , settings %[1]s P profile %[1]s a add %[1]s h more keys %[1]s q quit",
+
+	// Expanded help (h): one line per group, formatted like the help lines
+	"dashboard.cheatNav":         "nav",
+	"dashboard.cheatNavKeys":     "%[2]s select %[1]s J/K move %[1]s 1-9 pick quest %[1]s d details %[1]s tab/shift+tab switch panels %[1]s esc clear",
+	"dashboard.cheatActions":     "actions",
+	"dashboard.cheatActionsKeys": "a add %[1]s enter start/done %[1]s space tick sub-task %[1]s n sub-task %[1]s x set XP %[1]s z snooze %[1]s X abandon %[1]s C complete all %[1]s T template %[1]s i new insight %[1]s f/m/c react %[1]s A/+/- filter board",
+	"dashboard.cheatGlobal":      "global",
+	"dashboard.cheatGlobalKeys":  "G crew %[1]s R rival %[1]s L all-time %[1]s , settings %[1]s P profile %[1]s h fewer keys %[1]s q quit",
+
+	// HUD panels
+	"panel.quests":          "ACTIVE QUESTS",
+	"panel.intel":           "INTEL FEED",
+	"panel.leaderboardWeek": "LEADERBOARD %s WEEK",
+	"panel.leaderboardAll":  "LEADERBOARD %s ALL TIME",
+	"panel.noQuestsYet":     "no quests yet",
+	"panel.addOneBelow":     "add one below!",
+	"panel.earned":          "earned: ",
+	"panel.potentialLabel":  "potential: ",
+	"panel.noActivity":      "no activity yet",
+	"panel.noMatches":       "no one matches",
+	"panel.hidden":          "%s hidden by filter",
+	"panel.filterActive":    "active",
+	"panel.noRankings":      "no rankings yet",
+	"panel.inviteToCompete": "invite friends to start competing:",
+	"panel.noNotes":         "no notes",
+	"panel.start":           " [start]",
+	"panel.done":            " [done]",
+	"panel.abandoned":       "dropped",
+	"panel.rerolling":       "rerolling...",
+
+	// Weekly goal nudges in the insight box
+	"goal.nudgeQuest": "%s XP behind pace. \"%s\" gets you back",
+	"goal.nudge":      "%s XP behind pace on your %s XP goal. time to ship something",
+
+	// Quest status words shown with colorSafe on
+	"status.todo":    "todo",
+	"status.active":  "active",
+	"status.done":    "done",
+	"status.dropped": "dropped",
+	"status.snoozed": "snoozed",
+}
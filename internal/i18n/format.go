@@ -0,0 +1,113 @@
+package i18n
+
+import (
+	"strconv"
+	"strings"
+)
+
+// fullNumbers turns off FormatXP's abbreviations
+var fullNumbers bool
+
+// SetFullNumbers makes FormatXP print every digit (the "fullNumbers"
+// setting) instead of abbreviating large amounts
+func SetFullNumbers(full bool) {
+	fullNumbers = full
+}
+
+// FormatXP formats an XP amount for tight spots like the header and
+// leaderboard. Amounts under 1,000 are exact; larger ones are abbreviated
+// to one decimal, truncated rather than rounded so a total never reads as
+// a threshold it hasn't reached yet.
+//
+//	FormatXP(950)     → "950"
+//	FormatXP(1250)    → "1.2k"
+//	FormatXP(12000)   → "12k"
+//	FormatXP(3456789) → "3.4M"
+func FormatXP(n int) string {
+	if fullNumbers {
+		return Number(n)
+	}
+	sign := ""
+	abs := n
+	if n < 0 {
+		sign, abs = "-", -n
+	}
+	if abs < 1000 {
+		return strconv.Itoa(n)
+	}
+
+	units := []struct {
+		size   int
+		suffix string
+	}{
+		{1_000_000_000, "B"},
+		{1_000_000, "M"},
+		{1_000, "k"},
+	}
+	for _, u := range units {
+		if abs < u.size {
+			continue
+		}
+		tenths := abs / (u.size / 10)
+		out := strconv.Itoa(tenths / 10)
+		if d := tenths % 10; d != 0 {
+			out += T("number.decimal") + strconv.Itoa(d)
+		}
+		return sign + out + u.suffix
+	}
+	return strconv.Itoa(n)
+}
+
+// Number formats n with the active language's thousands separator,
+// e.g. 12345 → "12,345"
+func Number(n int) string {
+	digits := strconv.Itoa(n)
+	sign := ""
+	if n < 0 {
+		sign, digits = "-", digits[1:]
+	}
+	if len(digits) <= 3 {
+		return sign + digits
+	}
+
+	sep := T("number.thousands")
+	var b strings.Builder
+	b.WriteString(sign)
+	head := len(digits) % 3
+	if head > 0 {
+		b.WriteString(digits[:head])
+	}
+	for i := head; i < len(digits); i += 3 {
+		if i > 0 {
+			b.WriteString(sep)
+		}
+		b.WriteString(digits[i : i+3])
+	}
+	return b.String()
+}
+
+// Plural formats a count with the right plural form of key. Catalogs give
+// key+".one" for exactly 1 and key+".other" for everything else, each with
+// the count (formatted by Number) as its %s, plus an optional key+".zero"
+// used as-is for 0, e.g. "no members".
+//
+//	Plural("plural.member", 1)    → "1 member"
+//	Plural("plural.member", 1200) → "1,200 members"
+func Plural(key string, n int) string {
+	switch {
+	case n == 0 && has(key+".zero"):
+		return T(key + ".zero")
+	case n == 1:
+		return Tf(key+".one", Number(n))
+	}
+	return Tf(key+".other", Number(n))
+}
+
+// has reports whether key has a message in the active language or English
+func has(key string) bool {
+	if _, ok := current[key]; ok {
+		return true
+	}
+	_, ok := en[key]
+	return ok
+}
@@ -0,0 +1,52 @@
+package i18n
+
+import "testing"
+
+func TestFormatXP(t *testing.T) {
+	tests := []struct {
+		n    int
+		want string
+	}{
+		{0, "0"},
+		{999, "999"},
+		{1000, "1k"},
+		{1049, "1k"},
+		{1099, "1k"},
+		{1100, "1.1k"},
+		{9999, "9.9k"},
+		{12_000, "12k"},
+		{999_999, "999.9k"},
+		{1_000_000, "1M"},
+		{3_456_789, "3.4M"},
+		{2_000_000_000, "2B"},
+		{-999, "-999"},
+		{-1000, "-1k"},
+		{-1550, "-1.5k"},
+	}
+	for _, tt := range tests {
+		if got := FormatXP(tt.n); got != tt.want {
+			t.Errorf("FormatXP(%d) = %q, want %q", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestFormatXPFullNumbers(t *testing.T) {
+	SetFullNumbers(true)
+	defer SetFullNumbers(false)
+
+	tests := []struct {
+		n    int
+		want string
+	}{
+		{999, "999"},
+		{1049, "1,049"},
+		{999_999, "999,999"},
+		{1_000_000, "1,000,000"},
+		{-1550, "-1,550"},
+	}
+	for _, tt := range tests {
+		if got := FormatXP(tt.n); got != tt.want {
+			t.Errorf("FormatXP(%d) = %q, want %q", tt.n, got, tt.want)
+		}
+	}
+}
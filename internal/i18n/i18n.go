@@ -0,0 +1,75 @@
+// Package i18n looks up user-facing strings by message ID so translations
+// can be added without touching layout code.
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// DefaultLang is used when no language is set or the requested one has no
+// catalog. It is also the fallback for messages a catalog doesn't translate.
+const DefaultLang = "en"
+
+// catalogs maps a language code to its messages. A translation adds its
+// own file with a map keyed by the same IDs as en and registers it here.
+var catalogs = map[string]map[string]string{
+	"en": en,
+}
+
+// current is the active catalog
+var current = en
+
+// Languages returns the language codes that have a catalog, sorted
+func Languages() []string {
+	langs := make([]string, 0, len(catalogs))
+	for lang := range catalogs {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+// Supported reports whether lang has a catalog
+func Supported(lang string) bool {
+	_, ok := catalogs[lang]
+	return ok
+}
+
+// Use picks the active language: $GRIND_LANG if set, otherwise lang (the
+// "lang" setting), otherwise DefaultLang. Region suffixes are ignored, so
+// "pt_BR" or "pt-BR" use the "pt" catalog.
+func Use(lang string) {
+	if env := os.Getenv("GRIND_LANG"); env != "" {
+		lang = env
+	}
+	lang = strings.ToLower(lang)
+	if i := strings.IndexAny(lang, "_-."); i >= 0 {
+		lang = lang[:i]
+	}
+	if c, ok := catalogs[lang]; ok {
+		current = c
+	} else {
+		current = en
+	}
+}
+
+// T returns the message for key in the active language, falling back to
+// English and then to the key itself so a missing message is visible
+// rather than blank
+func T(key string) string {
+	if msg, ok := current[key]; ok {
+		return msg
+	}
+	if msg, ok := en[key]; ok {
+		return msg
+	}
+	return key
+}
+
+// Tf formats the message for key with args, like fmt.Sprintf
+func Tf(key string, args ...any) string {
+	return fmt.Sprintf(T(key), args...)
+}
@@ -21,6 +21,25 @@ var Levels = []Level{
 	{Number: 10, Name: "∞", MinXP: 5500},
 }
 
+// customNames are the crew's own names for the levels, in order; see
+// SetNames
+var customNames []string
+
+// SetNames renames the levels for display, e.g. to a crew's own names.
+// Thresholds don't change. A missing or blank entry keeps the default
+// name, and nil restores them all.
+func SetNames(names []string) {
+	customNames = names
+}
+
+// named returns l with its custom name, if one is set
+func named(l Level) Level {
+	if i := l.Number - 1; i >= 0 && i < len(customNames) && customNames[i] != "" {
+		l.Name = customNames[i]
+	}
+	return l
+}
+
 // GetLevel returns the level for a given XP amount
 func GetLevel(xp int) Level {
 	level := Levels[0]
@@ -31,15 +50,15 @@ func GetLevel(xp int) Level {
 			break
 		}
 	}
-	return level
+	return named(level)
 }
 
 // GetLevelByNumber returns the level by its number
 func GetLevelByNumber(num int) Level {
 	if num < 1 || num > len(Levels) {
-		return Levels[0]
+		return named(Levels[0])
 	}
-	return Levels[num-1]
+	return named(Levels[num-1])
 }
 
 // GetNextLevel returns the next level after the current one
@@ -47,7 +66,8 @@ func GetNextLevel(current Level) *Level {
 	if current.Number >= len(Levels) {
 		return nil
 	}
-	return &Levels[current.Number]
+	next := named(Levels[current.Number])
+	return &next
 }
 
 // XPToNextLevel returns XP needed to reach the next level
@@ -74,3 +94,38 @@ func LevelProgress(currentXP int) float64 {
 	}
 	return float64(levelXP) / float64(levelRange)
 }
+
+// XPForLevel returns the total XP needed to reach level n. Numbers below
+// the first level return 0 and numbers past the last return the top
+// level's requirement.
+func XPForLevel(n int) int {
+	if n < 1 {
+		return 0
+	}
+	if n > len(Levels) {
+		n = len(Levels)
+	}
+	return Levels[n-1].MinXP
+}
+
+// MaxLevel returns the highest level
+func MaxLevel() Level {
+	return named(Levels[len(Levels)-1])
+}
+
+// OverallProgress returns progress (0.0-1.0) from zero XP to the top level
+func OverallProgress(xp int) float64 {
+	top := MaxLevel().MinXP
+	if top <= 0 || xp >= top {
+		return 1.0
+	}
+	if xp <= 0 {
+		return 0.0
+	}
+	return float64(xp) / float64(top)
+}
+
+// LevelsRemaining returns how many levels are left before the top level
+func LevelsRemaining(xp int) int {
+	return MaxLevel().Number - GetLevel(xp).Number
+}
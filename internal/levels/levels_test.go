@@ -0,0 +1,89 @@
+package levels
+
+import "testing"
+
+func TestXPForLevel(t *testing.T) {
+	top := Levels[len(Levels)-1].MinXP
+	tests := []struct {
+		n    int
+		want int
+	}{
+		{-1, 0},
+		{0, 0},
+		{1, 0},
+		{2, 100},
+		{5, 1000},
+		{len(Levels), top},
+		{len(Levels) + 1, top},
+		{100, top},
+	}
+	for _, tt := range tests {
+		if got := XPForLevel(tt.n); got != tt.want {
+			t.Errorf("XPForLevel(%d) = %d, want %d", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestOverallProgress(t *testing.T) {
+	top := MaxLevel().MinXP
+	tests := []struct {
+		xp   int
+		want float64
+	}{
+		{-50, 0},
+		{0, 0},
+		{top / 2, 0.5},
+		{top, 1},
+		{top * 3, 1},
+	}
+	for _, tt := range tests {
+		if got := OverallProgress(tt.xp); got != tt.want {
+			t.Errorf("OverallProgress(%d) = %v, want %v", tt.xp, got, tt.want)
+		}
+	}
+}
+
+func TestLevelsRemaining(t *testing.T) {
+	top := MaxLevel().MinXP
+	tests := []struct {
+		xp   int
+		want int
+	}{
+		{0, len(Levels) - 1},
+		{99, len(Levels) - 1},
+		{100, len(Levels) - 2},
+		{top - 1, 1},
+		{top, 0},
+		{top * 2, 0},
+	}
+	for _, tt := range tests {
+		if got := LevelsRemaining(tt.xp); got != tt.want {
+			t.Errorf("LevelsRemaining(%d) = %d, want %d", tt.xp, got, tt.want)
+		}
+	}
+}
+
+func TestCustomNames(t *testing.T) {
+	names := make([]string, len(Levels))
+	names[0] = "Rookie"
+	names[len(Levels)-1] = "Legend"
+	SetNames(names)
+	defer SetNames(nil)
+
+	tests := []struct {
+		name string
+		got  Level
+		want string
+	}{
+		{"GetLevel", GetLevel(0), "Rookie"},
+		{"GetLevelByNumber out of range", GetLevelByNumber(0), "Rookie"},
+		{"blank keeps default", GetLevelByNumber(2), Levels[1].Name},
+		{"MaxLevel", MaxLevel(), "Legend"},
+		{"GetNextLevel", *GetNextLevel(GetLevelByNumber(len(Levels) - 1)), "Legend"},
+	}
+	for _, tt := range tests {
+		if tt.got.Name != tt.want {
+			t.Errorf("%s: name = %q, want %q", tt.name, tt.got.Name, tt.want)
+		}
+	}
+}
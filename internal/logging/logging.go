@@ -0,0 +1,136 @@
+// Package logging writes optional structured debug logs to a file.
+//
+// Logging is off unless Open is called. While off, every function returns
+// immediately; callers building expensive attributes should check Enabled
+// first so nothing is allocated.
+package logging
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// MaxSize is how large the log grows before it is rotated to <path>.1
+const MaxSize = 5 << 20 // 5 MB
+
+var (
+	logger *slog.Logger
+	file   *rotatingFile
+)
+
+// Open starts logging to path, creating its directory if needed
+func Open(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	f, err := openRotating(path, MaxSize)
+	if err != nil {
+		return err
+	}
+	file = f
+	logger = slog.New(slog.NewTextHandler(f, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	return nil
+}
+
+// Close flushes and closes the log file, turning logging off
+func Close() error {
+	if file == nil {
+		return nil
+	}
+	err := file.Close()
+	logger, file = nil, nil
+	return err
+}
+
+// Enabled reports whether logging is on
+func Enabled() bool {
+	return logger != nil
+}
+
+// Debug logs a debug-level message with key/value attributes
+func Debug(msg string, args ...any) {
+	if logger != nil {
+		logger.Debug(msg, args...)
+	}
+}
+
+// Info logs an info-level message with key/value attributes
+func Info(msg string, args ...any) {
+	if logger != nil {
+		logger.Info(msg, args...)
+	}
+}
+
+// Error logs an error-level message with key/value attributes
+func Error(msg string, args ...any) {
+	if logger != nil {
+		logger.Error(msg, args...)
+	}
+}
+
+// rotatingFile is an append-only file that moves itself to <path>.1 and
+// starts over once it passes maxSize, so the log never grows unbounded
+type rotatingFile struct {
+	mu      sync.Mutex
+	path    string
+	maxSize int64
+	f       *os.File
+	size    int64
+}
+
+func openRotating(path string, maxSize int64) (*rotatingFile, error) {
+	r := &rotatingFile{path: path, maxSize: maxSize}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *rotatingFile) open() error {
+	f, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	r.f = f
+	r.size = info.Size()
+	return nil
+}
+
+func (r *rotatingFile) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.size+int64(len(p)) > r.maxSize && r.size > 0 {
+		if err := r.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := r.f.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+// rotate replaces any previous backup with the current file and reopens
+func (r *rotatingFile) rotate() error {
+	if err := r.f.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(r.path, r.path+".1"); err != nil {
+		return fmt.Errorf("rotate log: %w", err)
+	}
+	return r.open()
+}
+
+func (r *rotatingFile) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.f.Close()
+}
@@ -0,0 +1,130 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"grind/internal/api"
+)
+
+// Backend is an api.ConvexAPI that answers the quest and user functions
+// from the local store, so commands run unchanged in local mode through
+// api.NewClientFrom. Anything that needs a crew or the AI fails with
+// ErrLocalOnly.
+type Backend struct {
+	// Location decides where "today" starts, like the backend's day
+	Location *time.Location
+}
+
+var _ api.ConvexAPI = Backend{}
+
+// ErrLocalOnly is returned for functions that need a backend
+var ErrLocalOnly = fmt.Errorf("not available in local mode")
+
+// now returns the current time in the backend's location
+func (b Backend) now() time.Time {
+	if b.Location == nil {
+		return time.Now()
+	}
+	return time.Now().In(b.Location)
+}
+
+// Query answers the read-only functions from the stored data
+func (b Backend) Query(ctx context.Context, path string, args map[string]any) (any, error) {
+	data, err := Load()
+	if err != nil {
+		return nil, err
+	}
+
+	switch path {
+	case "quests:listToday":
+		return data.Today(b.now()), nil
+	case "quests:list":
+		since, _ := args["since"].(int64)
+		limit, _ := args["limit"].(int)
+		status, _ := args["status"].(string)
+		return data.List(since, limit, status), nil
+	case "quests:get":
+		id, _ := args["questId"].(string)
+		return data.Quest(id), nil
+	case "users:get":
+		me := data.Me(b.now())
+		return &me, nil
+	case "activity:getUserActivity":
+		limit, _ := args["limit"].(int)
+		return data.Recent(limit), nil
+	}
+	return nil, fmt.Errorf("%s: %w", path, ErrLocalOnly)
+}
+
+// Mutation applies quest and profile changes to the stored data
+func (b Backend) Mutation(ctx context.Context, path string, args map[string]any) (any, error) {
+	id, _ := args["questId"].(string)
+	now := b.now()
+
+	var result any
+	err := Update(func(data *Data) error {
+		var err error
+		switch path {
+		case "quests:create":
+			title, _ := args["title"].(string)
+			notes, _ := args["notes"].(string)
+			xp, _ := args["xp"].(int)
+			reasoning, _ := args["aiReasoning"].(string)
+			key, _ := args["idempotencyKey"].(string)
+			subtasks, _ := args["subtasks"].([]string)
+			quest := data.QuestByKey(key)
+			if quest == nil {
+				quest = data.Quest(data.CreateQuest(title, notes, xp, reasoning, now).ID)
+				quest.IdempotencyKey = key
+				for _, s := range subtasks {
+					quest.Subtasks = append(quest.Subtasks, api.SubTask{Title: s})
+				}
+			}
+			result = map[string]any{"questId": quest.ID, "xp": quest.XP, "aiReasoning": quest.AIReasoning}
+		case "quests:start":
+			err = data.StartQuest(id, now)
+		case "quests:complete":
+			result, err = data.CompleteQuest(id, now)
+		case "quests:addSubtask":
+			title, _ := args["title"].(string)
+			err = data.AddSubtask(id, title)
+		case "quests:completeSubtask":
+			index, _ := args["index"].(int)
+			result, err = data.CompleteSubtask(id, index, now)
+		case "quests:abandon":
+			err = data.AbandonQuest(id, now)
+		case "quests:snooze":
+			err = data.SnoozeQuest(id, now)
+		case "quests:update":
+			var update api.QuestUpdate
+			if title, ok := args["title"].(string); ok {
+				update.Title = &title
+			}
+			if notes, ok := args["notes"].(string); ok {
+				update.Notes = &notes
+			}
+			if xp, ok := args["xp"].(int); ok {
+				update.XP = &xp
+			}
+			err = data.UpdateQuest(id, update)
+		case "users:update":
+			if name, ok := args["name"].(string); ok {
+				data.User.Name = name
+			}
+		case "quests:reorder":
+			ids, _ := args["questIds"].([]string)
+			err = data.ReorderQuests(ids)
+		default:
+			err = fmt.Errorf("%s: %w", path, ErrLocalOnly)
+		}
+		return err
+	})
+	return result, err
+}
+
+// Action always fails: actions reach out to the AI or other services
+func (b Backend) Action(ctx context.Context, path string, args map[string]any) (any, error) {
+	return nil, fmt.Errorf("%s: %w", path, ErrLocalOnly)
+}
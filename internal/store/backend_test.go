@@ -0,0 +1,66 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"grind/internal/api"
+)
+
+// TestBackend drives the store through an api.Client, the way the
+// dashboard and commands do in local mode
+func TestBackend(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	if err := Save(&Data{User: api.User{ID: "u1", Name: "ada"}}); err != nil {
+		t.Fatal(err)
+	}
+	client := api.ClientFor(Backend{Location: time.UTC})
+	ctx := context.Background()
+
+	create := map[string]any{"userId": "u1", "title": "ship it", "xp": 40, "idempotencyKey": "k1", "subtasks": []string{"build", "test"}}
+	first, err := client.Mutation(ctx, "quests:create", create)
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := client.Mutation(ctx, "quests:create", create)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, _ := first.(map[string]any)["questId"].(string)
+	if againID, _ := again.(map[string]any)["questId"].(string); id == "" || againID != id {
+		t.Fatalf("resent create made %q, first %q", againID, id)
+	}
+
+	if err := client.StartQuest(ctx, id); err != nil {
+		t.Fatal(err)
+	}
+	res, err := client.CompleteSubtask(ctx, id, 1)
+	if err != nil || res.XPEarned != 10 {
+		t.Fatalf("CompleteSubtask = %+v, %v", res, err)
+	}
+	res, err = client.CompleteQuest(ctx, id)
+	if err != nil || res.XPEarned != 30 || res.NewTotalXP != 40 {
+		t.Fatalf("CompleteQuest = %+v, %v", res, err)
+	}
+	if _, err := client.CompleteQuest(ctx, id); err == nil {
+		t.Error("completing twice succeeded")
+	}
+
+	quests, err := api.QueryAs[[]api.Quest](ctx, client, "quests:listToday", map[string]any{"userId": "u1"})
+	if err != nil || len(quests) != 1 {
+		t.Fatalf("listToday = %+v, %v", quests, err)
+	}
+	if q := quests[0]; q.Status != "completed" || len(q.Subtasks) != 2 || !q.Subtasks[1].Done || q.SubtaskXP != 10 {
+		t.Errorf("quest = %+v", q)
+	}
+	user, err := client.GetUser(ctx, "u1")
+	if err != nil || user == nil || user.TotalXP != 40 || user.Name != "ada" {
+		t.Errorf("GetUser = %+v, %v", user, err)
+	}
+
+	if _, err := client.Query(ctx, "leaderboard:weekly", nil); !errors.Is(err, ErrLocalOnly) {
+		t.Errorf("crew query: err = %v, want ErrLocalOnly", err)
+	}
+}
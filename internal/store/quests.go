@@ -0,0 +1,361 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"grind/internal/api"
+	"grind/internal/goals"
+	"grind/internal/levels"
+)
+
+// maxActivity caps the stored feed; older items are dropped
+const maxActivity = 200
+
+// ErrQuestNotFound is returned for a quest ID the store doesn't have
+var ErrQuestNotFound = errors.New("quest not found")
+
+// startOfDay returns midnight of the day containing now, in now's zone
+func startOfDay(now time.Time) time.Time {
+	y, m, d := now.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, now.Location())
+}
+
+// Today returns what quests:listToday would: quests created today, plus
+// snoozed ones whose snooze ran out today, in the user's order. now's
+// location decides where the day starts.
+func (d *Data) Today(now time.Time) []api.Quest {
+	start := startOfDay(now).UnixMilli()
+	nowMs := now.UnixMilli()
+
+	quests := []api.Quest{}
+	for _, q := range d.Quests {
+		if q.SnoozedUntil != 0 {
+			if q.SnoozedUntil <= nowMs && q.SnoozedUntil >= start {
+				quests = append(quests, q)
+			}
+			continue
+		}
+		if q.CreatedAt >= start {
+			quests = append(quests, q)
+		}
+	}
+
+	// Ordered quests first, then the rest in creation order, like byOrder
+	sort.SliceStable(quests, func(i, j int) bool {
+		a, b := quests[i], quests[j]
+		if (a.Order != 0) != (b.Order != 0) {
+			return a.Order != 0
+		}
+		if a.Order != b.Order {
+			return a.Order < b.Order
+		}
+		return a.CreatedAt < b.CreatedAt
+	})
+	return quests
+}
+
+// All returns every quest, newest first, like quests:list
+func (d *Data) All() []api.Quest {
+	quests := append([]api.Quest{}, d.Quests...)
+	sort.SliceStable(quests, func(i, j int) bool {
+		return quests[i].CreatedAt > quests[j].CreatedAt
+	})
+	return quests
+}
+
+// List returns quests created at or after since (Unix ms) in status,
+// newest first, at most limit of them, like quests:list. Zero values mean
+// no bound.
+func (d *Data) List(since int64, limit int, status string) []api.Quest {
+	var quests []api.Quest
+	for _, q := range d.All() {
+		if q.CreatedAt < since {
+			break
+		}
+		if limit > 0 && len(quests) == limit {
+			break
+		}
+		if status != "" && q.Status != status {
+			continue
+		}
+		quests = append(quests, q)
+	}
+	return quests
+}
+
+// Quest returns the quest with id, or nil
+func (d *Data) Quest(id string) *api.Quest {
+	for i := range d.Quests {
+		if d.Quests[i].ID == id {
+			return &d.Quests[i]
+		}
+	}
+	return nil
+}
+
+// Me returns the guest user with this week's XP worked out from the
+// quests completed since Monday, so it resets like the backend's
+func (d *Data) Me(now time.Time) api.User {
+	user := d.User
+	user.WeeklyXP = d.weeklyXP(now)
+	user.Level = levels.GetLevel(user.TotalXP).Number
+	return user
+}
+
+// weeklyXP sums the XP earned this week
+func (d *Data) weeklyXP(now time.Time) int {
+	return d.xpEarnedSince(goals.WeekStart(now).UnixMilli())
+}
+
+// xpEarnedSince sums the XP credited at or after since (Unix ms), like the
+// backend's xpEarnedToday: each sub-task payout counts when it was paid, and
+// a completion counts the rest. Payouts of abandoned quests are kept.
+func (d *Data) xpEarnedSince(since int64) int {
+	total := 0
+	for _, q := range d.Quests {
+		paid := 0
+		for _, p := range q.SubtaskPayouts {
+			paid += p.XP
+			if p.At >= since {
+				total += p.XP
+			}
+		}
+		if q.Status == "completed" && q.CompletedAt >= since {
+			total += max(q.XP-paid, 0)
+		}
+	}
+	return total
+}
+
+// QuestByKey returns the quest created with idempotency key key, or nil
+func (d *Data) QuestByKey(key string) *api.Quest {
+	if key == "" {
+		return nil
+	}
+	for i := range d.Quests {
+		if d.Quests[i].IdempotencyKey == key {
+			return &d.Quests[i]
+		}
+	}
+	return nil
+}
+
+// CreateQuest adds a pending quest, like quests:create
+func (d *Data) CreateQuest(title, notes string, xp int, reasoning string, now time.Time) api.Quest {
+	quest := api.Quest{
+		ID:          fmt.Sprintf("quest_%d", now.UnixNano()),
+		UserID:      d.User.ID,
+		Title:       title,
+		Notes:       notes,
+		XP:          xp,
+		AIReasoning: reasoning,
+		Status:      "pending",
+		CreatedAt:   now.UnixMilli(),
+	}
+	d.Quests = append(d.Quests, quest)
+	d.log(api.Activity{Type: "quest_created", QuestTitle: title, XP: xp}, now)
+	return quest
+}
+
+// StartQuest moves a pending quest to in progress, like quests:start
+func (d *Data) StartQuest(id string, now time.Time) error {
+	quest := d.Quest(id)
+	if quest == nil {
+		return ErrQuestNotFound
+	}
+	if quest.Status != "pending" {
+		return fmt.Errorf("quest must be pending to start")
+	}
+	quest.Status = "in_progress"
+	d.log(api.Activity{Type: "quest_started", QuestTitle: quest.Title}, now)
+	return nil
+}
+
+// CompleteQuest completes a quest and awards its XP, like quests:complete
+func (d *Data) CompleteQuest(id string, now time.Time) (*api.CompleteResult, error) {
+	quest := d.Quest(id)
+	if quest == nil {
+		return nil, ErrQuestNotFound
+	}
+	switch quest.Status {
+	case "completed":
+		return nil, fmt.Errorf("quest already completed")
+	case "abandoned":
+		return nil, fmt.Errorf("cannot complete abandoned quest")
+	}
+
+	quest.Status = "completed"
+	quest.CompletedAt = now.UnixMilli()
+
+	// Finished sub-tasks already paid part of it
+	xp := quest.RemainingXP()
+	result := d.award(xp, now)
+	d.log(api.Activity{Type: "quest_completed", QuestTitle: quest.Title, XP: xp}, now)
+	if result.LeveledUp {
+		d.log(api.Activity{Type: "level_up", NewLevel: d.User.Level}, now)
+	}
+	return result, nil
+}
+
+// AddSubtask appends a step to an open quest, like quests:addSubtask
+func (d *Data) AddSubtask(id, title string) error {
+	quest := d.Quest(id)
+	if quest == nil {
+		return ErrQuestNotFound
+	}
+	if !quest.IsOpen() {
+		return fmt.Errorf("cannot add sub-tasks to %s quest", quest.Status)
+	}
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return fmt.Errorf("sub-task can't be blank")
+	}
+	quest.Subtasks = append(quest.Subtasks, api.SubTask{Title: title})
+	return nil
+}
+
+// CompleteSubtask ticks off one of an open quest's sub-tasks and pays its
+// share of the quest's XP, like quests:completeSubtask
+func (d *Data) CompleteSubtask(id string, index int, now time.Time) (*api.CompleteResult, error) {
+	quest := d.Quest(id)
+	if quest == nil {
+		return nil, ErrQuestNotFound
+	}
+	if !quest.IsOpen() {
+		return nil, fmt.Errorf("quest already %s", quest.Status)
+	}
+	if index < 0 || index >= len(quest.Subtasks) {
+		return nil, fmt.Errorf("sub-task not found")
+	}
+	if quest.Subtasks[index].Done {
+		return nil, fmt.Errorf("sub-task already done")
+	}
+
+	xp := min(api.SubtaskShare(quest.XP, len(quest.Subtasks)), quest.RemainingXP())
+	quest.Subtasks[index].Done = true
+	quest.SubtaskXP += xp
+	quest.SubtaskPayouts = append(quest.SubtaskPayouts, api.Payout{At: now.UnixMilli(), XP: xp})
+	result := d.award(xp, now)
+	if result.LeveledUp {
+		d.log(api.Activity{Type: "level_up", NewLevel: d.User.Level}, now)
+	}
+	return result, nil
+}
+
+// award credits xp to the guest user. There are no XP events or crew caps
+// offline, so all of it is earned.
+func (d *Data) award(xp int, now time.Time) *api.CompleteResult {
+	oldLevel := levels.GetLevel(d.User.TotalXP).Number
+	d.User.TotalXP += xp
+	d.User.Level = levels.GetLevel(d.User.TotalXP).Number
+	d.User.LastActiveAt = now.UnixMilli()
+
+	return &api.CompleteResult{
+		XPEarned:    xp,
+		Multiplier:  1,
+		NewTotalXP:  d.User.TotalXP,
+		NewWeeklyXP: d.weeklyXP(now),
+		LeveledUp:   d.User.Level > oldLevel,
+		NewLevel:    d.User.Level,
+	}
+}
+
+// AbandonQuest drops an unfinished quest, like quests:abandon. XP its
+// sub-tasks already paid is kept.
+func (d *Data) AbandonQuest(id string, now time.Time) error {
+	quest := d.Quest(id)
+	if quest == nil {
+		return ErrQuestNotFound
+	}
+	switch quest.Status {
+	case "completed":
+		return fmt.Errorf("cannot abandon completed quest")
+	case "abandoned":
+		return fmt.Errorf("quest already abandoned")
+	}
+	quest.Status = "abandoned"
+	quest.AbandonedAt = now.UnixMilli()
+	d.log(api.Activity{Type: "quest_abandoned", QuestTitle: quest.Title}, now)
+	return nil
+}
+
+// SnoozeQuest hides an unfinished quest until tomorrow, like quests:snooze
+func (d *Data) SnoozeQuest(id string, now time.Time) error {
+	quest := d.Quest(id)
+	if quest == nil {
+		return ErrQuestNotFound
+	}
+	if !quest.IsOpen() {
+		return fmt.Errorf("cannot snooze %s quest", quest.Status)
+	}
+	quest.SnoozedUntil = startOfDay(now).AddDate(0, 0, 1).UnixMilli()
+	return nil
+}
+
+// UpdateQuest edits a quest, like quests:update. Setting XP marks it
+// manually set.
+func (d *Data) UpdateQuest(id string, update api.QuestUpdate) error {
+	quest := d.Quest(id)
+	if quest == nil {
+		return ErrQuestNotFound
+	}
+	if update.XP != nil {
+		if !quest.IsOpen() {
+			return fmt.Errorf("cannot change XP of %s quest", quest.Status)
+		}
+		if err := api.ValidateQuestXP(*update.XP); err != nil {
+			return err
+		}
+	}
+
+	if update.Title != nil {
+		quest.Title = *update.Title
+	}
+	if update.Notes != nil {
+		quest.Notes = *update.Notes
+	}
+	if update.XP != nil {
+		quest.XP = *update.XP
+		quest.AIReasoning = api.ManualXPReasoning
+	}
+	return nil
+}
+
+// ReorderQuests saves a new display order, like quests:reorder. ids is
+// today's list, top first.
+func (d *Data) ReorderQuests(ids []string) error {
+	for i, id := range ids {
+		quest := d.Quest(id)
+		if quest == nil {
+			return ErrQuestNotFound
+		}
+		quest.Order = i + 1
+	}
+	return nil
+}
+
+// Recent returns up to limit activity items, newest first
+func (d *Data) Recent(limit int) []api.Activity {
+	items := []api.Activity{}
+	for i := len(d.Activity) - 1; i >= 0 && len(items) < limit; i-- {
+		items = append(items, d.Activity[i])
+	}
+	return items
+}
+
+// log records an activity item from the guest, trimming the oldest past
+// maxActivity
+func (d *Data) log(item api.Activity, now time.Time) {
+	item.ID = fmt.Sprintf("activity_%d_%d", now.UnixNano(), len(d.Activity))
+	item.UserID = d.User.ID
+	item.UserName = d.User.Name
+	item.CreatedAt = now.UnixMilli()
+	d.Activity = append(d.Activity, item)
+	if len(d.Activity) > maxActivity {
+		d.Activity = d.Activity[len(d.Activity)-maxActivity:]
+	}
+}
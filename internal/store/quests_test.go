@@ -0,0 +1,255 @@
+package store
+
+import (
+	"testing"
+	"time"
+
+	"grind/internal/api"
+)
+
+// noon is a fixed "now" away from midnight, so day boundaries are obvious
+var noon = time.Date(2026, 3, 11, 12, 0, 0, 0, time.UTC)
+
+// dataWith returns data holding one quest in status, with subtasks
+// sub-tasks and XP xp
+func dataWith(status string, xp, subtasks int) (*Data, string) {
+	d := &Data{User: api.User{ID: "u1", Name: "ada"}}
+	quest := d.CreateQuest("ship it", "", xp, "", noon.Add(-time.Hour))
+	q := d.Quest(quest.ID)
+	q.Status = status
+	for range subtasks {
+		q.Subtasks = append(q.Subtasks, api.SubTask{Title: "step"})
+	}
+	return d, quest.ID
+}
+
+func TestCreateQuest(t *testing.T) {
+	d := &Data{User: api.User{ID: "u1", Name: "ada"}}
+	quest := d.CreateQuest("write docs", "the README", 40, "docs work", noon)
+	if quest.Status != "pending" || quest.UserID != "u1" || quest.XP != 40 || quest.CreatedAt != noon.UnixMilli() {
+		t.Errorf("quest = %+v", quest)
+	}
+	if got := d.Quest(quest.ID); got == nil || got.Title != "write docs" {
+		t.Errorf("stored quest = %+v", got)
+	}
+	if len(d.Activity) != 1 || d.Activity[0].Type != "quest_created" || d.Activity[0].UserName != "ada" {
+		t.Errorf("activity = %+v", d.Activity)
+	}
+}
+
+func TestStartQuest(t *testing.T) {
+	tests := []struct {
+		status string
+		ok     bool
+	}{
+		{"pending", true},
+		{"in_progress", false},
+		{"completed", false},
+		{"abandoned", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			d, id := dataWith(tt.status, 40, 0)
+			err := d.StartQuest(id, noon)
+			if (err == nil) != tt.ok {
+				t.Fatalf("StartQuest from %s: err = %v", tt.status, err)
+			}
+			if tt.ok && d.Quest(id).Status != "in_progress" {
+				t.Errorf("status = %s", d.Quest(id).Status)
+			}
+		})
+	}
+	if err := (&Data{}).StartQuest("nope", noon); err != ErrQuestNotFound {
+		t.Errorf("unknown quest: err = %v", err)
+	}
+}
+
+func TestCompleteQuest(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    string
+		subtaskXP int
+		xpEarned  int
+		ok        bool
+	}{
+		{"pending", "pending", 0, 40, true},
+		{"in progress", "in_progress", 0, 40, true},
+		{"sub-tasks paid part", "in_progress", 15, 25, true},
+		{"already completed", "completed", 0, 0, false},
+		{"abandoned", "abandoned", 0, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, id := dataWith(tt.status, 40, 0)
+			d.Quest(id).SubtaskXP = tt.subtaskXP
+			res, err := d.CompleteQuest(id, noon)
+			if (err == nil) != tt.ok {
+				t.Fatalf("CompleteQuest: err = %v", err)
+			}
+			if !tt.ok {
+				if d.User.TotalXP != 0 {
+					t.Errorf("failed completion awarded %d XP", d.User.TotalXP)
+				}
+				return
+			}
+			q := d.Quest(id)
+			if q.Status != "completed" || q.CompletedAt != noon.UnixMilli() {
+				t.Errorf("quest = %+v", q)
+			}
+			if res.XPEarned != tt.xpEarned || d.User.TotalXP != tt.xpEarned || res.NewTotalXP != tt.xpEarned {
+				t.Errorf("earned %d, total %d, want %d", res.XPEarned, d.User.TotalXP, tt.xpEarned)
+			}
+		})
+	}
+}
+
+func TestCompleteSubtask(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   string
+		xp       int
+		subtasks int
+		done     []int // Sub-tasks already ticked off, each paying its share
+		index    int
+		xpEarned int
+		ok       bool
+	}{
+		{"first of two", "pending", 40, 2, nil, 0, 10, true},
+		{"second of two", "in_progress", 40, 2, []int{0}, 1, 10, true},
+		{"share rounds down", "in_progress", 25, 3, nil, 2, 4, true},
+		{"already done", "in_progress", 40, 2, []int{1}, 1, 0, false},
+		{"out of range", "in_progress", 40, 2, nil, 2, 0, false},
+		{"negative index", "in_progress", 40, 2, nil, -1, 0, false},
+		{"quest completed", "completed", 40, 2, nil, 0, 0, false},
+		{"quest abandoned", "abandoned", 40, 2, nil, 0, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, id := dataWith(tt.status, tt.xp, tt.subtasks)
+			for _, i := range tt.done {
+				d.Quest(id).Subtasks[i].Done = true
+				d.Quest(id).SubtaskXP += api.SubtaskShare(tt.xp, tt.subtasks)
+			}
+			paid := d.Quest(id).SubtaskXP
+
+			res, err := d.CompleteSubtask(id, tt.index, noon)
+			if (err == nil) != tt.ok {
+				t.Fatalf("CompleteSubtask: err = %v", err)
+			}
+			q := d.Quest(id)
+			if !tt.ok {
+				if q.SubtaskXP != paid || d.User.TotalXP != 0 {
+					t.Errorf("failed sub-task paid out: subtaskXP %d, total %d", q.SubtaskXP, d.User.TotalXP)
+				}
+				return
+			}
+			if !q.Subtasks[tt.index].Done || q.SubtaskXP != paid+tt.xpEarned {
+				t.Errorf("quest = %+v", q)
+			}
+			if res.XPEarned != tt.xpEarned || d.User.TotalXP != tt.xpEarned {
+				t.Errorf("earned %d, total %d, want %d", res.XPEarned, d.User.TotalXP, tt.xpEarned)
+			}
+
+			// Completing the quest pays only what the sub-tasks didn't
+			res, err = d.CompleteQuest(id, noon)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if d.User.TotalXP != tt.xp-paid {
+				t.Errorf("total after completing = %d, want %d (%d earned)", d.User.TotalXP, tt.xp-paid, res.XPEarned)
+			}
+		})
+	}
+}
+
+func TestToday(t *testing.T) {
+	startOfToday := startOfDay(noon)
+	tomorrow := startOfToday.AddDate(0, 0, 1).UnixMilli()
+	tests := []struct {
+		name    string
+		quest   api.Quest
+		visible bool
+	}{
+		{"created today", api.Quest{CreatedAt: noon.Add(-time.Hour).UnixMilli()}, true},
+		{"created at midnight", api.Quest{CreatedAt: startOfToday.UnixMilli()}, true},
+		{"created yesterday", api.Quest{CreatedAt: startOfToday.Add(-time.Minute).UnixMilli()}, false},
+		{"snoozed until tomorrow", api.Quest{CreatedAt: noon.Add(-time.Hour).UnixMilli(), SnoozedUntil: tomorrow}, false},
+		{"snooze ran out today", api.Quest{CreatedAt: noon.AddDate(0, 0, -3).UnixMilli(), SnoozedUntil: startOfToday.UnixMilli()}, true},
+		{"snooze ran out yesterday", api.Quest{CreatedAt: noon.AddDate(0, 0, -3).UnixMilli(), SnoozedUntil: startOfToday.AddDate(0, 0, -1).UnixMilli()}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.quest.ID = "q1"
+			d := &Data{Quests: []api.Quest{tt.quest}}
+			if got := len(d.Today(noon)) == 1; got != tt.visible {
+				t.Errorf("listed = %v, want %v", got, tt.visible)
+			}
+		})
+	}
+}
+
+func TestTodayOrder(t *testing.T) {
+	at := func(minutes int) int64 { return noon.Add(time.Duration(minutes) * time.Minute).UnixMilli() }
+	d := &Data{Quests: []api.Quest{
+		{ID: "unordered late", CreatedAt: at(-10)},
+		{ID: "second", CreatedAt: at(-50), Order: 2},
+		{ID: "unordered early", CreatedAt: at(-40)},
+		{ID: "first", CreatedAt: at(-20), Order: 1},
+	}}
+	var ids []string
+	for _, q := range d.Today(noon) {
+		ids = append(ids, q.ID)
+	}
+	want := []string{"first", "second", "unordered early", "unordered late"}
+	if len(ids) != len(want) {
+		t.Fatalf("Today = %v, want %v", ids, want)
+	}
+	for i := range want {
+		if ids[i] != want[i] {
+			t.Fatalf("Today = %v, want %v", ids, want)
+		}
+	}
+}
+
+func TestXPEarnedSince(t *testing.T) {
+	today := startOfDay(noon)
+	yesterday := today.Add(-12 * time.Hour).UnixMilli()
+	paid := func(at int64, xp int) []api.Payout { return []api.Payout{{At: at, XP: xp}} }
+	tests := []struct {
+		name  string
+		quest api.Quest
+		want  int
+	}{
+		{"completed today", api.Quest{XP: 40, Status: "completed", CompletedAt: noon.UnixMilli()}, 40},
+		{"completed yesterday", api.Quest{XP: 40, Status: "completed", CompletedAt: yesterday}, 0},
+		{"sub-task paid yesterday, completed today", api.Quest{XP: 40, Status: "completed", CompletedAt: noon.UnixMilli(), SubtaskXP: 10, SubtaskPayouts: paid(yesterday, 10)}, 30},
+		{"sub-task paid today, still open", api.Quest{XP: 40, Status: "in_progress", SubtaskXP: 10, SubtaskPayouts: paid(noon.UnixMilli(), 10)}, 10},
+		{"sub-task paid yesterday, still open", api.Quest{XP: 40, Status: "in_progress", SubtaskXP: 10, SubtaskPayouts: paid(yesterday, 10)}, 0},
+		{"abandoned keeps today's payout", api.Quest{XP: 40, Status: "abandoned", SubtaskXP: 10, SubtaskPayouts: paid(noon.UnixMilli(), 10)}, 10},
+		{"paid before payouts were recorded", api.Quest{XP: 40, Status: "completed", CompletedAt: noon.UnixMilli(), SubtaskXP: 10}, 40},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &Data{Quests: []api.Quest{tt.quest}}
+			if got := d.xpEarnedSince(today.UnixMilli()); got != tt.want {
+				t.Errorf("xpEarnedSince = %d, want %d", got, tt.want)
+			}
+		})
+	}
+
+	// A sub-task ticked off yesterday and the completion today split the
+	// quest's XP between the two days
+	d, id := dataWith("in_progress", 40, 2)
+	if _, err := d.CompleteSubtask(id, 0, noon.AddDate(0, 0, -1)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.CompleteQuest(id, noon); err != nil {
+		t.Fatal(err)
+	}
+	if got := d.xpEarnedSince(today.UnixMilli()); got != 30 {
+		t.Errorf("earned today = %d, want 30", got)
+	}
+	if got := d.xpEarnedSince(today.AddDate(0, 0, -1).UnixMilli()); got != 40 || d.User.TotalXP != 40 {
+		t.Errorf("earned since yesterday = %d, total %d, want 40", got, d.User.TotalXP)
+	}
+}
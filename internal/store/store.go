@@ -0,0 +1,89 @@
+// Package store keeps local mode's quests, activity and XP on disk, in
+// data.json next to the config, for guest accounts that never talk to the
+// backend. Its operations mirror the Convex mutations they stand in for.
+package store
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"grind/internal/api"
+	"grind/internal/auth"
+)
+
+// Data is everything local mode persists, in the same shapes the backend
+// returns
+type Data struct {
+	User     api.User       `json:"user"`
+	Quests   []api.Quest    `json:"quests"`
+	Activity []api.Activity `json:"activity,omitempty"`
+}
+
+// mu serializes Update, since the dashboard saves from several commands
+// at once
+var mu sync.Mutex
+
+// Load reads the local data. No file yet means no quests.
+func Load() (*Data, error) {
+	path, err := auth.DataPath()
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &Data{}, nil
+		}
+		return nil, err
+	}
+
+	var data Data
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// Save writes the local data
+func Save(data *Data) error {
+	path, err := auth.DataPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+
+	raw, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// Same temp-file-and-rename as the config, so a crash mid-write can't
+	// truncate everything the guest has logged
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, raw, 0600); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// Update loads the data, applies fn and saves the result. Nothing is
+// written if fn fails.
+func Update(fn func(*Data) error) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	data, err := Load()
+	if err != nil {
+		return err
+	}
+	if err := fn(data); err != nil {
+		return err
+	}
+	return Save(data)
+}
@@ -0,0 +1,84 @@
+// Package streaks computes daily completion streaks with freezes, which
+// forgive a limited number of missed days each week.
+package streaks
+
+import (
+	"fmt"
+	"time"
+
+	"grind/internal/goals"
+)
+
+// DefaultFreezesPerWeek is how many missed days are forgiven per week
+// unless configured otherwise
+const DefaultFreezesPerWeek = 1
+
+// MaxFreezesPerWeek keeps freezes from making streaks meaningless
+const MaxFreezesPerWeek = 3
+
+// WindowDays is how many days of history the backend sends, which caps how
+// long a streak can be measured
+const WindowDays = 60
+
+// Streak is the current run of active days ending today or yesterday
+type Streak struct {
+	Days        int  // Days with at least one completed quest
+	FreezesUsed int  // Missed days forgiven inside the streak
+	Frozen      bool // A freeze was used this week
+	FreezesLeft int  // Freezes still available this week
+}
+
+// Compute builds the streak from quest completion times (Unix ms), bucketed
+// into local days. Today only counts once something is completed, but an
+// empty today doesn't break the streak since the day isn't over. A missed
+// day is forgiven if its week still has a freeze left; freezes are only
+// spent on gaps between active days, never to extend a dead streak.
+func Compute(completions []int64, freezesPerWeek int, now time.Time) Streak {
+	active := make(map[string]bool, len(completions))
+	for _, ms := range completions {
+		active[dayKey(time.UnixMilli(ms).In(now.Location()))] = true
+	}
+
+	used := map[string]int{} // Week key → freezes spent
+	var s Streak
+	pending := map[string]int{} // Freezes spent since the last active day
+
+	day := now
+	if !active[dayKey(day)] {
+		day = day.AddDate(0, 0, -1)
+	}
+	for i := 0; i < WindowDays; i++ {
+		if active[dayKey(day)] {
+			s.Days++
+			for week, n := range pending {
+				used[week] += n
+				s.FreezesUsed += n
+			}
+			clear(pending)
+		} else {
+			week := goals.WeekKey(day)
+			if used[week]+pending[week] >= freezesPerWeek {
+				break
+			}
+			pending[week]++
+		}
+		day = day.AddDate(0, 0, -1)
+	}
+
+	thisWeek := goals.WeekKey(now)
+	s.Frozen = used[thisWeek] > 0
+	s.FreezesLeft = max(freezesPerWeek-used[thisWeek], 0)
+	return s
+}
+
+// ValidateFreezes rejects freeze allowances outside 0-MaxFreezesPerWeek
+func ValidateFreezes(n int) error {
+	if n < 0 || n > MaxFreezesPerWeek {
+		return fmt.Errorf("streak freezes must be between 0 and %d per week", MaxFreezesPerWeek)
+	}
+	return nil
+}
+
+func dayKey(t time.Time) string {
+	return t.Format("2006-01-02")
+}
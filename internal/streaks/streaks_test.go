@@ -0,0 +1,72 @@
+package streaks
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCompute(t *testing.T) {
+	// Wednesday evening, the week after US clocks went forward (Sunday
+	// 2026-03-08), so a streak through last week crosses the DST switch
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("no tzdata:", err)
+	}
+	now := time.Date(2026, 3, 11, 18, 0, 0, 0, loc)
+
+	// daysAgo makes one completion at noon on each of the given days
+	daysAgo := func(offsets ...int) []int64 {
+		var ms []int64
+		for _, n := range offsets {
+			y, m, d := now.AddDate(0, 0, -n).Date()
+			ms = append(ms, time.Date(y, m, d, 12, 0, 0, 0, loc).UnixMilli())
+		}
+		return ms
+	}
+	run := func(from, to int) []int {
+		var offsets []int
+		for n := from; n <= to; n++ {
+			offsets = append(offsets, n)
+		}
+		return offsets
+	}
+
+	tests := []struct {
+		name        string
+		completions []int64
+		freezes     int
+		want        Streak
+	}{
+		{"nothing", nil, 1, Streak{FreezesLeft: 1}},
+		{"today only", daysAgo(0), 1, Streak{Days: 1, FreezesLeft: 1}},
+		{"empty today keeps yesterday's streak", daysAgo(1, 2), 1, Streak{Days: 2, FreezesLeft: 1}},
+		{"several today count once", append(daysAgo(0), daysAgo(0)...), 1, Streak{Days: 1, FreezesLeft: 1}},
+		{"gap forgiven this week", daysAgo(0, 2), 1, Streak{Days: 2, FreezesUsed: 1, Frozen: true}},
+		{"gap with no freezes breaks", daysAgo(0, 2), 0, Streak{Days: 1}},
+		{"gap longer than the freezes breaks", daysAgo(0, 3), 1, Streak{Days: 1, FreezesLeft: 1}},
+		{"two freezes cover two days", daysAgo(0, 3), 2, Streak{Days: 2, FreezesUsed: 2, Frozen: true}},
+		{"missed yesterday forgiven while today is open", daysAgo(2), 1, Streak{Days: 1, FreezesUsed: 1, Frozen: true}},
+		{"dead streak isn't extended", daysAgo(5), 1, Streak{FreezesLeft: 1}},
+		{"freeze from last week", daysAgo(0, 1, 2, 4, 5), 1, Streak{Days: 5, FreezesUsed: 1, FreezesLeft: 1}},
+		{"each week has its own freeze", daysAgo(0, 1, 3, 4, 5, 7), 1, Streak{Days: 6, FreezesUsed: 2, Frozen: true}},
+		{"second gap in a week breaks", daysAgo(0, 1, 2, 4, 6), 1, Streak{Days: 4, FreezesUsed: 1, FreezesLeft: 1}},
+		{"across the DST switch", daysAgo(run(0, 6)...), 1, Streak{Days: 7, FreezesLeft: 1}},
+		{"capped at the window", daysAgo(run(0, WindowDays+10)...), 1, Streak{Days: WindowDays, FreezesLeft: 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Compute(tt.completions, tt.freezes, now); got != tt.want {
+				t.Errorf("Compute = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateFreezes(t *testing.T) {
+	for n := -1; n <= MaxFreezesPerWeek+1; n++ {
+		err := ValidateFreezes(n)
+		if valid := n >= 0 && n <= MaxFreezesPerWeek; valid != (err == nil) {
+			t.Errorf("ValidateFreezes(%d) = %v", n, err)
+		}
+	}
+}
@@ -1,13 +1,19 @@
 package tui
 
 import (
+	"errors"
 	"fmt"
+	"os"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 
 	"grind/internal/api"
 	"grind/internal/auth"
+	"grind/internal/logging"
+	"grind/internal/store"
 )
 
 // Screen represents different screens in the app
@@ -19,48 +25,74 @@ const (
 	ScreenLeaderboard
 	ScreenStats
 	ScreenLevelUp
+	ScreenSettings
 )
 
 // App is the root model for the TUI
 type App struct {
 	screen       Screen
 	config       *auth.Config
-	client       *api.Client
+	backend      api.ConvexAPI // the local store in local mode
 	width        int
 	height       int
 	err          error
+	compact      bool // --compact: force the compact layout this session
 
 	// Screen models
 	onboarding   *OnboardingModel
 	dashboard    *DashboardModel
+	settings     *SettingsModel
 	// leaderboard  *LeaderboardModel
 	// stats        *StatsModel
 }
 
-// NewApp creates a new App instance
-func NewApp(cfg *auth.Config) *App {
-	var client *api.Client
-	if url := cfg.GetConvexURL(); url != "" {
-		client = api.NewClient(url)
+// NewApp creates a new App instance. compact forces the compact dashboard
+// layout for this session without changing the saved layout. A local-mode
+// config gets the local store as its backend, so nothing reaches Convex.
+func NewApp(cfg *auth.Config, compact bool) *App {
+	var backend api.ConvexAPI
+	if cfg.Local {
+		backend = store.Backend{Location: cfg.Location()}
+	} else if url := cfg.GetConvexURL(); url != "" {
+		backend = api.NewClient(url)
 	}
 
 	app := &App{
-		config: cfg,
-		client: client,
+		config:  cfg,
+		backend: backend,
+		compact: compact,
 	}
 
 	// Determine starting screen
 	if !cfg.IsLoggedIn() {
 		app.screen = ScreenOnboarding
-		app.onboarding = NewOnboardingModel(cfg, client)
+		app.onboarding = NewOnboardingModel(cfg, backend)
 	} else {
 		app.screen = ScreenDashboard
-		app.dashboard = NewDashboardModel(cfg, client)
+		app.dashboard = app.newDashboard()
+		app.dashboard.catchUpSince = markSeen(cfg, time.Now())
 	}
 
 	return app
 }
 
+// catchUpAfter is how long grind must go unopened for the next launch to
+// show what the crew did in between
+const catchUpAfter = 24 * time.Hour
+
+// markSeen records this launch in cfg and returns when the user was last
+// here (Unix ms) if that was at least catchUpAfter ago, otherwise 0
+func markSeen(cfg *auth.Config, now time.Time) int64 {
+	last := cfg.LastSeenAt
+	seen := func(c *auth.Config) { c.LastSeenAt = now.Unix() }
+	seen(cfg)
+	_ = auth.Update(seen) // Best effort - at worst the summary shows again
+	if last == 0 || now.Sub(time.Unix(last, 0)) < catchUpAfter {
+		return 0
+	}
+	return last * 1000
+}
+
 // Init initializes the app
 func (a *App) Init() tea.Cmd {
 	switch a.screen {
@@ -89,7 +121,11 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// Only quit on 'q' if not in text input mode
 			if a.screen == ScreenOnboarding && a.onboarding != nil && a.onboarding.focusedInput >= 0 {
 				// Let the input handle it
-			} else if a.screen == ScreenDashboard && a.dashboard != nil && a.dashboard.inputFocused {
+			} else if a.screen == ScreenDashboard && a.dashboard != nil && a.dashboard.CapturesKeys() {
+				// Let the input, modal, or prompt handle it
+			} else if a.screen == ScreenDashboard && a.dashboard != nil && !a.dashboard.ConfirmQuit() {
+				return a, nil
+			} else if a.screen == ScreenSettings && a.settings != nil && a.settings.editing {
 				// Let the input handle it
 			} else {
 				return a, tea.Quit
@@ -100,11 +136,27 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		a.screen = msg.Screen
 		switch msg.Screen {
 		case ScreenDashboard:
-			a.dashboard = NewDashboardModel(a.config, a.client)
+			a.dashboard = a.newDashboard()
 			return a, a.dashboard.Init()
 		case ScreenOnboarding:
-			a.onboarding = NewOnboardingModel(a.config, a.client)
+			if a.config.IsLoggedIn() {
+				a.onboarding = NewSetupModel(a.config, a.backend)
+			} else {
+				a.onboarding = NewOnboardingModel(a.config, a.backend)
+			}
 			return a, a.onboarding.Init()
+		case ScreenSettings:
+			// The dashboard stays alive underneath so it keeps polling
+			a.settings = NewSettingsModel(a.config, a.backend)
+			return a, a.settings.Init()
+		}
+		return a, nil
+
+	case SettingsClosedMsg:
+		a.screen = ScreenDashboard
+		a.settings = nil
+		if a.dashboard != nil {
+			return a, a.dashboard.ApplyConfig()
 		}
 		return a, nil
 
@@ -112,7 +164,7 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Save config and switch to dashboard
 		a.config = msg.Config
 		a.screen = ScreenDashboard
-		a.dashboard = NewDashboardModel(a.config, a.client)
+		a.dashboard = a.newDashboard()
 		return a, a.dashboard.Init()
 
 	case ErrorMsg:
@@ -138,6 +190,21 @@ func (a *App) updateCurrentScreen(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m, cmd = a.dashboard.Update(msg)
 			a.dashboard = m.(*DashboardModel)
 		}
+	case ScreenSettings:
+		// Keys go to settings only; everything else (ticks, loaded data,
+		// resizes) also reaches the dashboard so it's current when we return
+		var dashCmd tea.Cmd
+		if _, isKey := msg.(tea.KeyMsg); !isKey && a.dashboard != nil {
+			var m tea.Model
+			m, dashCmd = a.dashboard.Update(msg)
+			a.dashboard = m.(*DashboardModel)
+		}
+		if a.settings != nil {
+			var m tea.Model
+			m, cmd = a.settings.Update(msg)
+			a.settings = m.(*SettingsModel)
+		}
+		cmd = tea.Batch(cmd, dashCmd)
 	}
 	return a, cmd
 }
@@ -160,6 +227,10 @@ func (a *App) View() string {
 		if a.dashboard != nil {
 			content = a.dashboard.View()
 		}
+	case ScreenSettings:
+		if a.settings != nil {
+			content = a.settings.View()
+		}
 	default:
 		content = "Unknown screen"
 	}
@@ -187,15 +258,94 @@ type ErrorMsg struct {
 	Err error
 }
 
+// newDashboard creates the dashboard, applying any session-only overrides
+func (a *App) newDashboard() *DashboardModel {
+	d := NewDashboardModel(a.config, a.backend)
+	if a.compact {
+		d.forceCompact = true
+		d.compact = true
+	}
+	return d
+}
+
 // Run starts the TUI application
-func Run(cfg *auth.Config) error {
-	app := NewApp(cfg)
-	p := tea.NewProgram(
-		app,
-		tea.WithAltScreen(),
-		tea.WithMouseCellMotion(),
-	)
+func Run(cfg *auth.Config, compact bool) error {
+	return run(NewApp(cfg, compact))
+}
+
+// RunSetup starts the TUI on the setup screen, to change the name and crew
+// of an existing account. Finishing or leaving setup opens the dashboard.
+func RunSetup(cfg *auth.Config) error {
+	app := NewApp(cfg, false)
+	if cfg.IsLoggedIn() {
+		app.screen = ScreenOnboarding
+		app.onboarding = NewSetupModel(cfg, app.backend)
+		app.dashboard = nil
+	}
+	return run(app)
+}
+
+// altScreen runs the TUI on the terminal's alternate screen; off
+// (--no-altscreen) it draws inline, in the normal scrollback
+var altScreen = true
+
+// SetAltScreen turns the alternate screen on or off for the TUI
+func SetAltScreen(on bool) {
+	altScreen = on
+}
+
+func run(app *App) error {
+	// A dumb terminal gets neither the alternate screen nor the mouse and
+	// focus reporting, which it would print as garbage
+	var opts []tea.ProgramOption
+	dumb := os.Getenv("TERM") == "dumb"
+	if altScreen && !dumb {
+		opts = append(opts, tea.WithAltScreen())
+	}
+	if !dumb {
+		opts = append(opts, tea.WithMouseCellMotion(), tea.WithReportFocus())
+	}
+	p := tea.NewProgram(app, opts...)
+
+	// Bubbletea restores the terminal after a panic in the program itself;
+	// this covers one anywhere else on the way out
+	defer func() {
+		if r := recover(); r != nil {
+			restoreTerminal()
+			panic(r)
+		}
+	}()
 
 	_, err := p.Run()
+
+	// Flush state on every exit path (q, ctrl+c, or SIGINT/SIGTERM).
+	// Bubbletea has already restored the terminal by the time Run returns.
+	if saveErr := app.saveState(); saveErr != nil && err == nil {
+		err = fmt.Errorf("save state: %w", saveErr)
+	}
+
+	if errors.Is(err, tea.ErrProgramPanic) {
+		logging.Error("tui panic", "err", err)
+	}
+
+	// An interrupt is a deliberate exit, not a failure
+	if errors.Is(err, tea.ErrInterrupted) {
+		return nil
+	}
 	return err
 }
+
+// restoreTerminal leaves the alternate screen and turns off mouse and focus
+// reporting, and shows the cursor again. Each is harmless if not on.
+func restoreTerminal() {
+	fmt.Fprint(os.Stdout, ansi.ResetButtonEventMouseMode+ansi.ResetSgrExtMouseMode+
+		ansi.ResetFocusEventMode+ansi.ResetAltScreenSaveCursorMode+ansi.ShowCursor)
+}
+
+// saveState persists the current screen's unsynced state before exit
+func (a *App) saveState() error {
+	if a.dashboard != nil && a.config.IsLoggedIn() {
+		return a.dashboard.SaveState()
+	}
+	return nil
+}
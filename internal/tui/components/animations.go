@@ -39,6 +39,19 @@ var (
 	levelUpHintStyle = lipgloss.NewStyle().
 				Foreground(animDimmed)
 
+	levelUpRingDimStyle = lipgloss.NewStyle().
+				Foreground(animDimmed)
+
+	levelUpRingHeadStyle = lipgloss.NewStyle().
+				Bold(true).
+				Foreground(animWhite)
+
+	levelUpConfettiStyles = []lipgloss.Style{
+		lipgloss.NewStyle().Foreground(animGold),
+		lipgloss.NewStyle().Foreground(animCyan),
+		lipgloss.NewStyle().Foreground(animGreen),
+	}
+
 	xpGainStyle = lipgloss.NewStyle().
 			Bold(true).
 			Foreground(animGreen)
@@ -74,6 +87,10 @@ type AnimationState struct {
 	FlashQuestID string
 	FlashTicks   int
 
+	// Floating "+N XP" label
+	GainAmount int
+	GainTicks  int
+
 	// Animation frame counter
 	Frame int
 }
@@ -99,17 +116,26 @@ func (a *AnimationState) SetDisplayedXP(xp int) {
 	a.TargetXP = xp
 }
 
+// gainFloatTicks is how long the "+N XP" label floats (~1s at 50ms intervals)
+const gainFloatTicks = 20
+
 // TriggerXPGain starts an XP gain animation
 func (a *AnimationState) TriggerXPGain(amount, newTotal int) {
 	a.TargetXP = newTotal
-	// Adjust tick rate based on amount
+	a.XPTickRate = XPTickRate(amount)
+	a.GainAmount = amount
+	a.GainTicks = gainFloatTicks
+}
+
+// XPTickRate returns how much XP to count up per animation tick,
+// scaled so large gains don't take forever to animate
+func XPTickRate(amount int) int {
 	if amount > 50 {
-		a.XPTickRate = 10
+		return 10
 	} else if amount > 20 {
-		a.XPTickRate = 5
-	} else {
-		a.XPTickRate = 2
+		return 5
 	}
+	return 2
 }
 
 // TriggerQuestFlash starts a quest flash animation
@@ -120,7 +146,12 @@ func (a *AnimationState) TriggerQuestFlash(questID string) {
 
 // IsAnimating returns true if any animation is in progress
 func (a *AnimationState) IsAnimating() bool {
-	return a.DisplayedXP < a.TargetXP || a.FlashTicks > 0
+	return a.DisplayedXP < a.TargetXP || a.FlashTicks > 0 || a.GainTicks > 0
+}
+
+// IsCountingXP returns true while the XP counter is still ticking up
+func (a *AnimationState) IsCountingXP() bool {
+	return a.DisplayedXP < a.TargetXP
 }
 
 // Update updates the animation state
@@ -142,6 +173,12 @@ func (a *AnimationState) Update() tea.Cmd {
 		updated = true
 	}
 
+	// Floating gain label countdown
+	if a.GainTicks > 0 {
+		a.GainTicks--
+		updated = true
+	}
+
 	// Increment frame
 	a.Frame = (a.Frame + 1) % 100
 
@@ -164,6 +201,15 @@ func (a *AnimationState) GetFlashIntensity() float64 {
 	return float64(a.FlashTicks) / 6.0
 }
 
+// GetGainProgress returns 0-1 for how far the "+N XP" label has floated
+// (0 = just appeared, 1 = faded out)
+func (a *AnimationState) GetGainProgress() float64 {
+	if a.GainTicks <= 0 {
+		return 1
+	}
+	return 1 - float64(a.GainTicks)/float64(gainFloatTicks)
+}
+
 // TickAnimation returns a command to tick the animation
 func TickAnimation() tea.Cmd {
 	return tea.Tick(50*time.Millisecond, func(t time.Time) tea.Msg {
@@ -171,6 +217,9 @@ func TickAnimation() tea.Cmd {
 	})
 }
 
+// levelUpSweepTicks is how many ticks the level-up border ring takes to fill
+const levelUpSweepTicks = 20
+
 // LevelUpModal represents the level-up celebration modal
 type LevelUpModal struct {
 	Level      levels.Level
@@ -223,26 +272,28 @@ func (m *LevelUpModal) View(screenWidth, screenHeight int) string {
 	}
 
 	// Build modal content
-	title := levelUpTitleStyle.Render("⚡ LEVEL UP! ⚡")
+	title := levelUpTitleStyle.Render(Glyphs.LevelUp + " LEVEL UP! " + Glyphs.LevelUp)
 	levelNum := levelUpLevelStyle.Render(fmt.Sprintf("Level %d", m.Level.Number))
 	levelName := levelUpNameStyle.Render(m.Level.Name)
 	hint := levelUpHintStyle.Render("press any key to continue...")
 
+	// Create modal box
+	modalWidth := 34
+	confettiWidth := modalWidth - 6
+
 	// Combine content
 	content := lipgloss.JoinVertical(
 		lipgloss.Center,
-		"",
+		m.renderConfetti(confettiWidth, 0),
 		title,
-		"",
+		m.renderConfetti(confettiWidth, 1),
 		levelNum,
 		levelName,
 		"",
 		hint,
-		"",
+		m.renderConfetti(confettiWidth, 2),
 	)
 
-	// Create modal box
-	modalWidth := 30
 	modal := m.renderModalBox(content, modalWidth)
 
 	// Center on screen
@@ -255,19 +306,63 @@ func (m *LevelUpModal) View(screenWidth, screenHeight int) string {
 	)
 }
 
-// renderModalBox renders the modal with double border
-func (m *LevelUpModal) renderModalBox(content string, width int) string {
-	// Top border
-	topBorder := levelUpBorderStyle.Render("╔")
-	for i := 0; i < width-2; i++ {
-		topBorder += levelUpBorderStyle.Render("═")
+// renderConfetti renders one row of twinkling confetti. The pattern is
+// reseeded every other tick so the characters sparkle rather than scroll.
+func (m *LevelUpModal) renderConfetti(width, row int) string {
+	runes := []rune(Glyphs.Confetti)
+	var line string
+	for col := 0; col < width; col++ {
+		seed := col*7 + row*13 + (m.Ticks/2)*31
+		if seed%5 != 0 {
+			line += " "
+			continue
+		}
+		style := levelUpConfettiStyles[(col+m.Ticks)%len(levelUpConfettiStyles)]
+		line += style.Render(string(runes[(seed/5)%len(runes)]))
 	}
-	topBorder += levelUpBorderStyle.Render("╗")
+	return line
+}
 
-	// Content lines
+// renderModalBox renders the modal with a double border that acts as a
+// progress ring: it lights up clockwise over levelUpSweepTicks, then a
+// bright head keeps circling until the modal auto-dismisses
+func (m *LevelUpModal) renderModalBox(content string, width int) string {
+	b := Glyphs.Double
 	lines := splitLines(content)
+	height := len(lines) + 2
+	perimeter := 2*(width-1) + 2*(height-1)
+
+	lit := perimeter
+	head := (m.Ticks * 3) % perimeter
+	if m.Ticks < levelUpSweepTicks {
+		lit = perimeter * m.Ticks / levelUpSweepTicks
+		head = lit
+	}
+
+	// ring renders the border character at perimeter position pos
+	ring := func(pos int, ch string) string {
+		switch {
+		case pos == head:
+			return levelUpRingHeadStyle.Render(ch)
+		case pos < lit:
+			return levelUpBorderStyle.Render(ch)
+		default:
+			return levelUpRingDimStyle.Render(ch)
+		}
+	}
+
+	// Top border: left to right
+	topBorder := ring(0, b.TopLeft)
+	for i := 1; i < width-1; i++ {
+		topBorder += ring(i, b.Horizontal)
+	}
+	topBorder += ring(width-1, b.TopRight)
+
+	// Content lines: right side runs down, left side runs up
 	var body string
-	for _, line := range lines {
+	for r, line := range lines {
+		row := r + 1
+		line = FitLine(line, width-2)
 		lineLen := lipgloss.Width(line)
 		totalPadding := width - lineLen - 2
 		leftPad := totalPadding / 2
@@ -279,7 +374,7 @@ func (m *LevelUpModal) renderModalBox(content string, width int) string {
 			rightPad = 0
 		}
 
-		body += levelUpBorderStyle.Render("║")
+		body += ring(2*(width-1)+(height-1)+(height-1-row), b.Vertical)
 		for i := 0; i < leftPad; i++ {
 			body += " "
 		}
@@ -287,15 +382,16 @@ func (m *LevelUpModal) renderModalBox(content string, width int) string {
 		for i := 0; i < rightPad; i++ {
 			body += " "
 		}
-		body += levelUpBorderStyle.Render("║") + "\n"
+		body += ring(width-1+row, b.Vertical) + "\n"
 	}
 
-	// Bottom border
-	bottomBorder := levelUpBorderStyle.Render("╚")
-	for i := 0; i < width-2; i++ {
-		bottomBorder += levelUpBorderStyle.Render("═")
+	// Bottom border: right to left
+	bottomStart := width - 1 + height - 1
+	bottomBorder := ring(bottomStart+width-1, b.BottomLeft)
+	for i := 1; i < width-1; i++ {
+		bottomBorder += ring(bottomStart+width-1-i, b.Horizontal)
 	}
-	bottomBorder += levelUpBorderStyle.Render("╝")
+	bottomBorder += ring(bottomStart, b.BottomRight)
 
 	return topBorder + "\n" + body + bottomBorder
 }
@@ -0,0 +1,201 @@
+package components
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"grind/internal/api"
+	"grind/internal/i18n"
+)
+
+// Catch-up modal colors
+var (
+	catchUpCyan   = lipgloss.Color("#00D4FF")
+	catchUpGold   = lipgloss.Color("#FFD700")
+	catchUpGreen  = lipgloss.Color("#04B575")
+	catchUpRed    = lipgloss.Color("#FF0055")
+	catchUpWhite  = lipgloss.Color("#FFFFFF")
+	catchUpDimmed = lipgloss.Color("#7D7D7D")
+)
+
+// Catch-up modal styles
+var (
+	catchUpBorderStyle = lipgloss.NewStyle().
+				Foreground(catchUpCyan)
+
+	catchUpTitleStyle = lipgloss.NewStyle().
+				Bold(true).
+				Foreground(catchUpCyan)
+
+	catchUpTextStyle = lipgloss.NewStyle().
+				Foreground(catchUpWhite)
+
+	catchUpXPStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(catchUpGold)
+
+	catchUpUpStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(catchUpGreen)
+
+	catchUpDownStyle = lipgloss.NewStyle().
+				Bold(true).
+				Foreground(catchUpRed)
+
+	catchUpHintStyle = lipgloss.NewStyle().
+				Foreground(catchUpDimmed)
+)
+
+// catchUpLevelUps caps the level-ups listed, newest last
+const catchUpLevelUps = 3
+
+// CatchUpModal shows what the crew did while the user was away
+type CatchUpModal struct {
+	Visible bool
+	CatchUp *api.CatchUp
+}
+
+// NewCatchUpModal creates a new catch-up modal
+func NewCatchUpModal() *CatchUpModal {
+	return &CatchUpModal{Visible: false}
+}
+
+// Show displays the modal with a catch-up summary
+func (m *CatchUpModal) Show(c *api.CatchUp) {
+	m.CatchUp = c
+	m.Visible = true
+}
+
+// Hide hides the modal
+func (m *CatchUpModal) Hide() {
+	m.Visible = false
+}
+
+// View renders the catch-up modal
+func (m *CatchUpModal) View(screenWidth, screenHeight int) string {
+	if !m.Visible || m.CatchUp == nil {
+		return ""
+	}
+	c := m.CatchUp
+
+	modalWidth := 44
+	title := catchUpTitleStyle.Render("WHILE YOU WERE AWAY")
+	away := catchUpHintStyle.Render(awayFor(time.Since(time.UnixMilli(c.Since))))
+	dismissLine := catchUpHintStyle.Render("press any key to close")
+
+	body := []string{
+		catchUpTextStyle.Render(fmt.Sprintf("crew: %s quests %s ", i18n.Number(c.QuestsCompleted), Glyphs.Dot)) +
+			catchUpXPStyle.Render(fmt.Sprintf("+%s XP", i18n.FormatXP(c.XP))),
+	}
+
+	if len(c.Top) > 0 {
+		body = append(body, "", catchUpHintStyle.Render("top grinders"))
+		for i, member := range c.Top {
+			body = append(body, catchUpTextStyle.Render(fmt.Sprintf("%d. %s ", i+1, PadName(member.UserName, 12)))+
+				catchUpXPStyle.Render(fmt.Sprintf("+%s XP", i18n.FormatXP(member.XP))))
+		}
+	}
+
+	if len(c.LevelUps) > 0 {
+		body = append(body, "", catchUpHintStyle.Render("level ups"))
+		levelUps := c.LevelUps
+		if len(levelUps) > catchUpLevelUps {
+			body = append(body, catchUpHintStyle.Render(fmt.Sprintf("%d more earlier", len(levelUps)-catchUpLevelUps)))
+			levelUps = levelUps[len(levelUps)-catchUpLevelUps:]
+		}
+		for _, l := range levelUps {
+			body = append(body, catchUpTextStyle.Render(fmt.Sprintf("%s%s reached L%d", Glyphs.LevelUp, truncateString(l.UserName, 12), l.NewLevel)))
+		}
+	}
+
+	if rank := m.renderRank(); rank != "" {
+		body = append(body, "", rank)
+	}
+
+	lines := []string{"", title, away, ""}
+	lines = append(lines, body...)
+	lines = append(lines, "", dismissLine, "")
+	content := lipgloss.JoinVertical(lipgloss.Center, lines...)
+
+	modal := m.renderModalBox(content, modalWidth)
+
+	return lipgloss.Place(
+		screenWidth,
+		screenHeight,
+		lipgloss.Center,
+		lipgloss.Center,
+		modal,
+	)
+}
+
+// renderRank renders "your rank: #3 → #2 ▲1", or just the current rank
+// when the previous one isn't known
+func (m *CatchUpModal) renderRank() string {
+	c := m.CatchUp
+	if c.Rank <= 0 {
+		return ""
+	}
+	if c.PrevRank <= 0 || c.PrevRank == c.Rank {
+		return catchUpTextStyle.Render(fmt.Sprintf("your rank: #%d", c.Rank))
+	}
+	line := catchUpTextStyle.Render(fmt.Sprintf("your rank: #%d %s #%d ", c.PrevRank, Glyphs.Arrow, c.Rank))
+	if c.Rank < c.PrevRank {
+		return line + catchUpUpStyle.Render(fmt.Sprintf("%s%d", Glyphs.RankUp, c.PrevRank-c.Rank))
+	}
+	return line + catchUpDownStyle.Render(fmt.Sprintf("%s%d", Glyphs.RankDown, c.Rank-c.PrevRank))
+}
+
+// awayFor describes an absence, e.g. "away 3 days"
+func awayFor(d time.Duration) string {
+	days := int(d.Hours() / 24)
+	if days <= 1 {
+		return "away since yesterday"
+	}
+	return fmt.Sprintf("away %d days", days)
+}
+
+// renderModalBox renders the modal with double border
+func (m *CatchUpModal) renderModalBox(content string, width int) string {
+	b := Glyphs.Double
+	topBorder := catchUpBorderStyle.Render(b.TopLeft)
+	for i := 0; i < width-2; i++ {
+		topBorder += catchUpBorderStyle.Render(b.Horizontal)
+	}
+	topBorder += catchUpBorderStyle.Render(b.TopRight)
+
+	lines := splitLines(content)
+	var body string
+	for _, line := range lines {
+		line = FitLine(line, width-2)
+		lineLen := lipgloss.Width(line)
+		totalPadding := width - lineLen - 2
+		leftPad := totalPadding / 2
+		rightPad := totalPadding - leftPad
+		if leftPad < 0 {
+			leftPad = 0
+		}
+		if rightPad < 0 {
+			rightPad = 0
+		}
+
+		body += catchUpBorderStyle.Render(b.Vertical)
+		for i := 0; i < leftPad; i++ {
+			body += " "
+		}
+		body += line
+		for i := 0; i < rightPad; i++ {
+			body += " "
+		}
+		body += catchUpBorderStyle.Render(b.Vertical) + "\n"
+	}
+
+	bottomBorder := catchUpBorderStyle.Render(b.BottomLeft)
+	for i := 0; i < width-2; i++ {
+		bottomBorder += catchUpBorderStyle.Render(b.Horizontal)
+	}
+	bottomBorder += catchUpBorderStyle.Render(b.BottomRight)
+
+	return topBorder + "\n" + body + bottomBorder
+}
@@ -0,0 +1,33 @@
+package components
+
+import "grind/internal/i18n"
+
+// ColorSafe adds text labels and patterns wherever the HUD would otherwise
+// tell states apart by color alone, for color-blind users. Set from the
+// "colorSafe" setting.
+var ColorSafe bool
+
+// SetColorSafe turns the color-blind-friendly labels on or off
+func SetColorSafe(on bool) {
+	ColorSafe = on
+}
+
+// StatusLabel returns a quest status as a short word, e.g. " (active)", to
+// follow its title. It's empty unless ColorSafe is on.
+func StatusLabel(status string, snoozed bool) string {
+	if !ColorSafe {
+		return ""
+	}
+	key := "status.todo"
+	switch {
+	case snoozed:
+		key = "status.snoozed"
+	case status == "in_progress":
+		key = "status.active"
+	case status == "completed":
+		key = "status.done"
+	case status == "abandoned":
+		key = "status.dropped"
+	}
+	return " (" + i18n.T(key) + ")"
+}
@@ -0,0 +1,86 @@
+package components
+
+import (
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	// OfflineAfter is how many queries in a row must fail before the
+	// dashboard counts as offline, so one slow poll doesn't flip the indicator
+	OfflineAfter = 3
+
+	// MaxBackoff caps how far polling slows down while offline
+	MaxBackoff = 5 * time.Minute
+)
+
+var (
+	connOnlineStyle = lipgloss.NewStyle().
+			Foreground(headerGreen)
+
+	connOfflineStyle = lipgloss.NewStyle().
+				Bold(true).
+				Foreground(headerOrange)
+)
+
+// Connection tracks whether the dashboard's backend queries are getting
+// through, for the header's online/offline indicator
+type Connection struct {
+	failures int       // Consecutive failed queries
+	LastSync time.Time // Last successful query, zero before the first
+
+	// Problem is the layer the last health check failed at while offline,
+	// e.g. "dns" (an api.HealthKind), or "" if unknown
+	Problem string
+}
+
+// Record notes the outcome of a query finished at now. It reports whether
+// the query brought the connection back from offline.
+func (c *Connection) Record(err error, now time.Time) bool {
+	if err != nil {
+		c.failures++
+		return false
+	}
+	recovered := c.Offline()
+	c.failures = 0
+	c.LastSync = now
+	c.Problem = ""
+	return recovered
+}
+
+// Offline reports whether enough queries in a row have failed to treat the
+// data on screen as stale
+func (c *Connection) Offline() bool {
+	return c.failures >= OfflineAfter
+}
+
+// PollInterval returns how long to wait before the next poll: base while
+// online, then doubling with each failure past going offline, up to
+// MaxBackoff (or base, if that's longer)
+func (c *Connection) PollInterval(base time.Duration) time.Duration {
+	if !c.Offline() {
+		return base
+	}
+	interval := base
+	for i := OfflineAfter; i <= c.failures && interval < MaxBackoff; i++ {
+		interval *= 2
+	}
+	return max(min(interval, MaxBackoff), base)
+}
+
+// View renders "● online", or "○ offline (dns) · synced 14:32" with what
+// went wrong, when known, and the time of the last successful query
+func (c *Connection) View() string {
+	if !c.Offline() {
+		return connOnlineStyle.Render(Glyphs.Online + " online")
+	}
+	text := Glyphs.Offline + " offline"
+	if c.Problem != "" {
+		text += " (" + c.Problem + ")"
+	}
+	if !c.LastSync.IsZero() {
+		text += " " + Glyphs.Dot + " synced " + c.LastSync.Format("15:04")
+	}
+	return connOfflineStyle.Render(text)
+}
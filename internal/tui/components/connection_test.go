@@ -0,0 +1,30 @@
+package components
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestConnectionProblem(t *testing.T) {
+	now := time.Now()
+	var c Connection
+	for range OfflineAfter {
+		c.Record(errors.New("dial tcp: no such host"), now)
+	}
+	c.Problem = "dns"
+	if view := c.View(); !strings.Contains(view, "offline (dns)") {
+		t.Errorf("offline view = %q, want the problem shown", view)
+	}
+
+	if !c.Record(nil, now) {
+		t.Error("a successful check didn't report recovery")
+	}
+	if c.Problem != "" {
+		t.Errorf("Problem = %q after recovering", c.Problem)
+	}
+	if view := c.View(); strings.Contains(view, "dns") {
+		t.Errorf("online view = %q still names the problem", view)
+	}
+}
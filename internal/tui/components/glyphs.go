@@ -0,0 +1,262 @@
+package components
+
+import (
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// BorderSet holds the characters used to draw a panel or modal frame
+type BorderSet struct {
+	TopLeft     string
+	TopRight    string
+	BottomLeft  string
+	BottomRight string
+	Horizontal  string
+	Vertical    string
+}
+
+// Border returns the set as a lipgloss border, for styles that draw their
+// own frame
+func (b BorderSet) Border() lipgloss.Border {
+	return lipgloss.Border{
+		Top:         b.Horizontal,
+		Bottom:      b.Horizontal,
+		Left:        b.Vertical,
+		Right:       b.Vertical,
+		TopLeft:     b.TopLeft,
+		TopRight:    b.TopRight,
+		BottomLeft:  b.BottomLeft,
+		BottomRight: b.BottomRight,
+	}
+}
+
+// GlyphSet is every non-ASCII character the HUD draws. Panels and modals
+// read from Glyphs instead of hardcoding unicode so the whole UI can fall
+// back to plain ASCII on terminals that can't render it.
+type GlyphSet struct {
+	Rounded BorderSet // Panels
+	Square  BorderSet // Inner boxes (AI insight, invite code)
+	Double  BorderSet // Modals
+
+	// Panel and modal title icons
+	Quests      string
+	Intel       string
+	Leaderboard string
+	Crew        string
+	Rival       string
+	LevelUp     string
+	AI          string
+
+	// Inline icons
+	Crown    string
+	Warning  string
+	Stats    string
+	Skull    string
+	Note     string
+	Branch   string
+	Selected string
+	RankUp   string
+	RankDown string
+	RankSame string
+	Ellipsis string
+	Dot      string
+	Streak   string
+	Freeze   string
+	Timer    string
+	Goal     string // Weekly goal reached
+	Snooze   string
+	Times    string // XP event multipliers, e.g. ×2
+	Online   string
+	Offline  string
+	Check    string // Success marks in command output
+	Cross    string // Failure marks in command output
+	Arrow    string // Selection, hints, and before → after
+	UpDown   string // The up/down keys in help lines
+	Rule     string // Repeated for separator lines
+	Spinner  string // A still spinner frame for one-shot commands
+	Bullet   string
+
+	// Reaction icons by api.ReactionEmoji name
+	Reactions map[string]string
+
+	// Quest status icons
+	InProgress string
+	Completed  string
+
+	// Single-cell quest status marks, for the classic layout and commands
+	Todo    string
+	Working string
+	Dropped string
+
+	// Level-up confetti, picked from rune by rune
+	Confetti string
+
+	// Progress bars
+	BarFull  string
+	BarEmpty string
+	BarLight string
+}
+
+// UnicodeGlyphs is the default glyph set
+var UnicodeGlyphs = GlyphSet{
+	Rounded: BorderSet{"╭", "╮", "╰", "╯", "─", "│"},
+	Square:  BorderSet{"┌", "┐", "└", "┘", "─", "│"},
+	Double:  BorderSet{"╔", "╗", "╚", "╝", "═", "║"},
+
+	Quests:      "⚔️ ",
+	Intel:       "📡 ",
+	Leaderboard: "🏆 ",
+	Crew:        "👥 ",
+	Rival:       "⚔ ",
+	LevelUp:     "⚡",
+	AI:          "🤖 ",
+
+	Crown:    " 👑",
+	Warning:  "⚠",
+	Stats:    "📊",
+	Skull:    "💀",
+	Note:     "✎ ",
+	Branch:   "└─ ",
+	Selected: "┃",
+	RankUp:   "▲",
+	RankDown: "▼",
+	RankSame: "—",
+	Ellipsis: "…",
+	Dot:      "·",
+	Streak:   "🔥 ",
+	Freeze:   "❄ ",
+	Timer:    "⏱ ",
+	Goal:     "🎯 ",
+	Snooze:   "💤 ",
+	Times:    "×",
+	Online:   "●",
+	Offline:  "○",
+	Check:    "✓",
+	Cross:    "✗",
+	Arrow:    "→",
+	UpDown:   "↑↓",
+	Rule:     "═",
+	Spinner:  "⠋",
+	Bullet:   "•",
+
+	Reactions: map[string]string{"fire": "🔥", "muscle": "💪", "clap": "👏"},
+
+	InProgress: "[●]",
+	Completed:  "[✔]",
+
+	Todo:    "☐",
+	Working: "◐",
+	Dropped: "–",
+
+	Confetti: "✦✧·*+˚",
+
+	BarFull:  "█",
+	BarEmpty: "▒",
+	BarLight: "░",
+}
+
+// ASCIIGlyphs replaces emoji with short labels and box drawing with +-|
+var ASCIIGlyphs = GlyphSet{
+	Rounded: BorderSet{"+", "+", "+", "+", "-", "|"},
+	Square:  BorderSet{"+", "+", "+", "+", "-", "|"},
+	Double:  BorderSet{"#", "#", "#", "#", "=", "#"},
+
+	Quests:      "",
+	Intel:       "",
+	Leaderboard: "",
+	Crew:        "",
+	Rival:       "",
+	LevelUp:     "*",
+	AI:          "",
+
+	Crown:    " (1st)",
+	Warning:  "!",
+	Stats:    "#",
+	Skull:    "x",
+	Note:     "> ",
+	Branch:   "`- ",
+	Selected: ">",
+	RankUp:   "+",
+	RankDown: "-",
+	RankSame: "=",
+	Ellipsis: "~",
+	Dot:      "-",
+	Streak:   "",
+	Freeze:   "* ",
+	Timer:    "t-",
+	Goal:     "",
+	Snooze:   "z ",
+	Times:    "x",
+	Online:   "*",
+	Offline:  "o",
+	Check:    "ok",
+	Cross:    "x",
+	Arrow:    "->",
+	UpDown:   "up/down",
+	Rule:     "=",
+	Spinner:  "-",
+	Bullet:   "*",
+
+	Reactions: map[string]string{"fire": "fire:", "muscle": "flex:", "clap": "clap:"},
+
+	InProgress: "[*]",
+	Completed:  "[x]",
+
+	Todo:    "o",
+	Working: "~",
+	Dropped: "-",
+
+	Confetti: "*+.'`o",
+
+	BarFull:  "#",
+	BarEmpty: ".",
+	BarLight: ".",
+}
+
+// Glyphs is the active glyph set used by all components
+var Glyphs = UnicodeGlyphs
+
+// SetASCII switches every component between the unicode and ASCII glyph sets
+func SetASCII(ascii bool) {
+	if ascii {
+		Glyphs = ASCIIGlyphs
+	} else {
+		Glyphs = UnicodeGlyphs
+	}
+}
+
+// UseGlyphs applies a glyph mode setting: "ascii", "unicode", or "auto"
+// (the default), which picks ASCII when SupportsUnicode says no
+func UseGlyphs(mode string) {
+	switch mode {
+	case "ascii":
+		SetASCII(true)
+	case "unicode":
+		SetASCII(false)
+	default:
+		SetASCII(!SupportsUnicode())
+	}
+}
+
+// SupportsUnicode reports whether the environment looks able to render
+// unicode. An unset locale is given the benefit of the doubt; an explicit
+// non-UTF-8 one (C, POSIX, latin1) or the bare Linux console is not.
+func SupportsUnicode() bool {
+	if os.Getenv("TERM") == "linux" {
+		return false
+	}
+	locale := os.Getenv("LC_ALL")
+	if locale == "" {
+		locale = os.Getenv("LC_CTYPE")
+	}
+	if locale == "" {
+		locale = os.Getenv("LANG")
+	}
+	if locale == "" {
+		return true
+	}
+	locale = strings.ToUpper(locale)
+	return strings.Contains(locale, "UTF-8") || strings.Contains(locale, "UTF8")
+}
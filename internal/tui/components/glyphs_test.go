@@ -0,0 +1,62 @@
+package components
+
+import (
+	"reflect"
+	"testing"
+	"unicode"
+
+	"grind/internal/api"
+)
+
+// assertASCII fails if rendered output has any non-ASCII rune
+func assertASCII(t *testing.T, name, s string) {
+	t.Helper()
+	for _, r := range s {
+		if r > unicode.MaxASCII {
+			t.Errorf("%s has %q in ASCII mode:\n%s", name, r, s)
+			return
+		}
+	}
+}
+
+// TestASCIIGlyphs checks every string in the ASCII set really is ASCII, so
+// a glyph added to GlyphSet can't leak unicode into --ascii output
+func TestASCIIGlyphs(t *testing.T) {
+	var check func(path string, v reflect.Value)
+	check = func(path string, v reflect.Value) {
+		switch v.Kind() {
+		case reflect.String:
+			for _, r := range v.String() {
+				if r > unicode.MaxASCII {
+					t.Errorf("%s = %q is not ASCII", path, v.String())
+					break
+				}
+			}
+		case reflect.Struct:
+			for i := range v.NumField() {
+				check(path+"."+v.Type().Field(i).Name, v.Field(i))
+			}
+		case reflect.Map:
+			for _, k := range v.MapKeys() {
+				check(path+"["+k.String()+"]", v.MapIndex(k))
+			}
+		}
+	}
+	check("ASCIIGlyphs", reflect.ValueOf(ASCIIGlyphs))
+}
+
+func TestCatchUpModalASCII(t *testing.T) {
+	SetASCII(true)
+	defer SetASCII(false)
+
+	m := NewCatchUpModal()
+	m.Show(&api.CatchUp{
+		QuestsCompleted: 12,
+		XP:              840,
+		Top:             []api.CatchUpMember{{UserName: "ada", XP: 400}},
+		LevelUps:        []api.CatchUpLevelUp{{UserName: "ada", NewLevel: 4}},
+		Rank:            2,
+		PrevRank:        3,
+	})
+	assertASCII(t, "catch-up modal", m.View(80, 30))
+}
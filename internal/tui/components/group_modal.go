@@ -2,8 +2,11 @@ package components
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+
+	"grind/internal/i18n"
 )
 
 // Group modal colors
@@ -47,6 +50,7 @@ type GroupModal struct {
 	GroupName   string
 	InviteCode  string
 	MemberCount int
+	MaxMembers  int // 0 means no limit
 	HasGroup    bool
 }
 
@@ -58,11 +62,13 @@ func NewGroupModal() *GroupModal {
 	}
 }
 
-// Show displays the modal with group info
-func (m *GroupModal) Show(groupName, inviteCode string, memberCount int) {
+// Show displays the modal with group info. maxMembers is 0 for a crew
+// with no member limit.
+func (m *GroupModal) Show(groupName, inviteCode string, memberCount, maxMembers int) {
 	m.GroupName = groupName
 	m.InviteCode = inviteCode
 	m.MemberCount = memberCount
+	m.MaxMembers = maxMembers
 	m.HasGroup = true
 	m.Visible = true
 }
@@ -95,15 +101,26 @@ func (m *GroupModal) renderWithGroup(screenWidth, screenHeight int) string {
 	modalWidth := 42
 
 	// Build content
-	title := groupModalTitleStyle.Render("👥 YOUR CREW")
+	title := groupModalTitleStyle.Render(Glyphs.Crew + "YOUR CREW")
 
-	groupLine := groupModalTextStyle.Render(fmt.Sprintf("Group: %s", m.GroupName))
-	membersLine := groupModalTextStyle.Render(fmt.Sprintf("Members: %d", m.MemberCount))
+	groupLine := groupModalTextStyle.Render("Group: " + truncateString(m.GroupName, modalWidth-4-len("Group: ")))
+	members := i18n.Number(m.MemberCount)
+	if m.MaxMembers > 0 {
+		members += "/" + i18n.Number(m.MaxMembers)
+	}
+	membersLine := groupModalTextStyle.Render("Members: " + members)
 
 	// Inner code box
 	codeBox := m.renderCodeBox(m.InviteCode, modalWidth-8)
 
-	shareLine := groupModalHintStyle.Render("Share this code with friends!")
+	shareLines := []string{
+		groupModalHintStyle.Render("Friends join with: ") +
+			groupModalCommandStyle.Render(JoinCommand(m.InviteCode)),
+		groupModalHintStyle.Render("grind group invite prints a message to paste"),
+	}
+	if m.MaxMembers > 0 && m.MemberCount >= m.MaxMembers {
+		shareLines = []string{groupModalHintStyle.Render("Crew is full - no one else can join.")}
+	}
 	dismissLine := groupModalHintStyle.Render("press any key to close")
 
 	// Combine content
@@ -117,7 +134,7 @@ func (m *GroupModal) renderWithGroup(screenWidth, screenHeight int) string {
 		"",
 		codeBox,
 		"",
-		shareLine,
+		strings.Join(shareLines, "\n"),
 		"",
 		dismissLine,
 		"",
@@ -141,14 +158,14 @@ func (m *GroupModal) renderNoGroup(screenWidth, screenHeight int) string {
 	modalWidth := 42
 
 	// Build content
-	title := groupModalTitleStyle.Render("👥 YOUR CREW")
+	title := groupModalTitleStyle.Render(Glyphs.Crew + "YOUR CREW")
 
 	noGroupLine := groupModalTextStyle.Render("You're not in a crew yet!")
 
 	createCmd := groupModalHintStyle.Render("Run: ") +
 		groupModalCommandStyle.Render("grind group create <name>")
 	joinCmd := groupModalHintStyle.Render("Or:  ") +
-		groupModalCommandStyle.Render("grind group join <code>")
+		groupModalCommandStyle.Render(JoinCommand("<code>"))
 
 	dismissLine := groupModalHintStyle.Render("press any key to close")
 
@@ -180,6 +197,17 @@ func (m *GroupModal) renderNoGroup(screenWidth, screenHeight int) string {
 	)
 }
 
+// JoinCommand is the one-liner a friend runs to join with code
+func JoinCommand(code string) string {
+	return "grind join " + code
+}
+
+// InviteMessage is a ready-to-paste invite to the crew, for chat or email.
+// It's plain text so it survives any paste target.
+func InviteMessage(crew, code string) string {
+	return fmt.Sprintf("Join my crew %q on grind! Once grind is installed, run:\n\n    %s", crew, JoinCommand(code))
+}
+
 // renderCodeBox renders the invite code in a highlighted box
 func (m *GroupModal) renderCodeBox(code string, width int) string {
 	innerWidth := width - 4
@@ -188,25 +216,26 @@ func (m *GroupModal) renderCodeBox(code string, width int) string {
 	}
 
 	// Top border
-	topBorder := groupModalCodeBoxStyle.Render("┌─ INVITE CODE ")
-	remaining := innerWidth - 15
+	b := Glyphs.Square
+	topBorder := groupModalCodeBoxStyle.Render(b.TopLeft + b.Horizontal + " INVITE CODE ")
+	remaining := innerWidth - 16
 	if remaining < 0 {
 		remaining = 0
 	}
 	for i := 0; i < remaining; i++ {
-		topBorder += groupModalCodeBoxStyle.Render("─")
+		topBorder += groupModalCodeBoxStyle.Render(b.Horizontal)
 	}
-	topBorder += groupModalCodeBoxStyle.Render("┐")
+	topBorder += groupModalCodeBoxStyle.Render(b.TopRight)
 
 	// Empty line
-	emptyLine := groupModalCodeBoxStyle.Render("│")
+	emptyLine := groupModalCodeBoxStyle.Render(b.Vertical)
 	for i := 0; i < innerWidth-2; i++ {
 		emptyLine += " "
 	}
-	emptyLine += groupModalCodeBoxStyle.Render("│")
+	emptyLine += groupModalCodeBoxStyle.Render(b.Vertical)
 
 	// Code line (centered)
-	codeText := groupModalCodeStyle.Render(code)
+	codeText := FitLine(groupModalCodeStyle.Render(code), innerWidth-2)
 	codeLen := lipgloss.Width(codeText)
 	totalPadding := innerWidth - codeLen - 2
 	leftPad := totalPadding / 2
@@ -218,7 +247,7 @@ func (m *GroupModal) renderCodeBox(code string, width int) string {
 		rightPad = 0
 	}
 
-	codeLine := groupModalCodeBoxStyle.Render("│")
+	codeLine := groupModalCodeBoxStyle.Render(b.Vertical)
 	for i := 0; i < leftPad; i++ {
 		codeLine += " "
 	}
@@ -226,14 +255,14 @@ func (m *GroupModal) renderCodeBox(code string, width int) string {
 	for i := 0; i < rightPad; i++ {
 		codeLine += " "
 	}
-	codeLine += groupModalCodeBoxStyle.Render("│")
+	codeLine += groupModalCodeBoxStyle.Render(b.Vertical)
 
 	// Bottom border
-	bottomBorder := groupModalCodeBoxStyle.Render("└")
+	bottomBorder := groupModalCodeBoxStyle.Render(b.BottomLeft)
 	for i := 0; i < innerWidth-2; i++ {
-		bottomBorder += groupModalCodeBoxStyle.Render("─")
+		bottomBorder += groupModalCodeBoxStyle.Render(b.Horizontal)
 	}
-	bottomBorder += groupModalCodeBoxStyle.Render("┘")
+	bottomBorder += groupModalCodeBoxStyle.Render(b.BottomRight)
 
 	return topBorder + "\n" + emptyLine + "\n" + codeLine + "\n" + emptyLine + "\n" + bottomBorder
 }
@@ -241,16 +270,18 @@ func (m *GroupModal) renderCodeBox(code string, width int) string {
 // renderModalBox renders the modal with double border
 func (m *GroupModal) renderModalBox(content string, width int) string {
 	// Top border
-	topBorder := groupModalBorderStyle.Render("╔")
+	b := Glyphs.Double
+	topBorder := groupModalBorderStyle.Render(b.TopLeft)
 	for i := 0; i < width-2; i++ {
-		topBorder += groupModalBorderStyle.Render("═")
+		topBorder += groupModalBorderStyle.Render(b.Horizontal)
 	}
-	topBorder += groupModalBorderStyle.Render("╗")
+	topBorder += groupModalBorderStyle.Render(b.TopRight)
 
 	// Content lines
 	lines := splitLines(content)
 	var body string
 	for _, line := range lines {
+		line = FitLine(line, width-2)
 		lineLen := lipgloss.Width(line)
 		totalPadding := width - lineLen - 2
 		leftPad := totalPadding / 2
@@ -262,7 +293,7 @@ func (m *GroupModal) renderModalBox(content string, width int) string {
 			rightPad = 0
 		}
 
-		body += groupModalBorderStyle.Render("║")
+		body += groupModalBorderStyle.Render(b.Vertical)
 		for i := 0; i < leftPad; i++ {
 			body += " "
 		}
@@ -270,15 +301,15 @@ func (m *GroupModal) renderModalBox(content string, width int) string {
 		for i := 0; i < rightPad; i++ {
 			body += " "
 		}
-		body += groupModalBorderStyle.Render("║") + "\n"
+		body += groupModalBorderStyle.Render(b.Vertical) + "\n"
 	}
 
 	// Bottom border
-	bottomBorder := groupModalBorderStyle.Render("╚")
+	bottomBorder := groupModalBorderStyle.Render(b.BottomLeft)
 	for i := 0; i < width-2; i++ {
-		bottomBorder += groupModalBorderStyle.Render("═")
+		bottomBorder += groupModalBorderStyle.Render(b.Horizontal)
 	}
-	bottomBorder += groupModalBorderStyle.Render("╝")
+	bottomBorder += groupModalBorderStyle.Render(b.BottomRight)
 
 	return topBorder + "\n" + body + bottomBorder
 }
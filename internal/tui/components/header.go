@@ -2,11 +2,18 @@ package components
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 
 	"grind/internal/api"
+	"grind/internal/goals"
+	"grind/internal/i18n"
 	"grind/internal/levels"
+	"grind/internal/streaks"
 )
 
 // Header colors (referencing main tui package colors)
@@ -50,6 +57,15 @@ var (
 				Foreground(headerDimmed)
 )
 
+// headerGainFade is the color ramp the floating "+N XP" label fades through
+var headerGainFade = []lipgloss.Color{
+	lipgloss.Color("#FFFFFF"),
+	headerGreen,
+	lipgloss.Color("#03875A"),
+	lipgloss.Color("#025A3C"),
+	headerDimmed,
+}
+
 // HeaderModel represents the header HUD component
 type HeaderModel struct {
 	User      *api.User
@@ -57,6 +73,26 @@ type HeaderModel struct {
 	Level     levels.Level
 	NextLevel *levels.Level
 	Width     int
+
+	// Animation drives the XP count-up and floating gain label (optional)
+	Animation *AnimationState
+
+	// WeeklyGoal shows weekly XP as progress toward a target when set
+	WeeklyGoal int
+
+	// Compact stacks the header into short lines for narrow panes
+	Compact bool
+
+	// StreakFreezes is how many missed days a week the streak forgives
+	StreakFreezes int
+
+	// Connection shows the online/offline indicator (optional, e.g. not in
+	// local-only mode)
+	Connection *Connection
+
+	// Profile labels the panel title with the account in use, e.g.
+	// "GRIND · work"; empty for the default profile
+	Profile string
 }
 
 // NewHeader creates a new header component
@@ -89,6 +125,10 @@ func (h *HeaderModel) View() string {
 		return ""
 	}
 
+	if h.Compact {
+		return h.viewCompact()
+	}
+
 	width := h.Width
 	if width < 60 {
 		width = 60
@@ -100,29 +140,204 @@ func (h *HeaderModel) View() string {
 
 	// Combine lines
 	content := line1 + "\n" + line2
+	if event := h.renderEvent(); event != "" {
+		content += "\n   " + event
+	}
 
 	// Render with titled panel style
 	return h.renderPanel("GRIND", content, width)
 }
 
+// viewCompact renders the header as three short lines:
+//
+//	Lvl 2: DEBUGGER
+//	[████▒▒▒▒▒▒] 185 / 300 XP
+//	Rank #1 · This Week: 120 XP
+func (h *HeaderModel) viewCompact() string {
+	width := h.Width
+	if width < 40 {
+		width = 40
+	}
+
+	levelInfo := headerLevelStyle.Render(fmt.Sprintf("Lvl %d: %s", h.Level.Number, h.Level.Name))
+
+	xp := h.User.TotalXP
+	nextLevel := h.NextLevel
+	if h.Animation != nil && h.Animation.IsCountingXP() {
+		xp = h.Animation.DisplayedXP
+		nextLevel = levels.GetNextLevel(levels.GetLevel(xp))
+	}
+	barWidth := width - 24
+	var progressLine string
+	if nextLevel != nil {
+		filled := int(levels.LevelProgress(xp) * float64(barWidth))
+		progressLine = h.renderProgressBar(filled, barWidth) + " " +
+			headerXPStyle.Render(fmt.Sprintf("%s / %s XP", i18n.FormatXP(xp), i18n.FormatXP(nextLevel.MinXP)))
+	} else {
+		progressLine = h.renderProgressBar(barWidth, barWidth) + " " + headerXPStyle.Render("MAX LEVEL")
+	}
+	progressLine += h.renderGainFloat()
+
+	var parts []string
+	if h.Stats != nil && h.Stats.Week.Rank > 0 {
+		rank := fmt.Sprintf("Rank #%d", h.Stats.Week.Rank)
+		if h.Stats.Week.Rank == 1 {
+			rank += Glyphs.Crown
+		}
+		parts = append(parts, headerMutedStyle.Render(rank))
+	}
+	if streak := h.renderStreak(); streak != "" {
+		parts = append(parts, streak)
+	}
+	if h.Stats != nil {
+		if h.WeeklyGoal > 0 {
+			parts = append(parts, h.renderGoal(h.Stats.Week.XP))
+		} else {
+			parts = append(parts, headerMutedStyle.Render(fmt.Sprintf("This Week: %s XP", i18n.FormatXP(h.Stats.Week.XP))))
+		}
+	}
+	if cap := h.renderCap(); cap != "" {
+		parts = append(parts, cap)
+	}
+	// Wrap onto another line rather than overflow the narrow panel
+	sep := headerMutedStyle.Render(" " + Glyphs.Dot + " ")
+	statsLine, line := "", ""
+	for _, part := range parts {
+		switch {
+		case line == "":
+			line = part
+		case lipgloss.Width(line+sep+part) > width-4:
+			statsLine += line + "\n"
+			line = part
+		default:
+			line += sep + part
+		}
+	}
+	statsLine += line
+
+	content := levelInfo + "\n" + progressLine
+	if statsLine != "" {
+		content += "\n" + statsLine
+	}
+	if event := h.renderEvent(); event != "" {
+		content += "\n" + event
+	}
+	return h.renderPanel("GRIND", content, width)
+}
+
 // renderLevelLine renders: ⚡ Lvl 2: DEBUGGER [████▒▒▒▒▒▒] 185 / 300 XP
 func (h *HeaderModel) renderLevelLine() string {
 	// Level info
 	levelInfo := headerLevelStyle.Render(fmt.Sprintf("Lvl %d: %s", h.Level.Number, h.Level.Name))
 
+	// While counting up, the bar and total follow the animated XP so the
+	// gain is visible; the next-level target follows along across a level-up
+	xp := h.User.TotalXP
+	nextLevel := h.NextLevel
+	if h.Animation != nil && h.Animation.IsCountingXP() {
+		xp = h.Animation.DisplayedXP
+		nextLevel = levels.GetNextLevel(levels.GetLevel(xp))
+	}
+
 	// Progress bar
 	var progressBar, xpText string
-	if h.NextLevel != nil {
-		progress := levels.LevelProgress(h.User.TotalXP)
+	if nextLevel != nil {
+		progress := levels.LevelProgress(xp)
 		barWidth := 24
 		progressBar = h.renderProgressBar(int(progress*float64(barWidth)), barWidth)
-		xpText = headerXPStyle.Render(fmt.Sprintf("%d / %d XP", h.User.TotalXP, h.NextLevel.MinXP))
+		xpText = headerXPStyle.Render(fmt.Sprintf("%s / %s XP", i18n.FormatXP(xp), i18n.FormatXP(nextLevel.MinXP)))
 	} else {
 		progressBar = h.renderProgressBar(24, 24) // Full bar
 		xpText = headerXPStyle.Render("MAX LEVEL")
 	}
 
-	return fmt.Sprintf("  %s          %s %s", levelInfo, progressBar, xpText)
+	return fmt.Sprintf("  %s          %s %s%s", levelInfo, progressBar, xpText, h.renderGainFloat())
+}
+
+// renderGainFloat renders the transient "+N XP" label next to the XP total.
+// It drifts away from the bar and fades out as the animation progresses.
+func (h *HeaderModel) renderGainFloat() string {
+	if h.Animation == nil || h.Animation.GainTicks <= 0 {
+		return ""
+	}
+
+	progress := h.Animation.GetGainProgress()
+	drift := 1 + int(progress*4)
+	shade := int(progress * float64(len(headerGainFade)))
+	if shade >= len(headerGainFade) {
+		shade = len(headerGainFade) - 1
+	}
+
+	style := lipgloss.NewStyle().Bold(shade < 2).Foreground(headerGainFade[shade])
+	label := style.Render(fmt.Sprintf("+%s XP", i18n.Number(h.Animation.GainAmount)))
+	return fmt.Sprintf("%*s%s", drift, "", label)
+}
+
+// renderStreak renders "🔥 5 Day Streak", with a snowflake instead when a
+// freeze saved the streak this week, or "" with no streak
+func (h *HeaderModel) renderStreak() string {
+	if h.Stats == nil {
+		return ""
+	}
+	streak := streaks.Compute(h.Stats.RecentCompletions, h.StreakFreezes, time.Now())
+	if streak.Days == 0 {
+		return ""
+	}
+	icon := Glyphs.Streak
+	if streak.Frozen {
+		icon = Glyphs.Freeze
+	}
+	return headerStreakStyle.Render(icon + i18n.Plural("plural.dayStreak", streak.Days))
+}
+
+// renderCap renders how much XP is left under the crew's daily cap, or ""
+// when the crew has no cap
+func (h *HeaderModel) renderCap() string {
+	if h.Stats == nil {
+		return ""
+	}
+	remaining := h.Stats.Today.CapRemaining()
+	switch {
+	case remaining < 0:
+		return ""
+	case remaining == 0:
+		return headerStreakStyle.Render("daily cap reached")
+	default:
+		return headerMutedStyle.Render(fmt.Sprintf("Cap: %s XP left", i18n.Number(remaining)))
+	}
+}
+
+// renderEvent renders the banner for a running XP event, e.g.
+// "⚡ Happy Hour: ×2 XP for 42m", or "" with none
+func (h *HeaderModel) renderEvent() string {
+	event := h.Stats.ActiveEvent(time.Now())
+	if event == nil {
+		return ""
+	}
+	left := time.Until(time.UnixMilli(event.EndsAt)).Round(time.Minute)
+	if left < time.Minute {
+		left = time.Minute
+	}
+	text := fmt.Sprintf("%s %s: %s XP for %s", Glyphs.LevelUp, event.Name, Multiplier(event.Multiplier), formatLeft(left))
+	return headerXPStyle.Render(text)
+}
+
+// Multiplier formats an XP multiplier like "×2" or "×1.5"
+func Multiplier(m float64) string {
+	return Glyphs.Times + strconv.FormatFloat(m, 'f', -1, 64)
+}
+
+// formatLeft formats a whole-minute duration as "42m" or "2h 5m"
+func formatLeft(d time.Duration) string {
+	h, m := int(d.Hours()), int(d.Minutes())%60
+	switch {
+	case h == 0:
+		return fmt.Sprintf("%dm", m)
+	case m == 0:
+		return fmt.Sprintf("%dh", h)
+	default:
+		return fmt.Sprintf("%dh %dm", h, m)
+	}
 }
 
 // renderStatsLine renders: Rank #1 👑 | 🔥 5 Day Streak | 💀 Crew: 2 Active
@@ -133,30 +348,49 @@ func (h *HeaderModel) renderStatsLine() string {
 	if h.Stats != nil && h.Stats.Week.Rank > 0 {
 		rankIcon := ""
 		if h.Stats.Week.Rank == 1 {
-			rankIcon = " 👑"
+			rankIcon = Glyphs.Crown
 		}
 		parts = append(parts, headerMutedStyle.Render(fmt.Sprintf("   Rank #%d%s", h.Stats.Week.Rank, rankIcon)))
 	}
 
-	// Streak (placeholder - could add streak tracking later)
-	// parts = append(parts, headerStreakStyle.Render("🔥 5 Day Streak"))
+	// Streak
+	if streak := h.renderStreak(); streak != "" {
+		parts = append(parts, streak)
+	}
 
-	// Weekly XP
+	// Weekly XP, as goal progress when a goal is set
 	if h.Stats != nil {
-		parts = append(parts, headerMutedStyle.Render(fmt.Sprintf("This Week: %d XP", h.Stats.Week.XP)))
+		if h.WeeklyGoal > 0 {
+			parts = append(parts, h.renderGoal(h.Stats.Week.XP))
+		} else {
+			parts = append(parts, headerMutedStyle.Render(fmt.Sprintf("This Week: %s XP", i18n.FormatXP(h.Stats.Week.XP))))
+		}
 	}
 
 	// Crew status
 	if h.Stats != nil && h.Stats.Group != nil {
-		crewText := fmt.Sprintf("Crew: %d Active", h.Stats.Group.ActiveToday)
+		crewText := i18n.Tf("header.crewToday", i18n.Number(h.Stats.Group.ActiveToday), Glyphs.Dot, i18n.FormatXP(h.Stats.Group.GroupTodayXP))
 		parts = append(parts, headerMutedStyle.Render(crewText))
 	}
 
-	// Join with spacing
+	// Daily cap, if the crew has one
+	if cap := h.renderCap(); cap != "" {
+		parts = append(parts, cap)
+	}
+
+	// Join with spacing, tightened as needed to fit the panel
+	used := 0
+	for _, part := range parts {
+		used += lipgloss.Width(part)
+	}
+	gap := 14
+	if len(parts) > 1 {
+		gap = min(gap, max(2, (max(h.Width, 60)-4-used)/(len(parts)-1)))
+	}
 	result := ""
 	for i, part := range parts {
 		if i > 0 {
-			result += "              "
+			result += strings.Repeat(" ", gap)
 		}
 		result += part
 	}
@@ -164,6 +398,23 @@ func (h *HeaderModel) renderStatsLine() string {
 	return result
 }
 
+// renderGoal renders: Goal [███▒▒▒] 320 / 500 XP, with a hit badge or a
+// behind-pace nudge
+func (h *HeaderModel) renderGoal(weeklyXP int) string {
+	barWidth := 10
+	filled := barWidth * weeklyXP / h.WeeklyGoal
+	text := headerMutedStyle.Render("Goal ") + h.renderProgressBar(filled, barWidth) +
+		headerMutedStyle.Render(fmt.Sprintf(" %s / %s XP", i18n.FormatXP(weeklyXP), i18n.FormatXP(h.WeeklyGoal)))
+
+	if weeklyXP >= h.WeeklyGoal {
+		return text + headerXPStyle.Render(" HIT!")
+	}
+	if behind := goals.Behind(weeklyXP, h.WeeklyGoal, time.Now()); behind > 0 {
+		return text + headerStreakStyle.Render(fmt.Sprintf(" %s behind pace", i18n.Number(behind)))
+	}
+	return text
+}
+
 // renderProgressBar renders [████████▒▒▒▒▒▒▒▒▒▒▒▒]
 func (h *HeaderModel) renderProgressBar(filled, width int) string {
 	if filled > width {
@@ -173,10 +424,10 @@ func (h *HeaderModel) renderProgressBar(filled, width int) string {
 
 	bar := headerMutedStyle.Render("[")
 	for i := 0; i < filled; i++ {
-		bar += headerProgressFull.Render("█")
+		bar += headerProgressFull.Render(Glyphs.BarFull)
 	}
 	for i := 0; i < empty; i++ {
-		bar += headerProgressEmpty.Render("▒")
+		bar += headerProgressEmpty.Render(Glyphs.BarEmpty)
 	}
 	bar += headerMutedStyle.Render("]")
 
@@ -186,45 +437,78 @@ func (h *HeaderModel) renderProgressBar(filled, width int) string {
 // renderPanel creates the bordered panel
 func (h *HeaderModel) renderPanel(title, content string, width int) string {
 	// Top border with title
-	titlePart := "╭── " + title + " "
-	titleLen := len(titlePart)
+	b := Glyphs.Rounded
+	if h.Profile != "" {
+		title += " " + Glyphs.Dot + " " + h.Profile
+	}
+	titlePart := b.TopLeft + b.Horizontal + b.Horizontal + " " + title + " "
+	titleLen := lipgloss.Width(titlePart)
 	remainingWidth := width - titleLen - 1
+
+	// The connection indicator sits at the right end of the top border
+	var status string
+	if h.Connection != nil {
+		status = " " + h.Connection.View() + " " + headerBorderStyle.Render(b.Horizontal)
+		remainingWidth -= lipgloss.Width(status)
+	}
 	if remainingWidth < 0 {
 		remainingWidth = 0
 	}
 
 	topBorder := headerBorderStyle.Render(titlePart)
 	for i := 0; i < remainingWidth; i++ {
-		topBorder += headerBorderStyle.Render("─")
+		topBorder += headerBorderStyle.Render(b.Horizontal)
 	}
-	topBorder += headerBorderStyle.Render("╮")
+	topBorder += status + headerBorderStyle.Render(b.TopRight)
 
 	// Content lines with borders
 	lines := splitLines(content)
 	var body string
 	for _, line := range lines {
+		line = FitLine(line, width-4)
 		lineLen := lipgloss.Width(line)
 		padding := width - lineLen - 4
 		if padding < 0 {
 			padding = 0
 		}
-		body += headerBorderStyle.Render("│") + " " + line
+		body += headerBorderStyle.Render(b.Vertical) + " " + line
 		for i := 0; i < padding; i++ {
 			body += " "
 		}
-		body += " " + headerBorderStyle.Render("│") + "\n"
+		body += " " + headerBorderStyle.Render(b.Vertical) + "\n"
 	}
 
 	// Bottom border
-	bottomBorder := headerBorderStyle.Render("╰")
+	bottomBorder := headerBorderStyle.Render(b.BottomLeft)
 	for i := 0; i < width-2; i++ {
-		bottomBorder += headerBorderStyle.Render("─")
+		bottomBorder += headerBorderStyle.Render(b.Horizontal)
 	}
-	bottomBorder += headerBorderStyle.Render("╯")
+	bottomBorder += headerBorderStyle.Render(b.BottomRight)
 
 	return topBorder + "\n" + body + bottomBorder
 }
 
+// FitLine cuts a possibly styled line down to width cells, ending it with
+// an ellipsis, so content wider than a box can't push its border out of
+// line. Lines that fit are returned unchanged.
+func FitLine(line string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	if lipgloss.Width(line) <= width {
+		return line
+	}
+	return ansi.Truncate(line, width, Glyphs.Ellipsis)
+}
+
+// PadName fits a name into a fixed column of width cells: cut with an
+// ellipsis when longer, padded with spaces when shorter. Unlike %-12s it
+// counts wide runes by their cells, so columns of names stay aligned.
+func PadName(name string, width int) string {
+	name = FitLine(name, width)
+	return name + strings.Repeat(" ", max(0, width-lipgloss.Width(name)))
+}
+
 // splitLines splits a string by newlines
 func splitLines(s string) []string {
 	if s == "" {
@@ -0,0 +1,148 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"grind/internal/api"
+)
+
+// overWide is content wider than any box below: plain, styled, wide and
+// multibyte lines
+var overWide = strings.Join([]string{
+	"short",
+	strings.Repeat("ship the feature ", 10),
+	lipgloss.NewStyle().Bold(true).Render(strings.Repeat("bold ", 20)),
+	strings.Repeat("日本語", 20),
+	strings.Repeat("café crème ", 10),
+	strings.Repeat("🔥", 30),
+}, "\n")
+
+func TestFitLine(t *testing.T) {
+	styled := lipgloss.NewStyle().Bold(true).Render("shipped it")
+	tests := []struct {
+		name  string
+		line  string
+		width int
+		want  int // Width of the result
+	}{
+		{"fits", "ship", 10, 4},
+		{"exact", "ship", 4, 4},
+		{"cut", "ship the feature", 8, 8},
+		{"zero width", "ship", 0, 0},
+		{"negative width", "ship", -1, 0},
+		{"styled", styled, 6, 6},
+		{"wide runes", "日本語テキスト", 7, 7},
+		{"wide rune at the edge", "日本語", 4, 3},
+		{"emoji", "🔥🔥🔥🔥", 5, 5},
+		{"accented", "crème brûlée", 6, 6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FitLine(tt.line, tt.width)
+			if w := lipgloss.Width(got); w != tt.want {
+				t.Errorf("FitLine(%q, %d) = %q, %d cells; want %d", tt.line, tt.width, got, w, tt.want)
+			}
+			if lipgloss.Width(tt.line) <= tt.width && got != tt.line {
+				t.Errorf("FitLine changed %q, which fits", tt.line)
+			}
+		})
+	}
+}
+
+func TestPadName(t *testing.T) {
+	tests := []struct {
+		name  string
+		width int
+	}{
+		{"ada", 12},
+		{"", 12},
+		{"exactly12chr", 12},
+		{"a name far too long to fit", 12},
+		{"山田太郎さんのチーム", 12},
+		{"Zoë Ångström-Öberg", 8},
+		{"🔥🔥🔥🔥🔥🔥🔥", 5},
+	}
+	for _, tt := range tests {
+		if got := PadName(tt.name, tt.width); lipgloss.Width(got) != tt.width {
+			t.Errorf("PadName(%q, %d) = %q, %d cells", tt.name, tt.width, got, lipgloss.Width(got))
+		}
+	}
+}
+
+func TestTruncateString(t *testing.T) {
+	tests := []struct {
+		s    string
+		max  int
+		want string
+	}{
+		{"ship", 10, "ship"},
+		{"ship the feature", 8, "ship th" + Glyphs.Ellipsis},
+		{"日本語テキスト", 6, "日本" + Glyphs.Ellipsis},
+	}
+	for _, tt := range tests {
+		got := truncateString(tt.s, tt.max)
+		if got != tt.want {
+			t.Errorf("truncateString(%q, %d) = %q, want %q", tt.s, tt.max, got, tt.want)
+		}
+		if lipgloss.Width(got) > tt.max {
+			t.Errorf("truncateString(%q, %d) is %d cells", tt.s, tt.max, lipgloss.Width(got))
+		}
+	}
+}
+
+// TestBoxBorders checks every custom box renderer keeps each line at the
+// box's width when the content is wider, so the right border lines up
+func TestBoxBorders(t *testing.T) {
+	const width = 40
+	boxes := []struct {
+		name   string
+		render func() string
+	}{
+		{"header panel", func() string { return (&HeaderModel{}).renderPanel("CREW", overWide, width) }},
+		{"quest panel", func() string { return (&QuestPanelModel{}).renderPanel("QUESTS", overWide, width) }},
+		{"intel panel", func() string { return (&IntelFeedModel{}).renderPanel("INTEL", overWide, width) }},
+		{"rival modal", func() string { return (&RivalModal{}).renderModalBox(overWide, width) }},
+		{"group modal", func() string { return (&GroupModal{}).renderModalBox(overWide, width) }},
+		{"level-up modal", func() string { return (&LevelUpModal{}).renderModalBox(overWide, width) }},
+		{"catch-up modal", func() string { return (&CatchUpModal{}).renderModalBox(overWide, width) }},
+		{"invite code", func() string { return (&GroupModal{}).renderCodeBox(strings.Repeat("コード", 20), width) }},
+	}
+	for _, tt := range boxes {
+		t.Run(tt.name, func(t *testing.T) {
+			lines := strings.Split(tt.render(), "\n")
+			want := lipgloss.Width(lines[0])
+			for i, line := range lines {
+				if w := lipgloss.Width(line); w != want {
+					t.Errorf("line %d is %d cells, top border is %d: %q", i, w, want, line)
+				}
+			}
+		})
+	}
+}
+
+func TestInsightBoxBorders(t *testing.T) {
+	f := &IntelFeedModel{Width: 48, AIInsight: strings.Repeat("日本語 ", 30) + strings.Repeat("x", 80)}
+	lines := strings.Split(f.renderInsightBox(), "\n")
+	want := lipgloss.Width(lines[0])
+	for i, line := range lines {
+		if w := lipgloss.Width(line); w != want {
+			t.Errorf("line %d is %d cells, top border is %d: %q", i, w, want, line)
+		}
+	}
+}
+
+func TestStatsLineCrewXP(t *testing.T) {
+	h := &HeaderModel{Width: 120, Stats: &api.DashboardStats{Group: &api.GroupStats{ActiveToday: 3, GroupTodayXP: 420}}}
+	if line := h.renderStatsLine(); !strings.Contains(line, "Crew: 3 Active · 420 XP Today") {
+		t.Errorf("stats line = %q, want the crew's XP today", line)
+	}
+
+	SetASCII(true)
+	defer SetASCII(false)
+	if line := h.renderStatsLine(); !strings.Contains(line, "Crew: 3 Active - 420 XP Today") {
+		t.Errorf("ASCII stats line = %q", line)
+	}
+}
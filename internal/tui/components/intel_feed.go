@@ -2,11 +2,13 @@ package components
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
 
 	"grind/internal/api"
+	"grind/internal/i18n"
 )
 
 // Intel feed colors
@@ -26,6 +28,9 @@ var (
 	intelBorderStyle = lipgloss.NewStyle().
 				Foreground(intelSlate)
 
+	intelFocusBorderStyle = lipgloss.NewStyle().
+				Foreground(intelCyan)
+
 	intelTitleStyle = lipgloss.NewStyle().
 			Bold(true).
 			Foreground(intelGold)
@@ -47,6 +52,16 @@ var (
 				Bold(true).
 				Foreground(intelCyan)
 
+	intelSelectedStyle = lipgloss.NewStyle().
+				Bold(true).
+				Foreground(intelGold)
+
+	intelReactionStyle = lipgloss.NewStyle().
+				Foreground(intelSlate)
+
+	intelInviteStyle = lipgloss.NewStyle().
+				Foreground(intelCyan)
+
 	// Insight box styles
 	insightBorderStyle = lipgloss.NewStyle().
 				Foreground(intelNeonBlue)
@@ -79,17 +94,82 @@ var (
 
 	leaderNormalStyle = lipgloss.NewStyle().
 				Foreground(intelSlate)
+
+	rankUpStyle = lipgloss.NewStyle().
+			Foreground(intelGreen)
+
+	rankDownStyle = lipgloss.NewStyle().
+			Foreground(intelRed)
 )
 
+// FeedItems is how many recent activities the feed shows
+const FeedItems = 4
+
+// LeaderboardFilter narrows the leaderboard client-side. The zero value
+// shows everyone.
+type LeaderboardFilter struct {
+	ActiveToday bool // Only members who added or finished a quest today
+	MinLevel    int  // Only members at or above this level, 0 for any
+}
+
+// IsSet reports whether the filter hides anyone
+func (lf LeaderboardFilter) IsSet() bool {
+	return lf.ActiveToday || lf.MinLevel > 0
+}
+
+// Apply returns the entries that pass the filter, keeping their real ranks,
+// and how many were hidden
+func (lf LeaderboardFilter) Apply(entries []api.LeaderboardEntry, now time.Time) ([]api.LeaderboardEntry, int) {
+	if !lf.IsSet() {
+		return entries, 0
+	}
+	y, m, d := now.Date()
+	midnight := time.Date(y, m, d, 0, 0, 0, 0, now.Location()).UnixMilli()
+
+	var kept []api.LeaderboardEntry
+	for _, e := range entries {
+		if lf.ActiveToday && e.LastActiveAt < midnight {
+			continue
+		}
+		if e.Level < lf.MinLevel {
+			continue
+		}
+		kept = append(kept, e)
+	}
+	return kept, len(entries) - len(kept)
+}
+
+// Label describes the filter for the leaderboard title, e.g. "active · L3+"
+func (lf LeaderboardFilter) Label() string {
+	var parts []string
+	if lf.ActiveToday {
+		parts = append(parts, i18n.T("panel.filterActive"))
+	}
+	if lf.MinLevel > 0 {
+		parts = append(parts, fmt.Sprintf("L%d+", lf.MinLevel))
+	}
+	return strings.Join(parts, " "+Glyphs.Dot+" ")
+}
+
 // IntelFeedModel represents the intel/activity feed component
 type IntelFeedModel struct {
 	Activities  []api.Activity
 	Leaderboard []api.LeaderboardEntry
+	Filter      LeaderboardFilter // Client-side leaderboard filter
 	AIInsight   string
 	InsightType string // "rivalry", "analyst", or "stoic"
 	CurrentUser string
+	AllTime     bool   // Rank by total XP instead of weekly XP
+	Selected    int    // Index of the activity under the cursor, or -1
+	Focused     bool   // Feed has keyboard focus
+	MemberCount int    // Crew size from the dashboard stats, 0 if unknown
+	InviteCode  string // Shown in the invite nudge while the crew is too small to compete
 	Width       int
 	Height      int
+
+	// InsightSpinner, when set, replaces the insight text while a new one
+	// is being fetched (optional)
+	InsightSpinner string
 }
 
 // NewIntelFeed creates a new intel feed component
@@ -99,6 +179,7 @@ func NewIntelFeed(activities []api.Activity, leaderboard []api.LeaderboardEntry,
 		Leaderboard: leaderboard,
 		AIInsight:   insight,
 		CurrentUser: currentUser,
+		Selected:    -1,
 		Width:       width,
 		Height:      height,
 	}
@@ -123,7 +204,7 @@ func (f *IntelFeedModel) View() string {
 	var content string
 
 	// Activity feed (top section)
-	content += f.renderActivityFeed(4) // Show 4 recent activities
+	content += f.renderActivityFeed(FeedItems)
 
 	// AI Insight box (if available)
 	if f.AIInsight != "" {
@@ -133,13 +214,13 @@ func (f *IntelFeedModel) View() string {
 	// Mini leaderboard
 	content += "\n" + f.renderLeaderboard(3) // Show top 3
 
-	return f.renderPanel("INTEL FEED", content, width)
+	return f.renderPanel(i18n.T("panel.intel"), content, width)
 }
 
 // renderActivityFeed renders recent activity in kill-feed style
 func (f *IntelFeedModel) renderActivityFeed(maxItems int) string {
 	if len(f.Activities) == 0 {
-		return intelBorderStyle.Render("no activity yet")
+		return intelBorderStyle.Render(i18n.T("panel.noActivity"))
 	}
 
 	var lines string
@@ -148,31 +229,55 @@ func (f *IntelFeedModel) renderActivityFeed(maxItems int) string {
 		count = maxItems
 	}
 
-	for _, activity := range f.Activities[:count] {
-		lines += f.renderActivity(activity) + "\n"
+	for i, activity := range f.Activities[:count] {
+		item := f.renderActivity(activity)
+		if i == f.Selected {
+			item = intelSelectedStyle.Render(Glyphs.Selected) + item
+		} else if f.Selected >= 0 {
+			item = " " + item // Keep items aligned while the cursor is in the feed
+		}
+		lines += item + "\n"
 	}
 
 	return lines
 }
 
+// activityNameWidth caps a member's name in a feed item, so the XP and
+// reactions after it stay inside the panel
+const activityNameWidth = 12
+
+// SelectedFullName returns the whole name of the member behind the selected
+// feed item when the feed had to cut it short, and "" otherwise
+func (f *IntelFeedModel) SelectedFullName() string {
+	if f.Selected < 0 || f.Selected >= len(f.Activities) {
+		return ""
+	}
+	name := f.Activities[f.Selected].UserName
+	if lipgloss.Width(name) <= activityNameWidth {
+		return ""
+	}
+	return name
+}
+
 // renderActivity renders a single activity item
 func (f *IntelFeedModel) renderActivity(a api.Activity) string {
 	// Format timestamp
 	t := time.UnixMilli(a.CreatedAt)
 	timestamp := intelTimestampStyle.Render(fmt.Sprintf("[%s]", t.Format("15:04")))
 
-	// Get user initials (first 2 chars)
-	userName := a.UserName
+	// Long names are cut to fit; SelectedFullName shows them whole
+	userName := truncateString(a.UserName, activityNameWidth)
 	if userName == "" {
 		userName = "??"
 	}
 
 	switch a.Type {
 	case "quest_completed":
-		line1 := fmt.Sprintf("%s %s +%s",
+		line1 := fmt.Sprintf("%s %s +%s%s",
 			timestamp,
 			intelUserStyle.Render(userName),
-			intelXPStyle.Render(fmt.Sprintf("%d XP", a.XP)))
+			intelXPStyle.Render(fmt.Sprintf("%s XP", i18n.FormatXP(a.XP))),
+			renderReactions(a))
 		line2 := "        " + intelQuestStyle.Render(fmt.Sprintf("\"%s\"", truncateString(a.QuestTitle, 16)))
 		return line1 + "\n" + line2
 
@@ -182,6 +287,12 @@ func (f *IntelFeedModel) renderActivity(a api.Activity) string {
 			intelUserStyle.Render(userName)) + "\n" +
 			"        " + intelQuestStyle.Render(fmt.Sprintf("\"%s\"", truncateString(a.QuestTitle, 16)))
 
+	case "quest_abandoned":
+		return fmt.Sprintf("%s %s dropped",
+			timestamp,
+			intelUserStyle.Render(userName)) + "\n" +
+			"        " + intelTimestampStyle.Render(fmt.Sprintf("\"%s\"", truncateString(a.QuestTitle, 16)))
+
 	case "quest_created":
 		return fmt.Sprintf("%s %s added quest",
 			timestamp,
@@ -201,6 +312,21 @@ func (f *IntelFeedModel) renderActivity(a api.Activity) string {
 	}
 }
 
+// renderReactions renders reaction counts as " 🔥2 👏1", or "" if none
+func renderReactions(a api.Activity) string {
+	if len(a.Reactions) == 0 {
+		return ""
+	}
+	counts := a.ReactionCounts()
+	out := ""
+	for _, emoji := range api.ReactionEmoji {
+		if counts[emoji] > 0 {
+			out += fmt.Sprintf(" %s%d", Glyphs.Reactions[emoji], counts[emoji])
+		}
+	}
+	return intelReactionStyle.Render(out)
+}
+
 // getInsightStyles returns dynamic styles based on insight type
 func (f *IntelFeedModel) getInsightStyles() (borderStyle, titleStyle lipgloss.Style, icon, header string) {
 	switch f.InsightType {
@@ -208,42 +334,56 @@ func (f *IntelFeedModel) getInsightStyles() (borderStyle, titleStyle lipgloss.St
 		// Red - competitive alert when behind
 		borderStyle = lipgloss.NewStyle().Foreground(intelRed)
 		titleStyle = lipgloss.NewStyle().Bold(true).Foreground(intelRed)
-		icon = "⚠"
+		icon = Glyphs.Warning
 		header = "RIVALRY ALERT"
 	case "analyst":
 		// Blue - data-driven analysis
 		borderStyle = lipgloss.NewStyle().Foreground(intelNeonBlue)
 		titleStyle = lipgloss.NewStyle().Bold(true).Foreground(intelNeonBlue)
-		icon = "📊"
+		icon = Glyphs.Stats
 		header = "SYSTEM ANALYSIS"
 	default: // "stoic" or empty
 		// Gold - motivational/cyberpunk vibes
 		borderStyle = lipgloss.NewStyle().Foreground(intelGold)
 		titleStyle = lipgloss.NewStyle().Bold(true).Foreground(intelGold)
-		icon = "💀"
+		icon = Glyphs.Skull
 		header = "GRIND MODE"
 	}
 	return
 }
 
-// wrapText wraps text to fit within maxWidth, returning multiple lines
+// wrapText wraps text to fit within maxWidth cells, returning multiple
+// lines. It breaks between words, and only splits a word that is wider
+// than a whole line on its own. Empty text has no lines; a width of zero
+// or less leaves the text on one line.
 func wrapText(text string, maxWidth int) []string {
+	words := strings.Fields(text)
+	if len(words) == 0 {
+		return nil
+	}
 	if maxWidth <= 0 {
-		return []string{text}
+		return []string{strings.Join(words, " ")}
 	}
 
 	var lines []string
-	words := []rune(text)
 	currentLine := ""
-
-	for _, r := range words {
-		test := currentLine + string(r)
-		if len(test) > maxWidth && currentLine != "" {
+	for _, word := range words {
+		if currentLine != "" && lipgloss.Width(currentLine)+1+lipgloss.Width(word) <= maxWidth {
+			currentLine += " " + word
+			continue
+		}
+		if currentLine != "" {
 			lines = append(lines, currentLine)
-			currentLine = string(r)
-		} else {
-			currentLine = test
+			currentLine = ""
 		}
+
+		// Hard-wrap a word too long for any line
+		for lipgloss.Width(word) > maxWidth {
+			head, tail := splitAtWidth(word, maxWidth)
+			lines = append(lines, head)
+			word = tail
+		}
+		currentLine = word
 	}
 	if currentLine != "" {
 		lines = append(lines, currentLine)
@@ -252,6 +392,20 @@ func wrapText(text string, maxWidth int) []string {
 	return lines
 }
 
+// splitAtWidth splits s after as many runes as fit in width cells, always
+// taking at least one so a wide rune can't stall the split
+func splitAtWidth(s string, width int) (string, string) {
+	used := 0
+	for i, r := range s {
+		w := lipgloss.Width(string(r))
+		if used+w > width && i > 0 {
+			return s[:i], s[i:]
+		}
+		used += w
+	}
+	return s, ""
+}
+
 // renderInsightBox renders the AI insight in a nested box with dynamic styling
 func (f *IntelFeedModel) renderInsightBox() string {
 	innerWidth := f.Width - 8 // Account for outer panel borders and padding
@@ -262,39 +416,44 @@ func (f *IntelFeedModel) renderInsightBox() string {
 	// Get dynamic styles based on insight type
 	borderStyle, titleStyle, icon, header := f.getInsightStyles()
 
-	// Title line: "🤖 GEMINI OS"
-	title := "🤖 GEMINI OS"
+	// Title line: "🤖 GEMINI OS". A rivalry alert gets a double border in
+	// color-safe mode, so it stands out without the red.
+	b := Glyphs.Square
+	if ColorSafe && f.InsightType == "rivalry" {
+		b = Glyphs.Double
+	}
+	title := Glyphs.AI + "GEMINI OS"
 
 	// Build the box with dynamic border color
-	topBorder := borderStyle.Render("┌─ ") +
+	topBorder := borderStyle.Render(b.TopLeft+b.Horizontal+" ") +
 		titleStyle.Render(title) +
 		borderStyle.Render(" ")
 
 	// Fill remaining top border
-	titleLen := lipgloss.Width("┌─ " + title + " ")
-	remaining := innerWidth - titleLen
+	titleLen := lipgloss.Width(b.TopLeft + b.Horizontal + " " + title + " ")
+	remaining := innerWidth - titleLen - 1
 	if remaining < 0 {
 		remaining = 0
 	}
 	for i := 0; i < remaining; i++ {
-		topBorder += borderStyle.Render("─")
+		topBorder += borderStyle.Render(b.Horizontal)
 	}
-	topBorder += borderStyle.Render("┐")
+	topBorder += borderStyle.Render(b.TopRight)
 
 	// Header line with icon (e.g., "⚠ RIVALRY ALERT")
-	headerLine := borderStyle.Render("│ ") +
-		titleStyle.Render(icon+" "+header)
+	headerLine := borderStyle.Render(b.Vertical+" ") +
+		FitLine(titleStyle.Render(icon+" "+header), innerWidth-3)
 
 	// Pad header to width
 	headerLen := lipgloss.Width(headerLine)
-	headerPadding := innerWidth - headerLen + 1
+	headerPadding := innerWidth - headerLen - 1
 	if headerPadding < 0 {
 		headerPadding = 0
 	}
 	for i := 0; i < headerPadding; i++ {
 		headerLine += " "
 	}
-	headerLine += borderStyle.Render("│")
+	headerLine += borderStyle.Render(b.Vertical)
 
 	// Content - wrap insight text across multiple lines
 	insightText := f.AIInsight
@@ -302,32 +461,37 @@ func (f *IntelFeedModel) renderInsightBox() string {
 
 	// Wrap text to multiple lines
 	wrappedLines := wrapText(insightText, maxLineWidth)
+	quoted := true
+	if f.InsightSpinner != "" {
+		wrappedLines = []string{f.InsightSpinner + " " + i18n.T("panel.rerolling")}
+		quoted = false
+	}
 
 	// Build content lines with quotes
 	var contentLines string
 	for i, line := range wrappedLines {
 		prefix := " "
 		suffix := " "
-		if i == 0 {
+		if i == 0 && quoted {
 			prefix = "\""
 		}
-		if i == len(wrappedLines)-1 {
+		if i == len(wrappedLines)-1 && quoted {
 			suffix = "\""
 		}
 
-		contentLine := borderStyle.Render("│ ") +
-			insightTextStyle.Render(prefix+line+suffix)
+		contentLine := borderStyle.Render(b.Vertical+" ") +
+			FitLine(insightTextStyle.Render(prefix+line+suffix), innerWidth-3)
 
 		// Pad content to width
 		contentLen := lipgloss.Width(contentLine)
-		padding := innerWidth - contentLen + 1
+		padding := innerWidth - contentLen - 1
 		if padding < 0 {
 			padding = 0
 		}
 		for j := 0; j < padding; j++ {
 			contentLine += " "
 		}
-		contentLine += borderStyle.Render("│")
+		contentLine += borderStyle.Render(b.Vertical)
 		contentLines += contentLine + "\n"
 	}
 
@@ -337,31 +501,47 @@ func (f *IntelFeedModel) renderInsightBox() string {
 	}
 
 	// Bottom border
-	bottomBorder := borderStyle.Render("└")
+	bottomBorder := borderStyle.Render(b.BottomLeft)
 	for i := 0; i < innerWidth-2; i++ {
-		bottomBorder += borderStyle.Render("─")
+		bottomBorder += borderStyle.Render(b.Horizontal)
 	}
-	bottomBorder += borderStyle.Render("┘")
+	bottomBorder += borderStyle.Render(b.BottomRight)
 
 	return topBorder + "\n" + headerLine + "\n" + contentLines + "\n" + bottomBorder
 }
 
 // renderLeaderboard renders a mini leaderboard
 func (f *IntelFeedModel) renderLeaderboard(maxEntries int) string {
-	header := leaderTitleStyle.Render("🏆 LEADERBOARD")
+	title := Glyphs.Leaderboard + i18n.Tf("panel.leaderboardWeek", Glyphs.Dot)
+	if f.AllTime {
+		title = Glyphs.Leaderboard + i18n.Tf("panel.leaderboardAll", Glyphs.Dot)
+	}
+	if f.Filter.IsSet() {
+		title += " " + Glyphs.Dot + " " + f.Filter.Label()
+	}
+	header := leaderTitleStyle.Render(title)
 
-	if len(f.Leaderboard) == 0 {
-		return header + "\n" + intelBorderStyle.Render("no rankings yet")
+	entries, hidden := f.Filter.Apply(f.Leaderboard, time.Now())
+	var hiddenLine string
+	if hidden > 0 {
+		hiddenLine = intelTimestampStyle.Render(i18n.Tf("panel.hidden", i18n.Number(hidden))) + "\n"
+	}
+
+	if len(entries) == 0 {
+		if hidden > 0 {
+			return header + "\n" + intelBorderStyle.Render(i18n.T("panel.noMatches")) + "\n" + hiddenLine
+		}
+		return header + "\n" + intelBorderStyle.Render(i18n.T("panel.noRankings")) + "\n" + f.renderInviteNudge()
 	}
 
 	lines := header + "\n"
-	count := len(f.Leaderboard)
+	count := len(entries)
 	if count > maxEntries {
 		count = maxEntries
 	}
 
-	for i, entry := range f.Leaderboard[:count] {
-		rank := i + 1
+	for _, entry := range entries[:count] {
+		rank := entry.Rank
 		var rankStyle lipgloss.Style
 
 		switch rank {
@@ -381,51 +561,109 @@ func (f *IntelFeedModel) renderLeaderboard(maxEntries int) string {
 			name = "You"
 		}
 
-		lines += rankStyle.Render(fmt.Sprintf("%d. %s (%d XP)", rank, name, entry.WeeklyXP)) + "\n"
+		xp := entry.WeeklyXP
+		if f.AllTime {
+			xp = entry.TotalXP
+		}
+
+		// Cut the name, not the XP, when the row is too wide
+		prefix := fmt.Sprintf("%d. ", rank)
+		suffix := fmt.Sprintf(" (%s XP)", i18n.FormatXP(xp))
+		delta := renderRankDelta(entry.RankDelta)
+		nameWidth := f.contentWidth() - lipgloss.Width(prefix+suffix) - 1 - lipgloss.Width(delta)
+		name = truncateString(name, max(nameWidth, minNameWidth))
+
+		lines += rankStyle.Render(prefix+name+suffix) + " " + delta + "\n"
 	}
 
-	return lines
+	if f.lonely() {
+		lines += f.renderInviteNudge()
+	}
+	return lines + hiddenLine
+}
+
+// lonely reports whether the crew has no one to compete with yet. A filter
+// can hide members, so only the unfiltered board and crew size count.
+func (f *IntelFeedModel) lonely() bool {
+	return len(f.Leaderboard) < 2 && f.MemberCount < 2
+}
+
+// renderInviteNudge suggests inviting friends, with the join command when
+// the invite code is known
+func (f *IntelFeedModel) renderInviteNudge() string {
+	nudge := intelTimestampStyle.Render(i18n.T("panel.inviteToCompete")) + "\n"
+	if f.InviteCode != "" {
+		nudge += intelInviteStyle.Render(JoinCommand(f.InviteCode)) + "\n"
+	}
+	return nudge
+}
+
+// renderRankDelta renders ▲N / ▼N / — for a leaderboard rank change
+func renderRankDelta(delta int) string {
+	switch {
+	case delta > 0:
+		return rankUpStyle.Render(fmt.Sprintf("%s%d", Glyphs.RankUp, delta))
+	case delta < 0:
+		return rankDownStyle.Render(fmt.Sprintf("%s%d", Glyphs.RankDown, -delta))
+	default:
+		return intelTimestampStyle.Render(Glyphs.RankSame)
+	}
+}
+
+// minNameWidth keeps a few cells of a name even in the narrowest rows
+const minNameWidth = 4
+
+// contentWidth is how many cells a line inside the panel can use
+func (f *IntelFeedModel) contentWidth() int {
+	return max(f.Width, 36) - 4
 }
 
 // renderPanel creates the bordered panel with title
 func (f *IntelFeedModel) renderPanel(title, content string, width int) string {
+	// The focused panel gets a highlighted border
+	border := intelBorderStyle
+	if f.Focused {
+		border = intelFocusBorderStyle
+	}
+
 	// Top border with title and icon
-	titleWithIcon := "📡 " + title
-	titlePart := "╭─ " + titleWithIcon + " "
+	b := Glyphs.Rounded
+	titlePart := b.TopLeft + b.Horizontal + " " + Glyphs.Intel + title + " "
 	titleLen := lipgloss.Width(titlePart)
 	remainingWidth := width - titleLen - 1
 	if remainingWidth < 0 {
 		remainingWidth = 0
 	}
 
-	topBorder := intelTitleStyle.Render("╭─ " + titleWithIcon + " ")
+	topBorder := intelTitleStyle.Render(titlePart)
 	for i := 0; i < remainingWidth; i++ {
-		topBorder += intelBorderStyle.Render("─")
+		topBorder += border.Render(b.Horizontal)
 	}
-	topBorder += intelBorderStyle.Render("╮")
+	topBorder += border.Render(b.TopRight)
 
 	// Content lines with borders
 	lines := splitLines(content)
 	var body string
 	for _, line := range lines {
+		line = FitLine(line, width-4)
 		lineLen := lipgloss.Width(line)
 		padding := width - lineLen - 4
 		if padding < 0 {
 			padding = 0
 		}
-		body += intelBorderStyle.Render("│") + " " + line
+		body += border.Render(b.Vertical) + " " + line
 		for i := 0; i < padding; i++ {
 			body += " "
 		}
-		body += " " + intelBorderStyle.Render("│") + "\n"
+		body += " " + border.Render(b.Vertical) + "\n"
 	}
 
 	// Bottom border
-	bottomBorder := intelBorderStyle.Render("╰")
+	bottomBorder := border.Render(b.BottomLeft)
 	for i := 0; i < width-2; i++ {
-		bottomBorder += intelBorderStyle.Render("─")
+		bottomBorder += border.Render(b.Horizontal)
 	}
-	bottomBorder += intelBorderStyle.Render("╯")
+	bottomBorder += border.Render(b.BottomRight)
 
 	return topBorder + "\n" + body + bottomBorder
 }
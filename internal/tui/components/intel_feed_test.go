@@ -0,0 +1,73 @@
+package components
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestWrapText(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		width int
+		want  []string
+	}{
+		{"empty", "", 10, nil},
+		{"only spaces", "   ", 10, nil},
+		{"zero width", "ship the  feature", 0, []string{"ship the feature"}},
+		{"negative width", "ship it", -3, []string{"ship it"}},
+		{"fits", "ship it", 10, []string{"ship it"}},
+		{"exact width", "ship it now", 11, []string{"ship it now"}},
+		{"exact width lines", "abcd efgh ij", 4, []string{"abcd", "efgh", "ij"}},
+		{"wraps at words", "ran ten km today", 7, []string{"ran ten", "km", "today"}},
+		{"long word", "supercalifragilistic", 8, []string{"supercal", "ifragili", "stic"}},
+		{"long word mid line", "a verylongword b", 5, []string{"a", "veryl", "ongwo", "rd b"}},
+		{"wide runes", "日本語テキスト", 6, []string{"日本語", "テキス", "ト"}},
+		{"wide rune wider than line", "日本", 1, []string{"日", "本"}},
+		{"multibyte narrow", "café crème brûlée", 10, []string{"café crème", "brûlée"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := wrapText(tt.text, tt.width)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("wrapText(%q, %d) = %q, want %q", tt.text, tt.width, got, tt.want)
+			}
+			if tt.width <= 0 {
+				return
+			}
+			for _, line := range got {
+				// A single wide rune may overflow a one-cell line
+				if w := lipgloss.Width(line); w > tt.width && len([]rune(line)) > 1 {
+					t.Errorf("line %q is %d cells, over %d", line, w, tt.width)
+				}
+			}
+		})
+	}
+}
+
+func TestSplitAtWidth(t *testing.T) {
+	tests := []struct {
+		name       string
+		s          string
+		width      int
+		head, tail string
+	}{
+		{"empty", "", 3, "", ""},
+		{"fits", "abc", 3, "abc", ""},
+		{"splits", "abcdef", 4, "abcd", "ef"},
+		{"zero width takes one rune", "abc", 0, "a", "bc"},
+		{"wide rune", "日本語", 3, "日", "本語"},
+		{"wide rune over width", "日本", 1, "日", "本"},
+		{"accented", "éèêë", 2, "éè", "êë"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			head, tail := splitAtWidth(tt.s, tt.width)
+			if head != tt.head || tail != tt.tail {
+				t.Errorf("splitAtWidth(%q, %d) = %q, %q; want %q, %q", tt.s, tt.width, head, tail, tt.head, tt.tail)
+			}
+		})
+	}
+}
@@ -2,10 +2,12 @@ package components
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 
 	"grind/internal/api"
+	"grind/internal/i18n"
 )
 
 // Quest panel colors
@@ -27,6 +29,9 @@ var (
 				Bold(true).
 				Foreground(questGold)
 
+	questFocusBorderStyle = lipgloss.NewStyle().
+				Foreground(questNeonBlue)
+
 	questSelectionBorder = lipgloss.NewStyle().
 				Foreground(questNeonBlue).
 				Bold(true)
@@ -42,6 +47,10 @@ var (
 				Foreground(questDimmed).
 				Strikethrough(true)
 
+	questAbandonedStyle = lipgloss.NewStyle().
+				Foreground(questDimmed).
+				Italic(true)
+
 	questXPBadgeStyle = lipgloss.NewStyle().
 				Foreground(questGold).
 				Bold(true)
@@ -52,13 +61,31 @@ var (
 
 	questRewardStyle = lipgloss.NewStyle().
 				Foreground(questSlate)
+
+	questDetailStyle = lipgloss.NewStyle().
+				Foreground(questSlate).
+				Italic(true)
+
+	// Completion flash, from the first frame to the last
+	questFlashInvertStyle = lipgloss.NewStyle().
+				Bold(true).
+				Foreground(lipgloss.Color("#000000")).
+				Background(questGreen)
+
+	questFlashBrightStyle = lipgloss.NewStyle().
+				Bold(true).
+				Foreground(questGreen)
+
+	questFlashFadeStyle = lipgloss.NewStyle().
+				Bold(true).
+				Foreground(questWhite)
 )
 
-// Quest status icons
+// Quest status icons (in-progress and completed live in Glyphs)
 const (
-	IconPending    = "[ ]"
-	IconInProgress = "[●]"
-	IconCompleted  = "[✔]"
+	IconPending   = "[ ]"
+	IconSnoozed   = "[z]"
+	IconAbandoned = "[-]"
 )
 
 // QuestPanelModel represents the quest list component
@@ -66,8 +93,22 @@ type QuestPanelModel struct {
 	Quests   []api.Quest
 	Selected int
 	Focused  bool
+	Expanded bool // Show notes and AI reasoning under the selected quest
 	Width    int
 	Height   int
+
+	// Animation drives the completion flash (optional)
+	Animation *AnimationState
+
+	// Event badges unfinished quests' XP with its multiplier (optional)
+	Event *api.XPEvent
+
+	// EarnedToday is the XP earned today, for the footer
+	EarnedToday int
+
+	// Timers shows time left on in-progress quests: quest ID → when it's
+	// due, in Unix seconds (optional)
+	Timers map[string]int64
 }
 
 // NewQuestPanel creates a new quest panel component
@@ -99,8 +140,8 @@ func (q *QuestPanelModel) View() string {
 	var content string
 
 	if len(q.Quests) == 0 {
-		content = questPanelBorderStyle.Render("no quests yet\n")
-		content += questPanelBorderStyle.Render("add one below!")
+		content = questPanelBorderStyle.Render(i18n.T("panel.noQuestsYet") + "\n")
+		content += questPanelBorderStyle.Render(i18n.T("panel.addOneBelow"))
 	} else {
 		for i, quest := range q.Quests {
 			isSelected := q.Focused && i == q.Selected
@@ -108,14 +149,17 @@ func (q *QuestPanelModel) View() string {
 			content += questLine + "\n"
 		}
 
-		// Add potential XP summary
+		// Today's progress: earned so far, and what's still on the table
 		potentialXP := q.calculatePotentialXP()
-		if potentialXP > 0 {
-			content += "\n" + questRewardStyle.Render(fmt.Sprintf("Potential: +%d XP", potentialXP))
+		if q.EarnedToday > 0 || potentialXP > 0 {
+			content += "\n" + questRewardStyle.Render(i18n.T("panel.earned")) +
+				questXPCompletedStyle.Render(fmt.Sprintf("+%s", i18n.FormatXP(q.EarnedToday))) +
+				questRewardStyle.Render(" / "+i18n.T("panel.potentialLabel")) +
+				questXPBadgeStyle.Render(fmt.Sprintf("+%s XP", i18n.FormatXP(potentialXP)))
 		}
 	}
 
-	return q.renderPanel("ACTIVE QUESTS", content, width)
+	return q.renderPanel(i18n.T("panel.quests"), content, width)
 }
 
 // renderQuest renders a single quest item
@@ -123,7 +167,7 @@ func (q *QuestPanelModel) renderQuest(quest api.Quest, idx int, isSelected bool)
 	// Selection indicator
 	var prefix string
 	if isSelected {
-		prefix = questSelectionBorder.Render("┃") + " "
+		prefix = questSelectionBorder.Render(Glyphs.Selected) + " "
 	} else {
 		prefix = "  "
 	}
@@ -137,108 +181,248 @@ func (q *QuestPanelModel) renderQuest(quest api.Quest, idx int, isSelected bool)
 	case "pending":
 		icon = IconPending
 		titleStyle = questPendingStyle
-		xpStyle = questXPBadgeStyle
+		xpStyle = xpTierStyle(quest.XP)
 	case "in_progress":
-		icon = IconInProgress
+		icon = Glyphs.InProgress
 		titleStyle = questInProgressStyle
-		xpStyle = questXPBadgeStyle
+		xpStyle = xpTierStyle(quest.XP)
 	case "completed":
-		icon = IconCompleted
+		icon = Glyphs.Completed
 		titleStyle = questCompletedStyle
 		xpStyle = questXPCompletedStyle
+	case "abandoned":
+		icon = IconAbandoned
+		titleStyle = questAbandonedStyle
+		xpStyle = questAbandonedStyle
 	default:
 		icon = IconPending
 		titleStyle = questPendingStyle
-		xpStyle = questXPBadgeStyle
+		xpStyle = xpTierStyle(quest.XP)
+	}
+
+	// Snoozed quests (only visible in all-quest views) get a distinct badge
+	if quest.IsSnoozed() {
+		icon = IconSnoozed
+		titleStyle = questRewardStyle
 	}
 
-	// Title (truncated if needed)
-	title := truncateString(quest.Title, 20)
-	styledTitle := titleStyle.Render(title)
+	// A just-completed quest flashes: inverted, then bright, then fading
+	if flash, ok := q.flashStyle(quest); ok {
+		titleStyle = flash
+		xpStyle = flash
+	}
+
+	// Title: the selected quest wraps so long titles stay readable,
+	// the rest are truncated for density
+	var titleLines []string
+	if isSelected {
+		titleLines = wrapText(quest.Title, q.titleWrapWidth())
+	}
+	if len(titleLines) == 0 {
+		titleLines = []string{truncateString(quest.Title, 20)}
+	}
 
 	// First line: icon + title
-	line1 := prefix + icon + " " + styledTitle
+	line1 := prefix + icon + " " + titleStyle.Render(titleLines[0])
 
-	// Second line: XP reward (indented)
+	// Second line: XP reward (indented), then the status in words when
+	// color-safe labels are on. Dropped quests already say so.
 	var line2 string
-	if quest.Status == "completed" {
-		line2 = "      " + xpStyle.Render(fmt.Sprintf("+%d XP", quest.XP))
-	} else {
-		line2 = "      " + questRewardStyle.Render("Reward: ") + xpStyle.Render(fmt.Sprintf("%d XP", quest.XP))
+	switch quest.Status {
+	case "completed":
+		line2 = "      " + xpStyle.Render(fmt.Sprintf("+%s XP", i18n.FormatXP(quest.XP)))
+	case "abandoned":
+		line2 = "      " + xpStyle.Render(i18n.T("panel.abandoned"))
+	default:
+		line2 = "      " + questRewardStyle.Render("Reward: ") + xpStyle.Render(fmt.Sprintf("%s XP", i18n.FormatXP(quest.XP)))
+		if q.Event != nil {
+			line2 += " " + xpStyle.Render(Multiplier(q.Event.Multiplier))
+		}
+	}
+	if quest.Status != "abandoned" {
+		line2 += questRewardStyle.Render(SubtaskBadge(quest) + StatusLabel(quest.Status, quest.IsSnoozed()))
+	}
+	if due, ok := q.Timers[quest.ID]; ok && quest.Status == "in_progress" {
+		line2 += " " + questRewardStyle.Render(Glyphs.Timer+TimeLeft(time.Until(time.Unix(due, 0))))
 	}
 
 	// Add action hint if selected
 	if isSelected {
 		var hint string
 		if quest.Status == "pending" {
-			hint = questPanelBorderStyle.Render(" [start]")
+			hint = questPanelBorderStyle.Render(i18n.T("panel.start"))
 		} else if quest.Status == "in_progress" {
-			hint = questPanelBorderStyle.Render(" [done]")
+			hint = questPanelBorderStyle.Render(i18n.T("panel.done"))
 		}
 		line1 += hint
 	}
 
-	return line1 + "\n" + line2
+	// Continuation lines align under the title
+	for _, tl := range titleLines[1:] {
+		line1 += "\n      " + titleStyle.Render(tl)
+	}
+
+	result := line1 + "\n" + line2
+
+	// Expanded detail view (selected quest only)
+	if isSelected && q.Expanded {
+		result += q.renderDetail(quest)
+	}
+
+	return result
 }
 
-// calculatePotentialXP calculates XP from incomplete quests
+// flashStyle returns the style for a quest that is mid completion flash
+func (q *QuestPanelModel) flashStyle(quest api.Quest) (lipgloss.Style, bool) {
+	if q.Animation == nil || !q.Animation.IsQuestFlashing(quest.ID) {
+		return lipgloss.Style{}, false
+	}
+	intensity := q.Animation.GetFlashIntensity()
+	switch {
+	case intensity > 0.66:
+		return questFlashInvertStyle, true
+	case intensity > 0.33:
+		return questFlashBrightStyle, true
+	default:
+		return questFlashFadeStyle, true
+	}
+}
+
+// titleWrapWidth returns the width available for a wrapped quest title:
+// panel borders (4), icon indent (6), and room for the action hint (8)
+func (q *QuestPanelModel) titleWrapWidth() int {
+	width := q.Width
+	if width < 34 {
+		width = 34
+	}
+	return width - 18
+}
+
+// renderDetail renders the sub-tasks, notes, and AI reasoning lines for an
+// expanded quest. Reasoning is wrapped rather than truncated so the full
+// explanation is visible.
+func (q *QuestPanelModel) renderDetail(quest api.Quest) string {
+	width := q.Width
+	if width < 34 {
+		width = 34
+	}
+	maxLen := width - 14 // panel borders + indent + "└─ " prefix
+	if maxLen < 12 {
+		maxLen = 12
+	}
+
+	var detail string
+	for _, s := range quest.Subtasks {
+		box := "[ ] "
+		if s.Done {
+			box = "[x] "
+		}
+		detail += "\n      " + questDetailStyle.Render(box+truncateString(s.Title, maxLen))
+	}
+	if quest.Notes != "" {
+		detail += "\n      " + questDetailStyle.Render(Glyphs.Note+truncateString(quest.Notes, maxLen))
+	}
+	if quest.AIReasoning != "" {
+		for i, line := range wrapText(quest.AIReasoning, maxLen) {
+			prefix := "   "
+			if i == 0 {
+				prefix = Glyphs.Branch
+			}
+			detail += "\n      " + questDetailStyle.Render(prefix+line)
+		}
+	}
+	if detail == "" {
+		detail = "\n      " + questDetailStyle.Render(i18n.T("panel.noNotes"))
+	}
+	return detail
+}
+
+// calculatePotentialXP calculates XP from incomplete quests, boosted by
+// any running XP event
 func (q *QuestPanelModel) calculatePotentialXP() int {
 	total := 0
 	for _, quest := range q.Quests {
-		if quest.Status != "completed" {
-			total += quest.XP
+		if quest.IsOpen() {
+			total += q.Event.Apply(quest.RemainingXP())
 		}
 	}
 	return total
 }
 
+// TimeLeft renders a countdown to the minute, e.g. "1h05m", "12m", "<1m"
+func TimeLeft(d time.Duration) string {
+	minutes := int(d.Minutes())
+	switch {
+	case minutes < 1:
+		return "<1m"
+	case minutes < 60:
+		return fmt.Sprintf("%dm", minutes)
+	default:
+		return fmt.Sprintf("%dh%02dm", minutes/60, minutes%60)
+	}
+}
+
+// SubtaskBadge shows how far through its sub-tasks a quest is, e.g.
+// " (2/4)", or "" when it has none
+func SubtaskBadge(quest api.Quest) string {
+	done, total := quest.SubtaskProgress()
+	if total == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" (%d/%d)", done, total)
+}
+
 // renderPanel creates the bordered panel with title
 func (q *QuestPanelModel) renderPanel(title, content string, width int) string {
+	// The focused panel gets a highlighted border
+	border := questPanelBorderStyle
+	if q.Focused {
+		border = questFocusBorderStyle
+	}
+
 	// Top border with title and icon
-	titleWithIcon := "⚔️ " + title
-	titlePart := "╭─ " + titleWithIcon + " "
+	b := Glyphs.Rounded
+	titlePart := b.TopLeft + b.Horizontal + " " + Glyphs.Quests + title + " "
 	titleLen := lipgloss.Width(titlePart)
 	remainingWidth := width - titleLen - 1
 	if remainingWidth < 0 {
 		remainingWidth = 0
 	}
 
-	topBorder := questPanelTitleStyle.Render("╭─ " + titleWithIcon + " ")
+	topBorder := questPanelTitleStyle.Render(titlePart)
 	for i := 0; i < remainingWidth; i++ {
-		topBorder += questPanelBorderStyle.Render("─")
+		topBorder += border.Render(b.Horizontal)
 	}
-	topBorder += questPanelBorderStyle.Render("╮")
+	topBorder += border.Render(b.TopRight)
 
 	// Content lines with borders
 	lines := splitLines(content)
 	var body string
 	for _, line := range lines {
+		line = FitLine(line, width-4)
 		lineLen := lipgloss.Width(line)
 		padding := width - lineLen - 4
 		if padding < 0 {
 			padding = 0
 		}
-		body += questPanelBorderStyle.Render("│") + " " + line
+		body += border.Render(b.Vertical) + " " + line
 		for i := 0; i < padding; i++ {
 			body += " "
 		}
-		body += " " + questPanelBorderStyle.Render("│") + "\n"
+		body += " " + border.Render(b.Vertical) + "\n"
 	}
 
 	// Bottom border
-	bottomBorder := questPanelBorderStyle.Render("╰")
+	bottomBorder := border.Render(b.BottomLeft)
 	for i := 0; i < width-2; i++ {
-		bottomBorder += questPanelBorderStyle.Render("─")
+		bottomBorder += border.Render(b.Horizontal)
 	}
-	bottomBorder += questPanelBorderStyle.Render("╯")
+	bottomBorder += border.Render(b.BottomRight)
 
 	return topBorder + "\n" + body + bottomBorder
 }
 
-// truncateString truncates a string to max length with ellipsis
+// truncateString cuts s to max cells, ending it with an ellipsis
 func truncateString(s string, max int) string {
-	if len(s) <= max {
-		return s
-	}
-	return s[:max-1] + "…"
+	return FitLine(s, max)
 }
@@ -0,0 +1,192 @@
+package components
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"grind/internal/api"
+	"grind/internal/i18n"
+)
+
+// Rival modal colors
+var (
+	rivalGold   = lipgloss.Color("#FFD700")
+	rivalRed    = lipgloss.Color("#FF0055")
+	rivalGreen  = lipgloss.Color("#04B575")
+	rivalWhite  = lipgloss.Color("#FFFFFF")
+	rivalDimmed = lipgloss.Color("#7D7D7D")
+)
+
+// Rival modal styles
+var (
+	rivalModalBorderStyle = lipgloss.NewStyle().
+				Foreground(rivalRed)
+
+	rivalModalTitleStyle = lipgloss.NewStyle().
+				Bold(true).
+				Foreground(rivalRed)
+
+	rivalModalTextStyle = lipgloss.NewStyle().
+				Foreground(rivalWhite)
+
+	rivalModalLeadStyle = lipgloss.NewStyle().
+				Bold(true).
+				Foreground(rivalGreen)
+
+	rivalModalBehindStyle = lipgloss.NewStyle().
+				Bold(true).
+				Foreground(rivalRed)
+
+	rivalModalEvenStyle = lipgloss.NewStyle().
+				Bold(true).
+				Foreground(rivalGold)
+
+	rivalModalHintStyle = lipgloss.NewStyle().
+				Foreground(rivalDimmed)
+)
+
+// RivalModal shows a head-to-head comparison with a crew member
+type RivalModal struct {
+	Visible    bool
+	Comparison *api.RivalComparison
+}
+
+// NewRivalModal creates a new rival modal
+func NewRivalModal() *RivalModal {
+	return &RivalModal{Visible: false}
+}
+
+// Show displays the modal with a comparison (nil shows the no-rival state)
+func (m *RivalModal) Show(cmp *api.RivalComparison) {
+	m.Comparison = cmp
+	m.Visible = true
+}
+
+// Hide hides the modal
+func (m *RivalModal) Hide() {
+	m.Visible = false
+}
+
+// ApplyXP adds freshly earned XP to your side so the gap updates live
+func (m *RivalModal) ApplyXP(xp int) {
+	if m.Comparison == nil {
+		return
+	}
+	m.Comparison.You.WeeklyXP += xp
+	m.Comparison.You.WeeklyQuests++
+	m.Comparison.Gap = m.Comparison.Rival.WeeklyXP - m.Comparison.You.WeeklyXP
+}
+
+// RevertXP undoes an ApplyXP when the completion it was for failed
+func (m *RivalModal) RevertXP(xp int) {
+	if m.Comparison == nil {
+		return
+	}
+	m.Comparison.You.WeeklyXP -= xp
+	m.Comparison.You.WeeklyQuests--
+	m.Comparison.Gap = m.Comparison.Rival.WeeklyXP - m.Comparison.You.WeeklyXP
+}
+
+// View renders the rival modal
+func (m *RivalModal) View(screenWidth, screenHeight int) string {
+	if !m.Visible {
+		return ""
+	}
+
+	modalWidth := 44
+	title := rivalModalTitleStyle.Render(Glyphs.Rival + "HEAD TO HEAD")
+	dismissLine := rivalModalHintStyle.Render("press any key to close")
+
+	var body []string
+	if m.Comparison == nil {
+		body = []string{
+			rivalModalTextStyle.Render("No rivals yet."),
+			rivalModalHintStyle.Render("Invite your crew to start competing!"),
+		}
+	} else {
+		cmp := m.Comparison
+		rivalName := truncateString(cmp.Rival.UserName, 10)
+		row := func(label string, you, rival int) string {
+			return rivalModalTextStyle.Render(fmt.Sprintf("%-10s %8d  %8d", label, you, rival))
+		}
+
+		var verdict string
+		switch {
+		case cmp.Gap > 0:
+			verdict = rivalModalBehindStyle.Render(fmt.Sprintf("%s XP to close the gap", i18n.Number(cmp.Gap)))
+		case cmp.Gap < 0:
+			verdict = rivalModalLeadStyle.Render(fmt.Sprintf("you're %s XP ahead", i18n.Number(-cmp.Gap)))
+		default:
+			verdict = rivalModalEvenStyle.Render("dead even. break the tie.")
+		}
+
+		body = []string{
+			rivalModalHintStyle.Render(fmt.Sprintf("%-10s %8s  %8s", "", "you", rivalName)),
+			row("weekly XP", cmp.You.WeeklyXP, cmp.Rival.WeeklyXP),
+			row("quests", cmp.You.WeeklyQuests, cmp.Rival.WeeklyQuests),
+			row("level", cmp.You.Level, cmp.Rival.Level),
+			"",
+			verdict,
+		}
+	}
+
+	lines := []string{"", title, ""}
+	lines = append(lines, body...)
+	lines = append(lines, "", dismissLine, "")
+	content := lipgloss.JoinVertical(lipgloss.Center, lines...)
+
+	modal := m.renderModalBox(content, modalWidth)
+
+	return lipgloss.Place(
+		screenWidth,
+		screenHeight,
+		lipgloss.Center,
+		lipgloss.Center,
+		modal,
+	)
+}
+
+// renderModalBox renders the modal with double border
+func (m *RivalModal) renderModalBox(content string, width int) string {
+	b := Glyphs.Double
+	topBorder := rivalModalBorderStyle.Render(b.TopLeft)
+	for i := 0; i < width-2; i++ {
+		topBorder += rivalModalBorderStyle.Render(b.Horizontal)
+	}
+	topBorder += rivalModalBorderStyle.Render(b.TopRight)
+
+	lines := splitLines(content)
+	var body string
+	for _, line := range lines {
+		line = FitLine(line, width-2)
+		lineLen := lipgloss.Width(line)
+		totalPadding := width - lineLen - 2
+		leftPad := totalPadding / 2
+		rightPad := totalPadding - leftPad
+		if leftPad < 0 {
+			leftPad = 0
+		}
+		if rightPad < 0 {
+			rightPad = 0
+		}
+
+		body += rivalModalBorderStyle.Render(b.Vertical)
+		for i := 0; i < leftPad; i++ {
+			body += " "
+		}
+		body += line
+		for i := 0; i < rightPad; i++ {
+			body += " "
+		}
+		body += rivalModalBorderStyle.Render(b.Vertical) + "\n"
+	}
+
+	bottomBorder := rivalModalBorderStyle.Render(b.BottomLeft)
+	for i := 0; i < width-2; i++ {
+		bottomBorder += rivalModalBorderStyle.Render(b.Horizontal)
+	}
+	bottomBorder += rivalModalBorderStyle.Render(b.BottomRight)
+
+	return topBorder + "\n" + body + bottomBorder
+}
@@ -0,0 +1,48 @@
+package components
+
+import "github.com/charmbracelet/lipgloss"
+
+// Default XP at which a quest's badge turns from slate to gold (mid) and
+// from gold to orange (high)
+const (
+	DefaultXPTierMid  = 30
+	DefaultXPTierHigh = 75
+)
+
+// XPTierMid and XPTierHigh are the thresholds xpTierStyle uses. Set from
+// the "xpTiers" setting.
+var (
+	XPTierMid  = DefaultXPTierMid
+	XPTierHigh = DefaultXPTierHigh
+)
+
+// questOrange marks high-value quests
+var questOrange = lipgloss.Color("#FF6B00")
+
+// XP badge styles, one per tier
+var (
+	questXPLowStyle = lipgloss.NewStyle().
+			Foreground(questSlate).
+			Bold(true)
+
+	questXPHighStyle = lipgloss.NewStyle().
+				Foreground(questOrange).
+				Bold(true)
+)
+
+// SetXPTiers sets the XP thresholds for the mid and high badge colors
+func SetXPTiers(mid, high int) {
+	XPTierMid, XPTierHigh = mid, high
+}
+
+// xpTierStyle colors an open quest's XP badge by value so high-value quests
+// stand out: slate below XPTierMid, gold up to XPTierHigh, orange above
+func xpTierStyle(xp int) lipgloss.Style {
+	switch {
+	case xp >= XPTierHigh:
+		return questXPHighStyle
+	case xp >= XPTierMid:
+		return questXPBadgeStyle
+	}
+	return questXPLowStyle
+}
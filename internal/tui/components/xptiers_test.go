@@ -0,0 +1,39 @@
+package components
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestXPTierStyle(t *testing.T) {
+	defer SetXPTiers(DefaultXPTierMid, DefaultXPTierHigh)
+
+	low := questXPLowStyle.GetForeground()
+	mid := questXPBadgeStyle.GetForeground()
+	high := questXPHighStyle.GetForeground()
+
+	tests := []struct {
+		name      string
+		mid, high int
+		xp        int
+		want      lipgloss.TerminalColor
+	}{
+		{"zero", DefaultXPTierMid, DefaultXPTierHigh, 0, low},
+		{"below mid", DefaultXPTierMid, DefaultXPTierHigh, DefaultXPTierMid - 1, low},
+		{"at mid", DefaultXPTierMid, DefaultXPTierHigh, DefaultXPTierMid, mid},
+		{"below high", DefaultXPTierMid, DefaultXPTierHigh, DefaultXPTierHigh - 1, mid},
+		{"at high", DefaultXPTierMid, DefaultXPTierHigh, DefaultXPTierHigh, high},
+		{"epic", DefaultXPTierMid, DefaultXPTierHigh, 150, high},
+		{"custom tiers", 10, 20, 15, mid},
+		{"custom high", 10, 20, 20, high},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetXPTiers(tt.mid, tt.high)
+			if got := xpTierStyle(tt.xp).GetForeground(); got != tt.want {
+				t.Errorf("xpTierStyle(%d) with tiers %d/%d = %v, want %v", tt.xp, tt.mid, tt.high, got, tt.want)
+			}
+		})
+	}
+}
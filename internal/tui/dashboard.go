@@ -2,9 +2,13 @@ package tui
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
+	"unicode"
 
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/textinput"
@@ -13,10 +17,23 @@ import (
 
 	"grind/internal/api"
 	"grind/internal/auth"
+	"grind/internal/evaluator"
+	"grind/internal/goals"
+	"grind/internal/i18n"
 	"grind/internal/levels"
 	"grind/internal/tui/components"
 )
 
+// focusPanel identifies which part of the dashboard receives keys
+type focusPanel int
+
+const (
+	panelInput focusPanel = iota
+	panelQuests
+	panelFeed
+	panelCount
+)
+
 // DashboardModel is the main interactive screen
 type DashboardModel struct {
 	config       *auth.Config
@@ -30,71 +47,171 @@ type DashboardModel struct {
 	activity     []api.Activity
 	leaderboard  []api.LeaderboardEntry
 	stats        *api.DashboardStats
+	eventEndsAt  int64 // XP event end already scheduled for a stats reload
+	timerDue     int64 // Quest timer (Unix s) already scheduled, 0 if none
+
+	// On-demand insight reroll (i): whether one is in flight, and when the
+	// last one was asked for, for the cooldown
+	rerolling  bool
+	rerolledAt time.Time
+
+	// Activity polling
+	pollInterval time.Duration
+	unfocused    bool // Terminal window lost focus
+	pollStopped  bool // Tick loop halted while unfocused or idle
+	idle         bool // No input for the configured idle time; the next key resumes polling
+	lastInput    time.Time
+	conn         components.Connection // Whether polls are getting through, for the header
+
+	// Leaderboard rank tracking between refreshes (userID → rank/delta)
+	prevRanks  map[string]int
+	rankDeltas map[string]int
+
+	// Client-side leaderboard filter (A, +/-, esc to reset)
+	boardFilter components.LeaderboardFilter
+
+	// Crew invite code, fetched for the lonely-crew nudge or the group modal
+	inviteCode string
 
 	// UI components
 	input        textinput.Model
 	spinner      spinner.Model
-	inputFocused bool
+	focus        focusPanel // Panel receiving keyboard input
+	inputHint    string // Inline validation message under the input
 	loading      bool
 	err          error
 
 	// Quest selection
 	selectedQuest int
-	questFocus    bool
+	selectedFeed  int
+	questDetail   bool            // Expand notes/reasoning for the selected quest
+	confirmBulk   bool            // Waiting on y/n for "complete all"
+	bulkTotal     int             // Quests in the "complete all" batch in flight, 0 when none
+	abandonID     string          // Quest waiting on y/n to abandon, "" when not asking
+	confirmDoneID string          // High-XP quest waiting on y/n to complete, "" when not asking
+	missingGroup  bool            // Crew was deleted; waiting on Y/n to leave it
+	pickTemplate  bool            // Waiting on a number to add a saved template
+	xpEditID      string          // Quest whose XP is being edited, "" when not editing
+	xpInput       textinput.Model // Manual XP entry
+	subtaskForID  string          // Quest getting a new sub-task, "" when not adding one
+	subtaskInput  textinput.Model // Sub-task title entry
+	notice        string          // One-off success message under the input, cleared on keypress
+	pending       map[string]bool // Quest IDs with a start/complete still in flight
+	quitArmed     bool            // q was pressed once while changes were still syncing
 
 	// Cyber-HUD components
 	headerComp    *components.HeaderModel
+	compactHeader *components.HeaderModel
+	compactQuests *components.QuestPanelModel
 	questPanel    *components.QuestPanelModel
 	intelFeed     *components.IntelFeedModel
 	animation     *components.AnimationState
 	levelUpModal  *components.LevelUpModal
 	groupModal    *components.GroupModal
+	rivalModal    *components.RivalModal
+	catchUpModal  *components.CatchUpModal
 	useCyberHUD   bool // Toggle for new UI
+	compact       bool // Single-column layout for narrow panes
+	forceCompact  bool // --compact for this session, regardless of config
+
+	greetingVariant int // Picks the greeting phrasing, fixed for the session
+
+	// When the user was last here (Unix ms) if it was long enough ago for
+	// a catch-up summary on launch, 0 otherwise
+	catchUpSince int64
+
+	// h swaps the one-line help for the grouped cheat-sheet
+	helpExpanded bool
+
+	// Retries reported by the client, and the label shown next to the
+	// spinner while the quest being added is retried
+	retries    chan RetryMsg
+	retryLabel string
 }
 
 // NewDashboardModel creates a new dashboard
-func NewDashboardModel(cfg *auth.Config, client *api.Client) *DashboardModel {
+func NewDashboardModel(cfg *auth.Config, backend api.ConvexAPI) *DashboardModel {
 	input := textinput.New()
 	input.Placeholder = "what's the plan?"
 	input.Prompt = "" // Remove default prompt since we add our own
 	input.CharLimit = 200
 	input.Width = 50
+	input.SetValue(cfg.DraftQuest)
 	input.Focus()
 
+	xpInput := textinput.New()
+	xpInput.Prompt = ""
+	xpInput.CharLimit = 3
+	xpInput.Width = 5
+
+	subtaskInput := textinput.New()
+	subtaskInput.Prompt = ""
+	subtaskInput.CharLimit = 100
+	subtaskInput.Width = 40
+
 	s := spinner.New()
 	s.Spinner = spinner.Dot
 	s.Style = lipgloss.NewStyle().Foreground(ColorPrimary)
 
-	// Create mock user from config for now
+	// Seed user from last-known progress in config until loadUser returns.
+	// Level is recomputed from XP rather than trusting the stored number.
 	user := &api.User{
 		ID:       cfg.UserID,
 		Name:     cfg.UserName,
 		GroupID:  cfg.GroupID,
-		TotalXP:  0,
+		TotalXP:  cfg.TotalXP,
 		WeeklyXP: 0,
-		Level:    1,
+		Level:    levels.GetLevel(cfg.TotalXP).Number,
 	}
 
-	return &DashboardModel{
+	compactHeader := components.NewHeader(user, nil, 50)
+	compactHeader.Compact = true
+
+	d := &DashboardModel{
 		config:        cfg,
-		client:        client,
+		client:        api.ClientFor(backend),
 		user:          user,
 		quests:        []api.Quest{},
 		activity:      []api.Activity{},
 		leaderboard:   []api.LeaderboardEntry{},
+		pollInterval:  cfg.GetPollInterval(),
+		lastInput:     time.Now(),
+		prevRanks:     map[string]int{},
+		rankDeltas:    map[string]int{},
 		input:         input,
+		xpInput:       xpInput,
+		subtaskInput:  subtaskInput,
+		pending:       map[string]bool{},
 		spinner:       s,
-		inputFocused:  true,
+		focus:         panelInput,
 		selectedQuest: -1,
+		greetingVariant: rng.Intn(1000),
 		// Cyber-HUD components
 		headerComp:   components.NewHeader(user, nil, 70),
+		compactHeader: compactHeader,
+		compactQuests: components.NewQuestPanel([]api.Quest{}, 50, 14),
 		questPanel:   components.NewQuestPanel([]api.Quest{}, 36, 14),
 		intelFeed:    components.NewIntelFeed([]api.Activity{}, []api.LeaderboardEntry{}, "", cfg.UserName, 38, 14),
 		animation:    components.NewAnimationState(),
 		levelUpModal: components.NewLevelUpModal(),
 		groupModal:   components.NewGroupModal(),
-		useCyberHUD:  true, // Enable new UI by default
+		rivalModal:   components.NewRivalModal(),
+		catchUpModal: components.NewCatchUpModal(),
+		useCyberHUD:  cfg.Layout != "classic", // New UI unless classic is chosen in settings
+		compact:      cfg.Layout == "compact",
+	}
+
+	// Surface the client's retries, which happen inside a running command
+	if d.remote() {
+		d.retries = make(chan RetryMsg, 1)
+		d.client.SetRetryHook(func(attempt, max int) {
+			select {
+			case d.retries <- RetryMsg{Attempt: attempt, Max: max}:
+			default: // One is already waiting; the label catches up on the next
+			}
+		})
 	}
+	return d
 }
 
 // Init initializes the dashboard
@@ -105,10 +222,31 @@ func (d *DashboardModel) Init() tea.Cmd {
 		d.loadQuests(),
 		d.loadActivity(),
 		d.loadStats(),
+		d.loadLeaderboard(),
 		d.tickActivity(),
+		d.loadCatchUp(),
+		d.loadGroupInfo(false), // Picks up the crew's level names
+		d.waitForRetry(),
 	)
 }
 
+// RetryMsg is sent when the client retries a failed call; Attempt is the
+// try about to be made, out of Max
+type RetryMsg struct {
+	Attempt int
+	Max     int
+}
+
+// waitForRetry waits for the client's next retry
+func (d *DashboardModel) waitForRetry() tea.Cmd {
+	if d.retries == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		return <-d.retries
+	}
+}
+
 // loadUser fetches user data from Convex
 func (d *DashboardModel) loadUser() tea.Cmd {
 	return func() tea.Msg {
@@ -119,35 +257,73 @@ func (d *DashboardModel) loadUser() tea.Cmd {
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 
-		result, err := d.client.Query(ctx, "users:get", map[string]any{
-			"userId": d.user.ID,
-		})
-		if err != nil {
-			return UserLoadedMsg{Err: err}
-		}
+		user, err := d.client.GetUser(ctx, d.user.ID)
+		return UserLoadedMsg{User: user, Err: err}
+	}
+}
 
-		if result == nil {
-			return UserLoadedMsg{Err: nil}
-		}
+// saveProgress persists the user's XP and rank to config so the next
+// launch and 'grind status' can show them without waiting on the backend
+func (d *DashboardModel) saveProgress() {
+	rank := d.config.Rank
+	if d.stats != nil {
+		rank = d.stats.Week.Rank
+	}
+	if d.config.TotalXP == d.user.TotalXP && d.config.Rank == rank {
+		return
+	}
+	// Best effort - a stale cache is harmless
+	totalXP, now := d.user.TotalXP, time.Now().Unix()
+	_ = d.updateConfig(func(c *auth.Config) {
+		c.TotalXP = totalXP
+		c.Rank = rank
+		c.ProgressAt = now
+	})
+}
 
-		userData, ok := result.(map[string]any)
-		if !ok {
-			return UserLoadedMsg{Err: nil}
-		}
+// SaveState persists anything that would otherwise be lost on exit:
+// the half-typed quest input and last-known progress
+func (d *DashboardModel) SaveState() error {
+	draft, totalXP := sanitizeTitle(d.input.Value()), d.user.TotalXP
+	return d.updateConfig(func(c *auth.Config) {
+		c.DraftQuest = draft
+		c.TotalXP = totalXP
+	})
+}
 
-		user := &api.User{
-			ID:       userData["_id"].(string),
-			Name:     userData["name"].(string),
-			TotalXP:  int(userData["totalXp"].(float64)),
-			WeeklyXP: int(userData["weeklyXp"].(float64)),
-			Level:    int(userData["level"].(float64)),
-		}
-		if groupId, ok := userData["groupId"].(string); ok {
-			user.GroupID = groupId
-		}
+// updateConfig applies fn to the dashboard's config and to the one on
+// disk, so a save keeps whatever other commands changed since launch
+func (d *DashboardModel) updateConfig(fn func(*auth.Config)) error {
+	fn(d.config)
+	return auth.Update(fn)
+}
 
-		return UserLoadedMsg{User: user, Err: nil}
+// remote reports whether the dashboard talks to a deployment. Local mode's
+// client answers from the store, which has no crew, reactions or AI.
+func (d *DashboardModel) remote() bool {
+	return d.client != nil && !d.config.Local
+}
+
+// CapturesKeys reports whether the dashboard needs every key, including q,
+// because the user is typing, looking at a modal, or answering a prompt
+func (d *DashboardModel) CapturesKeys() bool {
+	return d.focus == panelInput || d.xpEditID != "" || d.subtaskForID != "" || d.pickTemplate || d.confirmBulk || d.abandonID != "" || d.confirmDoneID != "" || d.missingGroup ||
+		(d.levelUpModal != nil && d.levelUpModal.Visible) ||
+		(d.groupModal != nil && d.groupModal.Visible) ||
+		(d.rivalModal != nil && d.rivalModal.Visible) ||
+		(d.catchUpModal != nil && d.catchUpModal.Visible)
+}
+
+// ConfirmQuit reports whether q should quit now. While a start or complete
+// is still in flight the first q only warns, so a stray press doesn't drop
+// the change; a second q in a row quits anyway.
+func (d *DashboardModel) ConfirmQuit() bool {
+	if len(d.pending) == 0 || d.quitArmed {
+		return true
 	}
+	d.quitArmed = true
+	d.inputHint = helpf("dashboard.stillSyncing")
+	return false
 }
 
 // UserLoadedMsg is sent when user data is loaded from Convex
@@ -166,50 +342,11 @@ func (d *DashboardModel) loadActivity() tea.Cmd {
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 
-		result, err := d.client.Query(ctx, "activity:getUserActivity", map[string]any{
+		activities, err := api.QueryAs[[]api.Activity](ctx, d.client, "activity:getUserActivity", map[string]any{
 			"userId": d.user.ID,
 			"limit":  20,
 		})
-		if err != nil {
-			return ActivityLoadedMsg{Err: err}
-		}
-
-		activitiesData, ok := result.([]any)
-		if !ok {
-			return ActivityLoadedMsg{Activities: []api.Activity{}, Err: nil}
-		}
-
-		var activities []api.Activity
-		for _, ad := range activitiesData {
-			am, ok := ad.(map[string]any)
-			if !ok {
-				continue
-			}
-			activity := api.Activity{
-				ID:        am["_id"].(string),
-				UserID:    am["userId"].(string),
-				Type:      am["type"].(string),
-				CreatedAt: int64(am["createdAt"].(float64)),
-			}
-			if groupId, ok := am["groupId"].(string); ok {
-				activity.GroupID = groupId
-			}
-			if userName, ok := am["userName"].(string); ok {
-				activity.UserName = userName
-			}
-			if questTitle, ok := am["questTitle"].(string); ok {
-				activity.QuestTitle = questTitle
-			}
-			if xp, ok := am["xp"].(float64); ok {
-				activity.XP = int(xp)
-			}
-			if newLevel, ok := am["newLevel"].(float64); ok {
-				activity.NewLevel = int(newLevel)
-			}
-			activities = append(activities, activity)
-		}
-
-		return ActivityLoadedMsg{Activities: activities, Err: nil}
+		return ActivityLoadedMsg{Activities: activities, Err: err}
 	}
 }
 
@@ -219,10 +356,92 @@ type ActivityLoadedMsg struct {
 	Err        error
 }
 
+// maxActivity caps the feed kept in memory over a long session; the panel
+// only shows the newest few
+const maxActivity = 100
+
+// localActivityPrefix marks feed items the dashboard posted optimistically,
+// before the backend's copy arrives in a poll
+const localActivityPrefix = "local_"
+
+// activityMatchWindow is how far apart a local item and the backend's copy
+// may be timestamped and still match, allowing for clock skew. Unmatched
+// local items older than anything polled are dropped after the same window.
+const activityMatchWindow = 5 * time.Minute
+
+// addActivity posts a local item to the top of the feed
+func (d *DashboardModel) addActivity(a api.Activity) {
+	a.ID = fmt.Sprintf("%s%d", localActivityPrefix, time.Now().UnixNano())
+	d.activity = append([]api.Activity{a}, d.activity...)
+	if len(d.activity) > maxActivity {
+		d.activity = d.activity[:maxActivity]
+	}
+}
+
+// activityKey identifies the event an activity describes, so a local item
+// and the backend's copy of it compare equal despite different IDs
+func activityKey(a api.Activity) string {
+	return fmt.Sprintf("%s|%s|%s|%d", a.Type, a.UserID, a.QuestTitle, a.NewLevel)
+}
+
+// mergeActivity folds a polled feed into the current one, newest first.
+// Polled items are authoritative and replace the local item for the same
+// event; unmatched local items stay while the backend may still be catching
+// up. Items are deduped by ID and the result is capped at maxActivity.
+func mergeActivity(current, polled []api.Activity, now time.Time) []api.Activity {
+	var newest int64
+	polledAt := make(map[string][]int64, len(polled))
+	for _, a := range polled {
+		newest = max(newest, a.CreatedAt)
+		key := activityKey(a)
+		polledAt[key] = append(polledAt[key], a.CreatedAt)
+	}
+	window := activityMatchWindow.Milliseconds()
+
+	merged := make([]api.Activity, 0, len(polled)+len(current))
+	seen := make(map[string]bool, cap(merged))
+	for _, a := range polled {
+		if !seen[a.ID] {
+			seen[a.ID] = true
+			merged = append(merged, a)
+		}
+	}
+	for _, a := range current {
+		if seen[a.ID] || !strings.HasPrefix(a.ID, localActivityPrefix) {
+			continue
+		}
+		matched := slices.ContainsFunc(polledAt[activityKey(a)], func(at int64) bool {
+			return abs64(at-a.CreatedAt) <= window
+		})
+		stale := a.CreatedAt <= newest && now.UnixMilli()-a.CreatedAt > window
+		if matched || stale {
+			continue
+		}
+		seen[a.ID] = true
+		merged = append(merged, a)
+	}
+
+	slices.SortStableFunc(merged, func(a, b api.Activity) int {
+		return int(b.CreatedAt - a.CreatedAt)
+	})
+	if len(merged) > maxActivity {
+		merged = merged[:maxActivity]
+	}
+	return merged
+}
+
+// abs64 returns the absolute value of n
+func abs64(n int64) int64 {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
 // loadStats fetches dashboard stats from Convex (tries action first, falls back to query)
 func (d *DashboardModel) loadStats() tea.Cmd {
 	return func() tea.Msg {
-		if d.client == nil || d.user.ID == "" {
+		if !d.remote() || d.user.ID == "" {
 			return StatsLoadedMsg{Err: nil}
 		}
 
@@ -251,55 +470,8 @@ func (d *DashboardModel) loadStats() tea.Cmd {
 			return StatsLoadedMsg{Err: nil}
 		}
 
-		data, ok := result.(map[string]any)
-		if !ok {
-			return StatsLoadedMsg{Err: nil}
-		}
-
-		stats := &api.DashboardStats{}
-
-		// Parse today stats
-		if today, ok := data["today"].(map[string]any); ok {
-			stats.Today.XP = int(today["xp"].(float64))
-			stats.Today.QuestsCompleted = int(today["questsCompleted"].(float64))
-			stats.Today.QuestsTotal = int(today["questsTotal"].(float64))
-		}
-
-		// Parse week stats
-		if week, ok := data["week"].(map[string]any); ok {
-			stats.Week.XP = int(week["xp"].(float64))
-			stats.Week.Rank = int(week["rank"].(float64))
-		}
-
-		// Parse group stats (optional)
-		if group, ok := data["group"].(map[string]any); ok {
-			stats.Group = &api.GroupStats{
-				MemberCount:   int(group["memberCount"].(float64)),
-				ActiveToday:   int(group["activeToday"].(float64)),
-				UserRank:      int(group["userRank"].(float64)),
-				LeaderName:    group["leaderName"].(string),
-				LeaderXP:      int(group["leaderXP"].(float64)),
-				IsUserLeading: group["isUserLeading"].(bool),
-				GroupTodayXP:  int(group["groupTodayXP"].(float64)),
-			}
-		}
-
-		// Parse quote
-		if quote, ok := data["quote"].(string); ok {
-			stats.Quote = quote
-		}
-
-		// Parse competitive insight (from AI)
-		if insight, ok := data["competitiveInsight"].(string); ok {
-			stats.CompetitiveInsight = insight
-		}
-
-		// Parse insight type for dynamic styling
-		if insightType, ok := data["insightType"].(string); ok {
-			stats.InsightType = insightType
-		}
-
-		return StatsLoadedMsg{Stats: stats, Err: nil}
+		stats, err := api.DecodeStats(result)
+		return StatsLoadedMsg{Stats: stats, Err: err}
 	}
 }
 
@@ -309,60 +481,115 @@ type StatsLoadedMsg struct {
 	Err   error
 }
 
+// insightRerollCooldown is how long after one reroll the next is refused,
+// so holding i doesn't fire an AI call per keypress
+const insightRerollCooldown = 10 * time.Second
+
+// InsightRerolledMsg is sent when an on-demand insight reroll returns
+type InsightRerolledMsg struct {
+	Stats *api.DashboardStats
+	Err   error
+}
+
+// rerollInsight asks dashboard:getStatsWithInsight for a fresh insight
+// right away instead of waiting for the next poll, at most once per
+// insightRerollCooldown
+func (d *DashboardModel) rerollInsight() tea.Cmd {
+	if !d.remote() {
+		d.inputHint = "no AI insights in local mode"
+		return nil
+	}
+	if d.rerolling {
+		return nil
+	}
+	if wait := insightRerollCooldown - time.Since(d.rerolledAt); wait > 0 {
+		d.inputHint = fmt.Sprintf("new insight in %ds", int(wait.Seconds())+1)
+		return nil
+	}
+	d.rerolling = true
+	d.rerolledAt = time.Now()
+
+	userID := d.user.ID
+	return tea.Batch(d.spinner.Tick, func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+
+		result, err := d.client.Action(ctx, "dashboard:getStatsWithInsight", map[string]any{
+			"userId": userID,
+		})
+		if err != nil || result == nil {
+			return InsightRerolledMsg{Err: err}
+		}
+		stats, err := api.DecodeStats(result)
+		return InsightRerolledMsg{Stats: stats, Err: err}
+	})
+}
+
 // loadQuests fetches today's quests from Convex
 func (d *DashboardModel) loadQuests() tea.Cmd {
 	return func() tea.Msg {
 		if d.client == nil {
-			return QuestsLoadedMsg{Quests: []api.Quest{}, Err: nil}
+			return QuestsLoadedMsg{Err: nil}
 		}
 
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 
-		result, err := d.client.Query(ctx, "quests:listToday", map[string]any{
+		quests, err := api.QueryAs[[]api.Quest](ctx, d.client, "quests:listToday", map[string]any{
 			"userId": d.user.ID,
 		})
-		if err != nil {
-			return QuestsLoadedMsg{Err: err}
-		}
+		return QuestsLoadedMsg{Quests: quests, Err: err}
+	}
+}
 
-		// Parse quests from response
-		questsData, ok := result.([]any)
-		if !ok {
-			return QuestsLoadedMsg{Quests: []api.Quest{}, Err: nil}
+// loadLeaderboard fetches the group leaderboard (weekly or all-time) from Convex
+func (d *DashboardModel) loadLeaderboard() tea.Cmd {
+	allTime := d.config.LeaderboardAllTime
+	return func() tea.Msg {
+		if !d.remote() || d.user.GroupID == "" {
+			return LeaderboardLoadedMsg{AllTime: allTime, Err: nil}
 		}
 
-		var quests []api.Quest
-		for _, qd := range questsData {
-			qm, ok := qd.(map[string]any)
-			if !ok {
-				continue
-			}
-			quest := api.Quest{
-				ID:          qm["_id"].(string),
-				UserID:      qm["userId"].(string),
-				Title:       qm["title"].(string),
-				XP:          int(qm["xp"].(float64)),
-				AIReasoning: qm["aiReasoning"].(string),
-				Status:      qm["status"].(string),
-				CreatedAt:   int64(qm["createdAt"].(float64)),
-			}
-			if groupId, ok := qm["groupId"].(string); ok {
-				quest.GroupID = groupId
-			}
-			if completedAt, ok := qm["completedAt"].(float64); ok {
-				quest.CompletedAt = int64(completedAt)
-			}
-			quests = append(quests, quest)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		path := "leaderboard:weekly"
+		if allTime {
+			path = "leaderboard:allTime"
 		}
 
-		return QuestsLoadedMsg{Quests: quests, Err: nil}
+		entries, err := api.QueryAs[[]api.LeaderboardEntry](ctx, d.client, path, map[string]any{
+			"groupId": d.user.GroupID,
+		})
+		return LeaderboardLoadedMsg{Entries: entries, AllTime: allTime, Err: err}
+	}
+}
+
+// LeaderboardLoadedMsg is sent when the leaderboard is loaded from Convex
+type LeaderboardLoadedMsg struct {
+	Entries []api.LeaderboardEntry
+	AllTime bool
+	Err     error
+}
+
+// applyRankDeltas sets RankDelta on each entry by comparing against the ranks
+// seen on the previous refresh. A delta sticks until that member moves again,
+// so the ▲/▼ indicator doesn't vanish on the next poll.
+func applyRankDeltas(entries []api.LeaderboardEntry, prevRanks, deltas map[string]int) {
+	for i := range entries {
+		e := &entries[i]
+		if prev, ok := prevRanks[e.UserID]; ok && prev != e.Rank {
+			deltas[e.UserID] = prev - e.Rank
+		}
+		e.RankDelta = deltas[e.UserID]
+		prevRanks[e.UserID] = e.Rank
 	}
 }
 
-// tickActivity returns a command that ticks every 5 seconds for activity polling
+// tickActivity returns a command that ticks at the configured poll
+// interval, backed off while offline
 func (d *DashboardModel) tickActivity() tea.Cmd {
-	return tea.Tick(5*time.Second, func(t time.Time) tea.Msg {
+	return tea.Tick(d.conn.PollInterval(d.pollInterval), func(t time.Time) tea.Msg {
 		return ActivityTickMsg{}
 	})
 }
@@ -370,6 +597,122 @@ func (d *DashboardModel) tickActivity() tea.Cmd {
 // ActivityTickMsg is sent when the activity ticker fires
 type ActivityTickMsg struct{}
 
+// resumePolling refreshes immediately and restarts the tick loop if it
+// was stopped
+func (d *DashboardModel) resumePolling() tea.Cmd {
+	if !d.pollStopped {
+		return nil
+	}
+	d.pollStopped = false
+	return tea.Batch(d.loadActivity(), d.loadStats(), d.loadLeaderboard(), d.tickActivity())
+}
+
+// HealthMsg is sent when an offline probe of the backend finishes
+type HealthMsg struct {
+	Latency time.Duration
+	Err     error
+}
+
+// probeBackend checks whether the backend is reachable again, using the
+// client's health check so the header can say what's wrong. Local mode
+// has no backend; a stats reload stands in.
+func (d *DashboardModel) probeBackend() tea.Cmd {
+	if !d.remote() {
+		return d.loadStats()
+	}
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		latency, err := d.client.HealthCheck(ctx)
+		return HealthMsg{Latency: latency, Err: err}
+	}
+}
+
+// recordSync feeds a poll's outcome to the connection indicator. It
+// reports whether the poll brought the dashboard back online.
+func (d *DashboardModel) recordSync(err error) bool {
+	return d.conn.Record(err, time.Now().In(d.config.Location()))
+}
+
+// connection returns the indicator for the header, or nil in local-only
+// mode where there is nothing to be offline from
+func (d *DashboardModel) connection() *components.Connection {
+	if !d.remote() {
+		return nil
+	}
+	return &d.conn
+}
+
+// watchEvent schedules a stats reload for when the running XP event ends,
+// so the banner and boosted XP don't outlive it
+func (d *DashboardModel) watchEvent() tea.Cmd {
+	event := d.stats.ActiveEvent(time.Now())
+	if event == nil || event.EndsAt == d.eventEndsAt {
+		return nil
+	}
+	d.eventEndsAt = event.EndsAt
+	return tea.Tick(time.Until(time.UnixMilli(event.EndsAt)), func(time.Time) tea.Msg {
+		return EventEndedMsg{EndsAt: event.EndsAt}
+	})
+}
+
+// scheduleTimer wakes the dashboard when the next quest timer is due,
+// unless that one is already scheduled. Timers that ran out while the
+// dashboard was closed fire straight away.
+func (d *DashboardModel) scheduleTimer() tea.Cmd {
+	questID, due, ok := d.config.NextQuestTimer()
+	if !ok || due == d.timerDue {
+		return nil
+	}
+	d.timerDue = due
+	return tea.Tick(time.Until(time.Unix(due, 0)), func(time.Time) tea.Msg {
+		return QuestTimerMsg{QuestID: questID, Due: due}
+	})
+}
+
+// QuestTimerMsg is sent when a quest's timer may have run out
+type QuestTimerMsg struct {
+	QuestID string
+	Due     int64
+}
+
+// promptBusy reports whether a question that pops up on its own should
+// wait: another one is already on screen, or the user is typing and their
+// next key would answer it
+func (d *DashboardModel) promptBusy() bool {
+	return d.confirmDoneID != "" || d.abandonID != "" || d.confirmBulk || d.missingGroup || d.pickTemplate ||
+		d.xpEditID != "" || d.subtaskForID != "" || (d.focus == panelInput && d.input.Value() != "")
+}
+
+// timeUp asks whether a quest whose timer ran out is done. The question
+// waits a minute if promptBusy.
+func (d *DashboardModel) timeUp(questID string) {
+	if d.promptBusy() {
+		due := time.Now().Add(time.Minute)
+		_ = d.updateConfig(func(c *auth.Config) { c.SetQuestTimer(questID, due) })
+		return
+	}
+	d.clearTimer(questID)
+	for _, q := range d.quests {
+		if q.ID == questID && q.Status == "in_progress" && !d.pending[q.ID] {
+			d.confirmDoneID = q.ID
+			d.notice = fmt.Sprintf("%stime's up on %q", components.Glyphs.Timer, truncate(q.Title, 24))
+		}
+	}
+}
+
+// clearTimer drops a quest's timer, if it has one
+func (d *DashboardModel) clearTimer(questID string) {
+	if _, ok := d.config.QuestTimers[questID]; ok {
+		_ = d.updateConfig(func(c *auth.Config) { c.ClearQuestTimer(questID) })
+	}
+}
+
+// EventEndedMsg is sent when a scheduled XP event runs out
+type EventEndedMsg struct {
+	EndsAt int64
+}
+
 // QuestsLoadedMsg is sent when quests are loaded from Convex
 type QuestsLoadedMsg struct {
 	Quests []api.Quest
@@ -381,13 +724,17 @@ type GroupLoadedMsg struct {
 	Name        string
 	InviteCode  string
 	MemberCount int
+	MaxMembers  int      // 0 means no limit
+	LevelNames  []string // The crew's own level names; empty means the defaults
+	Show        bool     // Open the group modal; false only caches the invite code
 	Err         error
 }
 
-// loadGroupInfo fetches group info from Convex
-func (d *DashboardModel) loadGroupInfo() tea.Cmd {
+// loadGroupInfo fetches group info from Convex, opening the group modal
+// when show is set
+func (d *DashboardModel) loadGroupInfo(show bool) tea.Cmd {
 	return func() tea.Msg {
-		if d.client == nil || d.user.GroupID == "" {
+		if !d.remote() || d.user.GroupID == "" {
 			return GroupLoadedMsg{Err: fmt.Errorf("no group")}
 		}
 
@@ -403,17 +750,14 @@ func (d *DashboardModel) loadGroupInfo() tea.Cmd {
 		}
 
 		if result == nil {
-			return GroupLoadedMsg{Err: fmt.Errorf("group not found")}
+			return GroupLoadedMsg{Err: api.ErrGroupNotFound}
 		}
 
-		data, ok := result.(map[string]any)
-		if !ok {
-			return GroupLoadedMsg{Err: fmt.Errorf("invalid response")}
+		var group api.Group
+		if err := api.DecodeInto(result, &group); err != nil {
+			return GroupLoadedMsg{Err: fmt.Errorf("decode group: %w", err)}
 		}
 
-		name, _ := data["name"].(string)
-		inviteCode, _ := data["inviteCode"].(string)
-
 		// Get member count
 		membersResult, err := d.client.Query(ctx, "groups:getMembers", map[string]any{
 			"groupId": d.user.GroupID,
@@ -426,14 +770,64 @@ func (d *DashboardModel) loadGroupInfo() tea.Cmd {
 		}
 
 		return GroupLoadedMsg{
-			Name:        name,
-			InviteCode:  inviteCode,
+			Name:        group.Name,
+			InviteCode:  group.InviteCode,
 			MemberCount: memberCount,
+			MaxMembers:  group.MaxMembers,
+			LevelNames:  group.LevelNames,
+			Show:        show,
 			Err:         nil,
 		}
 	}
 }
 
+// CatchUpLoadedMsg is sent when the catch-up summary is loaded
+type CatchUpLoadedMsg struct {
+	CatchUp *api.CatchUp
+	Err     error
+}
+
+// loadCatchUp fetches what the crew did since the user was last here, if
+// they've been away long enough to be shown it
+func (d *DashboardModel) loadCatchUp() tea.Cmd {
+	if d.catchUpSince == 0 || !d.remote() || d.user.GroupID == "" {
+		return nil
+	}
+	since, prevRank := d.catchUpSince, d.config.Rank // Rank before this session's stats replace it
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		catchUp, err := d.client.GetCatchUp(ctx, d.user.ID, since)
+		if catchUp != nil {
+			catchUp.PrevRank = prevRank
+		}
+		return CatchUpLoadedMsg{CatchUp: catchUp, Err: err}
+	}
+}
+
+// RivalLoadedMsg is sent when a head-to-head comparison is loaded
+type RivalLoadedMsg struct {
+	Comparison *api.RivalComparison
+	Refresh    bool // Background refresh; don't reopen a dismissed modal
+	Err        error
+}
+
+// loadRival fetches the head-to-head comparison with the closest rival
+func (d *DashboardModel) loadRival(refresh bool) tea.Cmd {
+	return func() tea.Msg {
+		if !d.remote() || d.user.GroupID == "" {
+			return RivalLoadedMsg{Refresh: refresh, Err: nil}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		cmp, err := d.client.CompareRival(ctx, d.user.ID, "")
+		return RivalLoadedMsg{Comparison: cmp, Refresh: refresh, Err: err}
+	}
+}
+
 // QuestAddedMsg is sent when a quest is added
 type QuestAddedMsg struct {
 	Quest api.Quest
@@ -446,12 +840,26 @@ type QuestStartedMsg struct {
 	Err     error
 }
 
+// QuestSnoozedMsg is sent when a quest is deferred to tomorrow
+type QuestSnoozedMsg struct {
+	QuestID string
+	Err     error
+}
+
+// QuestAbandonedMsg is sent when a quest has been abandoned
+type QuestAbandonedMsg struct {
+	Quest api.Quest
+	Err   error
+}
+
 // QuestCompletedMsg is sent when a quest is completed
 type QuestCompletedMsg struct {
 	Quest    api.Quest
 	XPEarned int
 	LevelUp  bool
 	NewLevel int
+	Capped   bool // The crew's daily cap cut XPEarned below the boosted XP
+	Shown    int  // XP shown optimistically, the quest's XP boosted by any event
 	Err      error
 }
 
@@ -464,64 +872,226 @@ func (d *DashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return d, nil
 
 	case tea.KeyMsg:
+		d.lastInput = time.Now()
+		if d.idle {
+			// The key that wakes an idle dashboard only resumes polling,
+			// unless a prompt or the quest input is waiting for it
+			d.idle = false
+			resume := d.resumePolling()
+			if !d.CapturesKeys() {
+				return d, resume
+			}
+			model, cmd := d.handleKey(msg)
+			return model, tea.Batch(resume, cmd)
+		}
 		return d.handleKey(msg)
 
+	case tea.BlurMsg:
+		d.unfocused = true
+		return d, nil
+
+	case tea.FocusMsg:
+		d.unfocused = false
+		d.idle = false
+		d.lastInput = time.Now()
+		return d, d.resumePolling()
+
 	case ActivityTickMsg:
+		// Stop polling while the terminal is unfocused; FocusMsg restarts it
+		if d.unfocused {
+			d.pollStopped = true
+			return d, nil
+		}
+
+		// Stop polling when nobody has touched the dashboard for a while,
+		// e.g. left open overnight; the next key restarts it
+		if idleAfter := d.config.GetIdleAfter(); idleAfter > 0 && time.Since(d.lastInput) >= idleAfter {
+			d.idle = true
+			d.pollStopped = true
+			return d, nil
+		}
+
+		// While offline, probe with a single health check at a backed-off
+		// interval rather than hammering a down backend; its success
+		// reloads the rest
+		if d.conn.Offline() {
+			return d, tea.Batch(d.probeBackend(), d.tickActivity())
+		}
+
+		// Pick up timers set with 'grind start --timer' in another shell
+		if fresh, err := auth.Load(); err == nil {
+			d.config.QuestTimers = fresh.QuestTimers
+		}
+
 		// Poll for activity and stats updates
-		return d, tea.Batch(d.loadActivity(), d.loadStats(), d.tickActivity())
+		cmds := []tea.Cmd{d.loadActivity(), d.loadStats(), d.loadLeaderboard(), d.tickActivity(), d.scheduleTimer()}
+		if d.rivalModal != nil && d.rivalModal.Visible {
+			cmds = append(cmds, d.loadRival(true))
+		}
+		return d, tea.Batch(cmds...)
 
 	case components.AnimationTickMsg:
-		// Update animations
-		var cmds []tea.Cmd
+		// Update animations. Both return the same tick command; schedule it
+		// once so two loops don't run in parallel and double the frame rate.
+		var next tea.Cmd
 		if d.animation != nil {
-			cmd := d.animation.Update()
-			if cmd != nil {
-				cmds = append(cmds, cmd)
+			if cmd := d.animation.Update(); cmd != nil {
+				next = cmd
 			}
 		}
 		if d.levelUpModal != nil {
-			cmd := d.levelUpModal.Update()
-			if cmd != nil {
-				cmds = append(cmds, cmd)
+			if cmd := d.levelUpModal.Update(); cmd != nil {
+				next = cmd
 			}
 		}
-		if len(cmds) > 0 {
-			return d, tea.Batch(cmds...)
-		}
-		return d, nil
+		return d, next
 
 	case UserLoadedMsg:
+		d.recordSync(msg.Err)
 		if msg.Err == nil && msg.User != nil {
 			d.user = msg.User
+			d.user.Level = levels.GetLevel(d.user.TotalXP).Number
+			d.saveProgress()
 		}
 		return d, nil
 
 	case ActivityLoadedMsg:
+		d.recordSync(msg.Err)
 		if msg.Err == nil && msg.Activities != nil {
-			d.activity = msg.Activities
+			d.activity = mergeActivity(d.activity, msg.Activities, time.Now())
+		}
+		return d, nil
+
+	case HealthMsg:
+		var healthErr *api.HealthError
+		if errors.As(msg.Err, &healthErr) {
+			d.conn.Problem = string(healthErr.Kind)
+		}
+		if d.recordSync(msg.Err) {
+			// Back online: catch up on everything
+			return d, tea.Batch(d.loadUser(), d.loadQuests(), d.loadActivity(), d.loadStats(), d.loadLeaderboard())
 		}
 		return d, nil
 
 	case StatsLoadedMsg:
+		var reload tea.Cmd
+		if d.recordSync(msg.Err) {
+			// Back online: catch up on everything the probe didn't fetch
+			reload = tea.Batch(d.loadUser(), d.loadQuests(), d.loadActivity(), d.loadLeaderboard())
+		}
 		if msg.Err == nil && msg.Stats != nil {
 			d.stats = msg.Stats
+			d.checkGoal()
+			d.saveProgress()
+			return d, tea.Batch(reload, d.watchEvent())
 		}
-		return d, nil
+		return d, reload
 
-	case QuestsLoadedMsg:
-		if msg.Err == nil && msg.Quests != nil {
-			d.quests = msg.Quests
+	case CatchUpLoadedMsg:
+		// Nothing happened, or it failed: not worth interrupting for
+		if msg.Err == nil && msg.CatchUp != nil && (msg.CatchUp.QuestsCompleted > 0 || len(msg.CatchUp.LevelUps) > 0) {
+			d.catchUpModal.Show(msg.CatchUp)
 		}
 		return d, nil
 
-	case GroupLoadedMsg:
-		if msg.Err == nil {
-			d.groupModal.Show(msg.Name, msg.InviteCode, msg.MemberCount)
+	case InsightRerolledMsg:
+		d.rerolling = false
+		if msg.Err != nil {
+			d.inputHint = "couldn't get a new insight"
+			return d, nil
+		}
+		if msg.Stats != nil {
+			d.stats = msg.Stats
 		}
 		return d, nil
 
-	case QuestAddedMsg:
-		d.loading = false
+	case EventEndedMsg:
+		if msg.EndsAt != d.eventEndsAt {
+			return d, nil // A newer event replaced it
+		}
+		d.eventEndsAt = 0
+		return d, d.loadStats()
+
+	case LeaderboardLoadedMsg:
+		d.recordSync(msg.Err)
+		if msg.AllTime != d.config.LeaderboardAllTime {
+			return d, nil // Stale response from before a mode switch
+		}
+		if msg.Err == nil && msg.Entries != nil {
+			applyRankDeltas(msg.Entries, d.prevRanks, d.rankDeltas)
+			d.leaderboard = msg.Entries
+			// A crew of one gets an invite nudge, which needs the code
+			if len(d.leaderboard) < 2 && d.inviteCode == "" {
+				return d, d.loadGroupInfo(false)
+			}
+		}
+		return d, nil
+
+	case QuestsLoadedMsg:
+		d.recordSync(msg.Err)
+		if msg.Err == nil && msg.Quests != nil {
+			d.quests = d.keepPendingStatus(msg.Quests)
+			// Timers only make sense on quests still being worked on
+			for _, q := range d.quests {
+				if q.Status != "in_progress" {
+					d.clearTimer(q.ID)
+				}
+			}
+		}
+		return d, d.scheduleTimer()
+
+	case QuestTimerMsg:
+		if d.timerDue == msg.Due {
+			d.timerDue = 0
+		}
+		if d.config.QuestTimers[msg.QuestID] != msg.Due {
+			return d, d.scheduleTimer() // Cleared or reset since
+		}
+		d.timeUp(msg.QuestID)
+		return d, d.scheduleTimer()
+
+	case GroupLoadedMsg:
+		if errors.Is(msg.Err, api.ErrGroupNotFound) {
+			// Leaving can't be undone, so don't ask mid-word; the next
+			// group poll asks again
+			if !d.promptBusy() {
+				d.missingGroup = true
+			}
+			return d, nil
+		}
+		if msg.Err == nil {
+			d.inviteCode = msg.InviteCode
+			if !slices.Equal(msg.LevelNames, d.config.LevelNames) {
+				levels.SetNames(msg.LevelNames)
+				_ = d.updateConfig(func(c *auth.Config) { c.LevelNames = msg.LevelNames })
+			}
+			if msg.Show {
+				d.groupModal.Show(msg.Name, msg.InviteCode, msg.MemberCount, msg.MaxMembers)
+			}
+		}
+		return d, nil
+
+	case RivalLoadedMsg:
+		if msg.Refresh && !d.rivalModal.Visible {
+			return d, nil
+		}
+		if msg.Err != nil {
+			d.err = msg.Err
+			return d, nil
+		}
+		d.rivalModal.Show(msg.Comparison)
+		return d, nil
+
+	case RetryMsg:
+		// Background polls retry too; only a quest being added shows it
+		if d.loading {
+			d.retryLabel = i18n.Tf("dashboard.retrying", msg.Attempt, msg.Max, components.Glyphs.Ellipsis)
+		}
+		return d, d.waitForRetry()
+
+	case QuestAddedMsg:
+		d.loading = false
+		d.retryLabel = ""
 		d.input.SetValue("")
 		if msg.Err != nil {
 			d.err = msg.Err
@@ -529,103 +1099,184 @@ func (d *DashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		d.quests = append(d.quests, msg.Quest)
 		// Add to activity feed
-		d.activity = append([]api.Activity{{
-			ID:         fmt.Sprintf("activity_%d", time.Now().UnixNano()),
+		d.addActivity(api.Activity{
 			UserID:     d.user.ID,
 			UserName:   d.user.Name,
 			Type:       "quest_created",
 			QuestTitle: msg.Quest.Title,
 			XP:         msg.Quest.XP,
 			CreatedAt:  time.Now().UnixMilli(),
-		}}, d.activity...)
+		})
 		return d, nil
 
 	case QuestStartedMsg:
+		delete(d.pending, msg.QuestID)
+		if msg.Err != nil {
+			// Roll back the optimistic start
+			d.err = msg.Err
+			d.setQuestStatus(msg.QuestID, "pending")
+		}
+		return d, nil
+
+	case QuestXPSetMsg:
+		if msg.Err != nil {
+			d.err = msg.Err
+			return d, d.loadQuests()
+		}
+		return d, nil
+
+	case GroupLeftMsg:
 		if msg.Err != nil {
 			d.err = msg.Err
 			return d, nil
 		}
-		// Update quest status locally
-		for i := range d.quests {
-			if d.quests[i].ID == msg.QuestID {
-				d.quests[i].Status = "in_progress"
-			}
+		_ = d.updateConfig(func(c *auth.Config) { c.ClearGroup() })
+		d.user.GroupID = ""
+		d.inviteCode = ""
+		d.leaderboard = []api.LeaderboardEntry{}
+		d.groupModal.ShowNoGroup()
+		return d, nil
+
+	case SubtaskAddedMsg:
+		if msg.Err != nil {
+			d.err = msg.Err
+			return d, d.loadQuests()
 		}
 		return d, nil
 
-	case QuestCompletedMsg:
+	case SubtaskDoneMsg:
+		delete(d.pending, msg.Quest.ID)
 		if msg.Err != nil {
 			d.err = msg.Err
 			return d, nil
 		}
-		// Update quest status
+		oldXP := d.user.TotalXP
+		d.adjustXP(msg.Result.XPEarned)
+		if d.animation != nil && msg.Result.XPEarned > 0 {
+			d.animation.SetDisplayedXP(oldXP)
+			d.animation.TriggerXPGain(msg.Result.XPEarned, d.user.TotalXP)
+		}
+		d.showLevelUpIfCrossed(oldXP, d.user.TotalXP)
+		return d, tea.Batch(d.loadQuests(), components.TickAnimation())
+
+	case ReactedMsg:
+		if msg.Err != nil {
+			// Roll back the optimistic toggle to whatever the backend has
+			d.err = msg.Err
+			return d, d.loadActivity()
+		}
+		return d, nil
+
+	case QuestsReorderedMsg:
+		if msg.Err != nil {
+			// Roll back the optimistic move to whatever the backend has
+			d.err = msg.Err
+			return d, d.loadQuests()
+		}
+		return d, nil
+
+	case QuestSnoozedMsg:
+		if msg.Err != nil {
+			d.err = msg.Err
+			return d, nil
+		}
+		// Drop from today's list; it comes back tomorrow
 		for i := range d.quests {
-			if d.quests[i].ID == msg.Quest.ID {
-				d.quests[i].Status = "completed"
-				d.quests[i].CompletedAt = time.Now().UnixMilli()
+			if d.quests[i].ID == msg.QuestID {
+				d.quests = append(d.quests[:i], d.quests[i+1:]...)
+				break
 			}
 		}
-		// Update user XP
-		d.user.TotalXP += msg.XPEarned
-		d.user.WeeklyXP += msg.XPEarned
-		d.user.Level = levels.GetLevel(d.user.TotalXP).Number
+		if d.selectedQuest >= len(d.quests) {
+			d.selectedQuest = len(d.quests) - 1
+		}
+		return d, nil
 
-		// Add to activity feed
-		d.activity = append([]api.Activity{{
-			ID:         fmt.Sprintf("activity_%d", time.Now().UnixNano()),
+	case QuestAbandonedMsg:
+		if msg.Err != nil {
+			d.err = msg.Err
+			return d, nil
+		}
+		// Keep it in the list, dropped, for an honest record of the day
+		d.setQuestStatus(msg.Quest.ID, "abandoned")
+		d.clearTimer(msg.Quest.ID)
+		d.addActivity(api.Activity{
 			UserID:     d.user.ID,
 			UserName:   d.user.Name,
-			Type:       "quest_completed",
+			Type:       "quest_abandoned",
 			QuestTitle: msg.Quest.Title,
-			XP:         msg.XPEarned,
 			CreatedAt:  time.Now().UnixMilli(),
-		}}, d.activity...) // Prepend to show newest first
+		})
+		return d, nil
 
-		// Trigger quest flash animation
-		if d.animation != nil {
-			d.animation.TriggerQuestFlash(msg.Quest.ID)
-			d.animation.TriggerXPGain(msg.XPEarned, d.user.TotalXP)
-		}
-
-		var cmds []tea.Cmd
-
-		if msg.LevelUp {
-			// Add level up to activity
-			d.activity = append([]api.Activity{{
-				ID:        fmt.Sprintf("activity_%d", time.Now().UnixNano()),
-				UserID:    d.user.ID,
-				UserName:  d.user.Name,
-				Type:      "level_up",
-				NewLevel:  msg.NewLevel,
-				CreatedAt: time.Now().UnixMilli(),
-			}}, d.activity...)
-
-			// Show level-up modal
-			if d.levelUpModal != nil {
-				newLevel := levels.GetLevelByNumber(msg.NewLevel)
-				d.levelUpModal.Show(newLevel)
-				cmds = append(cmds, components.TickAnimation())
-			}
+	case QuestCompletedMsg:
+		// The completion was already shown optimistically with the quest's
+		// listed XP; reconcile with what the backend actually awarded
+		delete(d.pending, msg.Quest.ID)
+		if msg.Err != nil {
+			d.err = msg.Err
+			d.revertCompletion(msg.Quest, msg.Shown)
+			return d, nil
 		}
+		d.clearTimer(msg.Quest.ID)
+		if delta := msg.XPEarned - msg.Shown; delta != 0 {
+			d.adjustXP(delta)
+		}
+		if msg.Capped {
+			d.notice = fmt.Sprintf("daily cap reached: +%d of %d XP", msg.XPEarned, msg.Shown)
+		}
+		return d, nil
 
-		// Start animation tick if animating
-		if d.animation != nil && d.animation.IsAnimating() {
-			cmds = append(cmds, components.TickAnimation())
+	case BulkCompletedMsg:
+		d.bulkTotal = 0
+		for _, c := range append(msg.Completed, msg.Failed...) {
+			delete(d.pending, c.Quest.ID)
+		}
+		xp := 0
+		for _, c := range msg.Completed {
+			d.applyCompletion(c.Quest, c.XPEarned)
+			d.clearTimer(c.Quest.ID)
+			xp += c.XPEarned
+		}
+		if len(msg.Failed) > 0 {
+			d.err = fmt.Errorf("completed %d of %d quests; %d failed: %v",
+				len(msg.Completed), len(msg.Completed)+len(msg.Failed), len(msg.Failed), msg.Failed[0].Err)
+		}
+		if len(msg.Completed) == 0 {
+			return d, nil
 		}
 
-		if len(cmds) > 0 {
-			return d, tea.Batch(cmds...)
+		// One combined count-up and at most one level-up for the batch
+		if d.animation != nil {
+			if !d.animation.IsCountingXP() {
+				d.animation.SetDisplayedXP(d.user.TotalXP - xp)
+			}
+			d.animation.TriggerQuestFlash(msg.Completed[len(msg.Completed)-1].Quest.ID)
+			d.animation.TriggerXPGain(xp, d.user.TotalXP)
 		}
-		return d, nil
+		d.showLevelUpIfCrossed(d.user.TotalXP-xp, d.user.TotalXP)
+		return d, components.TickAnimation()
 
 	case spinner.TickMsg:
+		// Only an insight reroll animates the spinner; stop once it's back
+		if !d.rerolling {
+			return d, nil
+		}
 		var cmd tea.Cmd
 		d.spinner, cmd = d.spinner.Update(msg)
 		return d, cmd
 	}
 
-	// Update text input
+	// Update text inputs
 	var cmd tea.Cmd
+	if d.xpEditID != "" {
+		d.xpInput, cmd = d.xpInput.Update(msg)
+		return d, cmd
+	}
+	if d.subtaskForID != "" {
+		d.subtaskInput, cmd = d.subtaskInput.Update(msg)
+		return d, cmd
+	}
 	d.input, cmd = d.input.Update(msg)
 	return d, cmd
 }
@@ -645,17 +1296,117 @@ func (d *DashboardModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return d, nil
 	}
 
-	// Clear error on any keypress
+	// Dismiss rival modal on any keypress
+	if d.rivalModal != nil && d.rivalModal.Visible {
+		d.rivalModal.Hide()
+		return d, nil
+	}
+
+	// Dismiss catch-up modal on any keypress
+	if d.catchUpModal != nil && d.catchUpModal.Visible {
+		d.catchUpModal.Hide()
+		return d, nil
+	}
+
+	// Manual XP entry takes all keys until saved or cancelled
+	if d.xpEditID != "" {
+		return d.handleXPEditKey(msg)
+	}
+
+	// So does sub-task entry
+	if d.subtaskForID != "" {
+		return d.handleSubtaskKey(msg)
+	}
+
+	// Pick a template by number; anything else cancels
+	if d.pickTemplate {
+		d.pickTemplate = false
+		names := d.config.TemplateNames()
+		if n, err := strconv.Atoi(key); err == nil && n >= 1 && n <= len(names) {
+			return d, d.addTemplate(names[n-1])
+		}
+		return d, nil
+	}
+
+	// Answer the abandon confirmation; anything but y cancels
+	if d.abandonID != "" {
+		id := d.abandonID
+		d.abandonID = ""
+		if key == "y" || key == "Y" {
+			for _, q := range d.quests {
+				if q.ID == id && !d.pending[id] {
+					return d, d.abandonQuest(q)
+				}
+			}
+		}
+		return d, nil
+	}
+
+	// Answer the missing-crew prompt; other keys are ignored, since a
+	// stray one shouldn't leave the crew
+	if d.missingGroup {
+		switch key {
+		case "y", "Y", "enter":
+			d.missingGroup = false
+			return d, d.leaveMissingGroup()
+		case "n", "N", "esc":
+			d.missingGroup = false
+		}
+		return d, nil
+	}
+
+	// Answer the high-XP completion confirmation; anything but y cancels
+	if d.confirmDoneID != "" {
+		id := d.confirmDoneID
+		d.confirmDoneID = ""
+		if key == "y" || key == "Y" {
+			for _, q := range d.quests {
+				if q.ID == id && q.Status == "in_progress" && !d.pending[id] {
+					return d, d.finishQuest(q)
+				}
+			}
+		}
+		return d, nil
+	}
+
+	// Answer the "complete all" confirmation; anything but y cancels
+	if d.confirmBulk {
+		d.confirmBulk = false
+		if key == "y" || key == "Y" {
+			// Hold every quest in the batch so Enter can't complete one
+			// a second time while the calls run
+			var batch []api.Quest
+			for _, q := range api.Unfinished(d.quests) {
+				if !d.pending[q.ID] {
+					d.pending[q.ID] = true
+					batch = append(batch, q)
+				}
+			}
+			if len(batch) == 0 {
+				return d, nil
+			}
+			d.bulkTotal = len(batch)
+			return d, d.completeAll(batch)
+		}
+		return d, nil
+	}
+
+	// Any key other than a second q disarms the quit confirmation
+	d.quitArmed = false
+
+	// Clear error and input hint on any keypress
 	if d.err != nil {
 		d.err = nil
 	}
+	d.inputHint = ""
+	d.notice = ""
 
 	// Global hotkeys (work regardless of input focus)
 	switch key {
 	case "G":
 		// Open group modal - Shift+G
 		if d.user.GroupID != "" {
-			return d, d.loadGroupInfo()
+			return d, d.loadGroupInfo(true)
 		} else {
 			d.groupModal.ShowNoGroup()
 		}
@@ -665,54 +1416,99 @@ func (d *DashboardModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	// Handle special keys first
 	switch key {
 	case "enter":
-		if d.inputFocused && d.input.Value() != "" {
-			return d.addQuest(d.input.Value())
+		if d.focus == panelInput && d.input.Value() != "" {
+			title := sanitizeTitle(d.input.Value())
+			if title == "" {
+				d.inputHint = "quest can't be blank"
+				return d, nil
+			}
+			return d.addQuest(title)
 		}
-		if d.questFocus && d.selectedQuest >= 0 && d.selectedQuest < len(d.quests) {
+		if d.focus == panelQuests && d.selectedQuest >= 0 && d.selectedQuest < len(d.quests) {
 			return d.handleQuestAction(d.selectedQuest)
 		}
 		return d, nil
 
 	case "tab":
-		d.inputFocused = !d.inputFocused
-		d.questFocus = !d.inputFocused
-		if d.inputFocused {
-			d.input.Focus()
-			d.selectedQuest = -1
-			return d, textinput.Blink
-		} else {
-			d.input.Blur()
-			if len(d.quests) > 0 {
-				d.selectedQuest = 0
-			}
-		}
-		return d, nil
+		// Cycle input → quests → intel feed (when shown) → input
+		return d, d.cycleFocus(1)
+
+	case "shift+tab":
+		return d, d.cycleFocus(-1)
 
 	case "esc":
-		if d.inputFocused {
+		if d.focus == panelInput {
 			d.input.SetValue("")
+		} else if d.boardFilter.IsSet() {
+			d.boardFilter = components.LeaderboardFilter{}
 		}
 		return d, nil
 	}
 
 	// If input is focused, pass all other keys to the text input
-	if d.inputFocused {
+	if d.focus == panelInput {
 		var cmd tea.Cmd
 		d.input, cmd = d.input.Update(msg)
 		return d, cmd
 	}
 
+	// Views bound to capitals, handled only outside the input so typing
+	// "Review PR" or "Lunch" doesn't trigger them
+	switch key {
+	case "R":
+		// Open head-to-head rival view - Shift+R
+		return d, d.loadRival(false)
+
+	case "L":
+		// Toggle weekly / all-time leaderboard - Shift+L (remembered in config)
+		allTime := !d.config.LeaderboardAllTime
+		_ = d.updateConfig(func(c *auth.Config) { c.LeaderboardAllTime = allTime })
+		return d, d.resetLeaderboard()
+	}
+
+	// Leaderboard filters
+	switch key {
+	case "A":
+		d.boardFilter.ActiveToday = !d.boardFilter.ActiveToday
+		return d, nil
+	case "+", "=":
+		if d.boardFilter.MinLevel < len(levels.Levels) {
+			d.boardFilter.MinLevel++
+		}
+		return d, nil
+	case "-":
+		if d.boardFilter.MinLevel > 0 {
+			d.boardFilter.MinLevel--
+		}
+		return d, nil
+	}
+
+	// Reroll the AI insight, from either panel
+	if key == "i" {
+		return d, d.rerollInsight()
+	}
+
+	// Expand or collapse the help footer
+	if key == "h" {
+		d.helpExpanded = !d.helpExpanded
+		return d, nil
+	}
+
+	if d.focus == panelFeed {
+		return d, d.handleFeedKey(key)
+	}
+
 	// Handle keys when input is NOT focused
 	switch key {
 	case "up", "k":
-		if d.questFocus && d.selectedQuest > 0 {
-			d.selectedQuest--
+		if d.focus == panelQuests {
+			d.selectedQuest = moveCursor(d.selectedQuest, -1, len(d.quests), d.config.WrapNavigation)
 		}
 		return d, nil
 
 	case "down", "j":
-		if d.questFocus && d.selectedQuest < len(d.quests)-1 {
-			d.selectedQuest++
+		if d.focus == panelQuests {
+			d.selectedQuest = moveCursor(d.selectedQuest, 1, len(d.quests), d.config.WrapNavigation)
 		}
 		return d, nil
 
@@ -722,71 +1518,444 @@ func (d *DashboardModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			return d.handleQuestAction(idx)
 		}
 
-	case "l":
-		// TODO: Switch to leaderboard screen
+	case "z":
+		// Snooze the selected quest to tomorrow
+		if d.focus == panelQuests && d.selectedQuest >= 0 && d.selectedQuest < len(d.quests) {
+			quest := d.quests[d.selectedQuest]
+			if quest.IsOpen() {
+				return d, d.snoozeQuest(quest)
+			}
+		}
+		return d, nil
+
+	case "X":
+		// Abandon the selected quest, after a y/n confirmation
+		if d.focus == panelQuests && d.selectedQuest >= 0 && d.selectedQuest < len(d.quests) {
+			quest := d.quests[d.selectedQuest]
+			if quest.IsOpen() {
+				d.abandonID = quest.ID
+			}
+		}
+		return d, nil
+
+	case "x":
+		// Set the selected quest's XP by hand
+		if d.focus == panelQuests && d.selectedQuest >= 0 && d.selectedQuest < len(d.quests) {
+			quest := d.quests[d.selectedQuest]
+			if quest.IsOpen() {
+				d.xpEditID = quest.ID
+				d.xpInput.SetValue(strconv.Itoa(quest.XP))
+				d.xpInput.CursorEnd()
+				d.xpInput.Focus()
+				return d, textinput.Blink
+			}
+		}
+		return d, nil
+
+	case "n":
+		// Add a sub-task to the selected quest
+		if d.focus == panelQuests && d.selectedQuest >= 0 && d.selectedQuest < len(d.quests) {
+			quest := d.quests[d.selectedQuest]
+			if quest.IsOpen() {
+				d.subtaskForID = quest.ID
+				d.subtaskInput.SetValue("")
+				d.subtaskInput.Focus()
+				return d, textinput.Blink
+			}
+		}
+		return d, nil
+
+	case " ":
+		// Tick off the selected quest's next sub-task
+		if d.focus == panelQuests && d.selectedQuest >= 0 && d.selectedQuest < len(d.quests) {
+			quest := d.quests[d.selectedQuest]
+			if quest.IsOpen() && quest.NextSubtask() >= 0 && !d.pending[quest.ID] {
+				return d, d.completeSubtask(quest, quest.NextSubtask())
+			}
+		}
+		return d, nil
+
+	case "C":
+		// Complete every unfinished quest, after a y/n confirmation, unless
+		// a batch is already running
+		if d.focus == panelQuests && d.bulkTotal == 0 && len(api.Unfinished(d.quests)) > 0 {
+			d.confirmBulk = true
+		}
+		return d, nil
+
+	case "T":
+		// Add a saved template
+		if len(d.config.Templates) == 0 {
+			d.inputHint = "no templates yet; save one with 'grind template add'"
+		} else {
+			d.pickTemplate = true
+		}
+		return d, nil
+
+	case "K", "shift+up":
+		// Move the selected quest up
+		return d, d.moveQuest(-1)
+
+	case "J", "shift+down":
+		// Move the selected quest down
+		return d, d.moveQuest(1)
+
+	case "d":
+		// Toggle notes/reasoning for the selected quest
+		if d.focus == panelQuests {
+			d.questDetail = !d.questDetail
+		}
+		return d, nil
+
+	case ",":
+		// Open settings
+		return d, func() tea.Msg {
+			return SwitchScreenMsg{Screen: ScreenSettings}
+		}
+
+	case "P":
+		// Re-run setup to change name or crew
+		return d, func() tea.Msg {
+			return SwitchScreenMsg{Screen: ScreenOnboarding}
+		}
+
+	case "l":
+		// TODO: Switch to leaderboard screen
+
+	case "s":
+		// TODO: Switch to stats screen
+
+	case "a":
+		return d, d.setFocus(panelInput)
+	}
+
+	return d, nil
+}
+
+// feedVisible reports whether the intel feed is on screen and has items
+// to select
+func (d *DashboardModel) feedVisible() bool {
+	return d.useCyberHUD && !d.compact && len(d.activity) > 0
+}
+
+// setFocus moves keyboard focus to p, resetting the cursors of the panels
+// it leaves
+func (d *DashboardModel) setFocus(p focusPanel) tea.Cmd {
+	d.focus = p
+	d.selectedQuest = -1
+	switch p {
+	case panelInput:
+		d.input.Focus()
+		return textinput.Blink
+	case panelQuests:
+		d.input.Blur()
+		if len(d.quests) > 0 {
+			d.selectedQuest = 0
+		}
+	case panelFeed:
+		d.input.Blur()
+		d.selectedFeed = 0
+	}
+	return nil
+}
+
+// cycleFocus moves focus dir steps (+1 forward, -1 back) through the
+// panels, skipping the intel feed when it isn't on screen
+func (d *DashboardModel) cycleFocus(dir int) tea.Cmd {
+	next := d.focus
+	for {
+		next = (next + focusPanel(dir) + panelCount) % panelCount
+		if next != panelFeed || d.feedVisible() {
+			return d.setFocus(next)
+		}
+	}
+}
+
+// feedKeys maps reaction keys to api.ReactionEmoji
+var feedKeys = map[string]string{
+	"f": "fire",
+	"m": "muscle",
+	"c": "clap",
+}
+
+// handleFeedKey handles keys while the cursor is in the intel feed
+func (d *DashboardModel) handleFeedKey(key string) tea.Cmd {
+	visible := min(len(d.activity), components.FeedItems)
+	switch key {
+	case "up", "k":
+		d.selectedFeed = moveCursor(d.selectedFeed, -1, visible, d.config.WrapNavigation)
+	case "down", "j":
+		d.selectedFeed = moveCursor(d.selectedFeed, 1, visible, d.config.WrapNavigation)
+	case ",":
+		return func() tea.Msg { return SwitchScreenMsg{Screen: ScreenSettings} }
+	default:
+		emoji, ok := feedKeys[key]
+		if !ok || d.selectedFeed >= visible {
+			return nil
+		}
+		return d.react(d.selectedFeed, emoji)
+	}
+	return nil
+}
+
+// react toggles a reaction on a crew member's completion, optimistically
+func (d *DashboardModel) react(idx int, emoji string) tea.Cmd {
+	activity := &d.activity[idx]
+	if !activity.CanReact(d.user.ID) {
+		return nil
+	}
+	activity.ToggleReaction(d.user.ID, emoji)
+	if !d.remote() {
+		return nil
+	}
+
+	activityID := activity.ID
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		return ReactedMsg{Err: d.client.React(ctx, activityID, d.user.ID, emoji)}
+	}
+}
+
+// ReactedMsg is sent when a reaction toggle has been saved
+type ReactedMsg struct {
+	Err error
+}
+
+// handleXPEditKey handles keys while a quest's XP is being edited
+func (d *DashboardModel) handleXPEditKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		d.stopXPEdit()
+		return d, nil
+	case "enter":
+		xp, err := strconv.Atoi(strings.TrimSpace(d.xpInput.Value()))
+		if err != nil {
+			d.inputHint = "XP must be a number"
+			return d, nil
+		}
+		if err := api.ValidateQuestXP(xp); err != nil {
+			d.inputHint = err.Error()
+			return d, nil
+		}
+		questID := d.xpEditID
+		d.stopXPEdit()
+		return d, d.setQuestXP(questID, xp)
+	}
+
+	d.inputHint = ""
+	var cmd tea.Cmd
+	d.xpInput, cmd = d.xpInput.Update(msg)
+	return d, cmd
+}
+
+// stopXPEdit leaves manual XP entry
+func (d *DashboardModel) stopXPEdit() {
+	d.xpEditID = ""
+	d.xpInput.Blur()
+	d.inputHint = ""
+}
+
+// setQuestXP saves a manual XP override. The quest is updated right away;
+// a failed save reloads the list to undo it.
+func (d *DashboardModel) setQuestXP(questID string, xp int) tea.Cmd {
+	for i := range d.quests {
+		if d.quests[i].ID == questID {
+			d.quests[i].XP = xp
+			d.quests[i].AIReasoning = api.ManualXPReasoning
+		}
+	}
+
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		return QuestXPSetMsg{Err: d.client.SetQuestXP(ctx, questID, xp)}
+	}
+}
+
+// QuestXPSetMsg is sent when a manual XP override has been saved
+type QuestXPSetMsg struct {
+	Err error
+}
+
+// handleSubtaskKey handles keys while a sub-task title is being typed
+func (d *DashboardModel) handleSubtaskKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		d.stopSubtaskEntry()
+		return d, nil
+	case "enter":
+		title := sanitizeTitle(d.subtaskInput.Value())
+		if title == "" {
+			d.inputHint = "sub-task can't be blank"
+			return d, nil
+		}
+		questID := d.subtaskForID
+		d.stopSubtaskEntry()
+		return d, d.addSubtask(questID, title)
+	}
+
+	d.inputHint = ""
+	var cmd tea.Cmd
+	d.subtaskInput, cmd = d.subtaskInput.Update(msg)
+	return d, cmd
+}
+
+// stopSubtaskEntry leaves sub-task entry
+func (d *DashboardModel) stopSubtaskEntry() {
+	d.subtaskForID = ""
+	d.subtaskInput.Blur()
+	d.inputHint = ""
+}
+
+// addSubtask appends a sub-task to a quest. It shows right away; a failed
+// save reloads the list to undo it.
+func (d *DashboardModel) addSubtask(questID, title string) tea.Cmd {
+	for i := range d.quests {
+		if d.quests[i].ID == questID {
+			d.quests[i].Subtasks = append(d.quests[i].Subtasks, api.SubTask{Title: title})
+		}
+	}
+
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		return SubtaskAddedMsg{Err: d.client.AddSubtask(ctx, questID, title)}
+	}
+}
+
+// SubtaskAddedMsg is sent when a new sub-task has been saved
+type SubtaskAddedMsg struct {
+	Err error
+}
+
+// completeSubtask ticks off sub-task index of quest, which pays part of
+// its XP. The XP shows once the backend says what was earned.
+func (d *DashboardModel) completeSubtask(quest api.Quest, index int) tea.Cmd {
+	d.pending[quest.ID] = true
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		res, err := d.client.CompleteSubtask(ctx, quest.ID, index)
+		return SubtaskDoneMsg{Quest: quest, Result: res, Err: err}
+	}
+}
+
+// SubtaskDoneMsg is sent when a sub-task has been ticked off
+type SubtaskDoneMsg struct {
+	Quest  api.Quest
+	Result *api.CompleteResult
+	Err    error
+}
+
+// leaveMissingGroup clears a crew that was deleted while the user was
+// still in it, so crew queries stop failing
+func (d *DashboardModel) leaveMissingGroup() tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		return GroupLeftMsg{Err: d.client.LeaveGroup(ctx, d.user.ID)}
+	}
+}
+
+// GroupLeftMsg is sent when the user has left a crew that no longer exists
+type GroupLeftMsg struct {
+	Err error
+}
 
-	case "s":
-		// TODO: Switch to stats screen
+// resetLeaderboard clears the leaderboard and rank deltas and reloads it,
+// for when the weekly/all-time mode changes
+func (d *DashboardModel) resetLeaderboard() tea.Cmd {
+	d.leaderboard = []api.LeaderboardEntry{}
+	d.prevRanks = map[string]int{}
+	d.rankDeltas = map[string]int{}
+	return d.loadLeaderboard()
+}
 
-	case "a":
-		d.inputFocused = true
-		d.questFocus = false
-		d.input.Focus()
-		d.selectedQuest = -1
-		return d, textinput.Blink
+// ApplyConfig picks up preferences changed on the settings screen
+func (d *DashboardModel) ApplyConfig() tea.Cmd {
+	d.useCyberHUD = d.config.Layout != "classic"
+	d.compact = d.forceCompact || d.config.Layout == "compact"
+	if d.focus == panelFeed && !d.feedVisible() {
+		d.setFocus(panelQuests)
 	}
+	d.pollInterval = d.config.GetPollInterval()
+	// A rename shows in the header straight away; the feed and board pick
+	// it up on the next poll
+	d.user.Name = d.config.UserName
+	d.intelFeed.CurrentUser = d.config.UserName
+	if d.intelFeed.AllTime != d.config.LeaderboardAllTime {
+		return d.resetLeaderboard()
+	}
+	return nil
+}
 
-	return d, nil
+// sanitizeTitle strips control characters that would break rendering and
+// trims surrounding whitespace
+func sanitizeTitle(s string) string {
+	s = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, s)
+	return strings.TrimSpace(s)
 }
 
 func (d *DashboardModel) addQuest(title string) (tea.Model, tea.Cmd) {
 	d.loading = true
+	d.retryLabel = ""
+	return d, d.addQuestCmd(sanitizeTitle(title))
+}
 
-	return d, func() tea.Msg {
-		if d.client == nil {
-			// Fallback to local-only mode if no client
-			return QuestAddedMsg{Quest: api.Quest{
-				ID:          fmt.Sprintf("quest_%d", time.Now().UnixNano()),
-				UserID:      d.user.ID,
-				GroupID:     d.user.GroupID,
-				Title:       title,
-				XP:          estimateXP(title),
-				AIReasoning: "local mode (no backend)",
-				Status:      "pending",
-				CreatedAt:   time.Now().UnixMilli(),
-			}}
+// addTemplate adds every quest in a saved template, in order, each
+// evaluated like a typed quest
+func (d *DashboardModel) addTemplate(name string) tea.Cmd {
+	var cmds []tea.Cmd
+	for _, title := range d.config.Templates[name] {
+		if title = sanitizeTitle(title); title != "" {
+			cmds = append(cmds, d.addQuestCmd(title))
 		}
+	}
+	if len(cmds) == 0 {
+		return nil
+	}
+	d.loading = true
+	d.retryLabel = ""
+	return tea.Sequence(cmds...)
+}
 
+// addQuestCmd evaluates a quest's XP and saves it. The idempotency key is
+// picked here, once per add, so a resent quests:create can't duplicate it.
+func (d *DashboardModel) addQuestCmd(title string) tea.Cmd {
+	key := api.NewIdempotencyKey()
+	return func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
 
-		// Step 1: Get XP from AI
-		var xp int
-		var reasoning string
-
-		aiResult, err := d.client.Action(ctx, "ai:evaluateQuest", map[string]any{
-			"title": title,
-		})
+		// Step 1: Score it, falling back to the keyword estimate if the
+		// evaluator fails
+		var backend api.ConvexAPI
+		if d.remote() {
+			backend = d.client
+		}
+		xp, reasoning, err := evaluator.For(d.config, backend).Evaluate(ctx, title)
 		if err != nil {
-			xp = estimateXP(title)
-			reasoning = "local estimate"
-		} else {
-			data, ok := aiResult.(map[string]any)
-			if !ok {
-				xp = estimateXP(title)
-				reasoning = "local estimate"
-			} else {
-				xp = int(data["xp"].(float64))
-				reasoning = data["reasoning"].(string)
-			}
+			xp, reasoning, _ = evaluator.Keywords{Rules: d.config.XPKeywords}.Evaluate(ctx, title)
 		}
 
 		// Step 2: Save quest to Convex
 		createResult, err := d.client.Mutation(ctx, "quests:create", map[string]any{
-			"userId":      d.user.ID,
-			"title":       title,
-			"xp":          xp,
-			"aiReasoning": reasoning,
+			"userId":         d.user.ID,
+			"title":          title,
+			"xp":             xp,
+			"aiReasoning":    reasoning,
+			"idempotencyKey": key,
 		})
 		if err != nil {
 			return QuestAddedMsg{Err: fmt.Errorf("failed to save quest: %w", err)}
@@ -821,29 +1990,44 @@ func (d *DashboardModel) handleQuestAction(idx int) (tea.Model, tea.Cmd) {
 		return d, nil
 	}
 	quest := d.quests[idx]
+	if d.pending[quest.ID] {
+		// Still waiting on the last action; don't fire it twice
+		return d, nil
+	}
 
+	// Both transitions show immediately and are rolled back if the
+	// mutation fails
 	switch quest.Status {
 	case "pending":
 		// Start the quest
+		d.pending[quest.ID] = true
+		d.setQuestStatus(quest.ID, "in_progress")
 		return d, d.startQuest(quest)
 	case "in_progress":
-		// Complete the quest
-		return d, d.completeQuest(quest)
-	case "completed":
-		// Already done, do nothing
+		// Complete the quest, asking first if it's worth a lot
+		if d.config.ConfirmsDone(d.stats.ActiveEvent(time.Now()).Apply(quest.RemainingXP())) {
+			d.confirmDoneID = quest.ID
+			return d, nil
+		}
+		return d, d.finishQuest(quest)
+	case "completed", "abandoned":
+		// Already done or dropped, do nothing
 		return d, nil
 	}
 	return d, nil
 }
 
+// finishQuest completes an in-progress quest, celebrating right away
+func (d *DashboardModel) finishQuest(quest api.Quest) tea.Cmd {
+	d.pending[quest.ID] = true
+	xp := d.stats.ActiveEvent(time.Now()).Apply(quest.RemainingXP())
+	tick := d.celebrateCompletion(quest, xp)
+	return tea.Batch(tick, d.completeQuest(quest, xp))
+}
+
 // startQuest transitions a quest from pending to in_progress
 func (d *DashboardModel) startQuest(quest api.Quest) tea.Cmd {
 	return func() tea.Msg {
-		if d.client == nil {
-			// Local-only mode
-			return QuestStartedMsg{QuestID: quest.ID}
-		}
-
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 
@@ -858,110 +2042,351 @@ func (d *DashboardModel) startQuest(quest api.Quest) tea.Cmd {
 	}
 }
 
-// completeQuest transitions a quest to completed and earns XP
-func (d *DashboardModel) completeQuest(quest api.Quest) tea.Cmd {
+// snoozeQuest defers a quest to tomorrow
+func (d *DashboardModel) snoozeQuest(quest api.Quest) tea.Cmd {
 	return func() tea.Msg {
-		if d.client == nil {
-			// Local-only mode
-			return QuestCompletedMsg{
-				Quest:    quest,
-				XPEarned: quest.XP,
-				LevelUp:  false,
-				NewLevel: 0,
-			}
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		if err := d.client.SnoozeQuest(ctx, quest.ID); err != nil {
+			return QuestSnoozedMsg{QuestID: quest.ID, Err: err}
 		}
+		return QuestSnoozedMsg{QuestID: quest.ID}
+	}
+}
 
+// abandonQuest drops a quest for the day without deleting it
+func (d *DashboardModel) abandonQuest(quest api.Quest) tea.Cmd {
+	return func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 
-		result, err := d.client.Mutation(ctx, "quests:complete", map[string]any{
-			"questId": quest.ID,
-		})
-		if err != nil {
-			return QuestCompletedMsg{Quest: quest, Err: err}
-		}
+		return QuestAbandonedMsg{Quest: quest, Err: d.client.AbandonQuest(ctx, quest.ID)}
+	}
+}
 
-		// Parse response
-		data, ok := result.(map[string]any)
-		if !ok {
-			return QuestCompletedMsg{
-				Quest:    quest,
-				XPEarned: quest.XP,
-				LevelUp:  false,
-				NewLevel: 0,
-			}
-		}
+// moveQuest moves the selected quest up (-1) or down (+1). The list is
+// updated immediately and the new order saved in the background.
+func (d *DashboardModel) moveQuest(dir int) tea.Cmd {
+	from := d.selectedQuest
+	to := from + dir
+	if d.focus != panelQuests || from < 0 || from >= len(d.quests) || to < 0 || to >= len(d.quests) {
+		return nil
+	}
 
-		xpEarned := int(data["xpEarned"].(float64))
-		leveledUp, _ := data["leveledUp"].(bool)
-		newLevel := 0
-		if leveledUp {
-			newLevel = int(data["newLevel"].(float64))
+	d.quests[from], d.quests[to] = d.quests[to], d.quests[from]
+	d.selectedQuest = to
+
+	ids := make([]string, len(d.quests))
+	for i := range d.quests {
+		d.quests[i].Order = i
+		ids[i] = d.quests[i].ID
+	}
+
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		return QuestsReorderedMsg{Err: d.client.ReorderQuests(ctx, d.user.ID, ids)}
+	}
+}
+
+// QuestsReorderedMsg is sent when a new quest order has been saved
+type QuestsReorderedMsg struct {
+	Err error
+}
+
+// setQuestStatus updates a quest's status in the local list
+func (d *DashboardModel) setQuestStatus(questID, status string) {
+	for i := range d.quests {
+		if d.quests[i].ID == questID {
+			d.quests[i].Status = status
 		}
+	}
+}
 
-		return QuestCompletedMsg{
-			Quest:    quest,
-			XPEarned: xpEarned,
-			LevelUp:  leveledUp,
-			NewLevel: newLevel,
+// keepPendingStatus carries the optimistic status of quests with an action
+// still in flight over into a freshly loaded list, so a poll landing
+// mid-request doesn't flicker them back
+func (d *DashboardModel) keepPendingStatus(quests []api.Quest) []api.Quest {
+	if len(d.pending) == 0 {
+		return quests
+	}
+	status := make(map[string]string, len(d.pending))
+	for _, q := range d.quests {
+		if d.pending[q.ID] {
+			status[q.ID] = q.Status
+		}
+	}
+	for i := range quests {
+		if s, ok := status[quests[i].ID]; ok {
+			quests[i].Status = s
 		}
 	}
+	return quests
 }
 
-// estimateXP provides a rough local XP estimate based on task length/keywords
-// This is a GRIND app - we reward ACTIVE effort, not passive activities
-func estimateXP(title string) int {
-	lower := strings.ToLower(title)
+// celebrateCompletion optimistically completes a quest for xp, its listed
+// XP boosted by any event, with the flash, XP count-up and any level-up,
+// before the backend confirms
+func (d *DashboardModel) celebrateCompletion(quest api.Quest, xp int) tea.Cmd {
+	oldXP := d.user.TotalXP
+	d.applyCompletion(quest, xp)
+
+	// Start the count-up from the old total unless a previous gain is
+	// still counting
+	if d.animation != nil {
+		if !d.animation.IsCountingXP() {
+			d.animation.SetDisplayedXP(oldXP)
+		}
+		d.animation.TriggerQuestFlash(quest.ID)
+		d.animation.TriggerXPGain(xp, d.user.TotalXP)
+	}
+
+	d.showLevelUpIfCrossed(oldXP, d.user.TotalXP)
+
+	// Start a single animation tick loop for the flash, count-up and modal
+	if (d.levelUpModal != nil && d.levelUpModal.Visible) || (d.animation != nil && d.animation.IsAnimating()) {
+		return components.TickAnimation()
+	}
+	return nil
+}
 
-	// Passive activities get 0 XP - not a grind task
-	passive := []string{"sleep", "rest", "nap", "relax", "chill", "watch", "scroll"}
-	for _, kw := range passive {
-		if strings.Contains(lower, kw) {
-			return 0
+// revertCompletion undoes an optimistic completion whose mutation failed
+func (d *DashboardModel) revertCompletion(quest api.Quest, xp int) {
+	for i := range d.quests {
+		if d.quests[i].ID == quest.ID {
+			d.quests[i].Status = quest.Status
+			d.quests[i].CompletedAt = 0
 		}
 	}
 
-	xp := 20 // Base XP for active tasks
+	if d.stats != nil && len(d.stats.RecentCompletions) > 0 {
+		d.stats.RecentCompletions = d.stats.RecentCompletions[:len(d.stats.RecentCompletions)-1]
+	}
+
+	oldLevel := d.user.Level
+	d.adjustXP(-xp)
+	if d.user.Level < oldLevel && d.levelUpModal != nil {
+		d.levelUpModal.Hide()
+	}
+	if d.rivalModal != nil && d.rivalModal.Visible {
+		d.rivalModal.RevertXP(xp)
+	}
 
-	// High effort keywords (+40)
-	highEffort := []string{"ship", "deploy", "launch", "build", "implement", "create", "refactor", "marathon", "10km", "20km"}
-	for _, kw := range highEffort {
-		if strings.Contains(lower, kw) {
-			xp += 40
+	// Drop the feed entry posted for it
+	for i, a := range d.activity {
+		if a.Type == "quest_completed" && a.UserID == d.user.ID && a.QuestTitle == quest.Title {
+			d.activity = append(d.activity[:i], d.activity[i+1:]...)
 			break
 		}
 	}
+}
+
+// adjustXP corrects local XP totals by delta, e.g. when the backend awarded
+// a different amount than was shown optimistically
+func (d *DashboardModel) adjustXP(delta int) {
+	d.user.TotalXP += delta
+	d.user.WeeklyXP += delta
+	d.user.Level = levels.GetLevel(d.user.TotalXP).Number
+	if d.stats != nil {
+		d.stats.Week.XP += delta
+		d.stats.Today.XP += delta
+		if d.stats.Today.XPCap > 0 {
+			d.stats.Today.CapUsed += delta
+		}
+	}
+	if d.animation != nil {
+		if d.animation.IsCountingXP() && d.user.TotalXP >= d.animation.DisplayedXP {
+			d.animation.SetTargetXP(d.user.TotalXP)
+		} else {
+			d.animation.SetDisplayedXP(d.user.TotalXP)
+		}
+	}
+	d.saveProgress()
+}
 
-	// Medium effort keywords (+25)
-	medEffort := []string{"gym", "workout", "run", "fix", "deep work", "study", "learn", "practice", "write", "design", "code"}
-	for _, kw := range medEffort {
-		if strings.Contains(lower, kw) {
-			xp += 25
-			break
+// applyCompletion marks a quest completed locally, adds its XP, and posts
+// it to the activity feed
+func (d *DashboardModel) applyCompletion(quest api.Quest, xp int) {
+	for i := range d.quests {
+		if d.quests[i].ID == quest.ID {
+			d.quests[i].Status = "completed"
+			d.quests[i].CompletedAt = time.Now().UnixMilli()
+		}
+	}
+	d.user.TotalXP += xp
+	d.user.WeeklyXP += xp
+	d.user.Level = levels.GetLevel(d.user.TotalXP).Number
+	if d.stats != nil {
+		d.stats.Week.XP += xp
+		d.stats.Today.XP += xp
+		if d.stats.Today.XPCap > 0 {
+			d.stats.Today.CapUsed += xp
 		}
+		d.stats.RecentCompletions = append(d.stats.RecentCompletions, time.Now().UnixMilli())
+		d.checkGoal()
+	}
+	d.saveProgress()
+	if d.rivalModal != nil && d.rivalModal.Visible {
+		d.rivalModal.ApplyXP(xp)
 	}
 
-	// Small effort keywords (+10)
-	smallEffort := []string{"read", "review", "call", "meeting", "email", "update", "check"}
-	for _, kw := range smallEffort {
-		if strings.Contains(lower, kw) {
-			xp += 10
-			break
+	// Add to activity feed
+	d.addActivity(api.Activity{
+		UserID:     d.user.ID,
+		UserName:   d.user.Name,
+		Type:       "quest_completed",
+		QuestTitle: quest.Title,
+		XP:         xp,
+		CreatedAt:  time.Now().UnixMilli(),
+	})
+}
+
+// earnedToday is the XP earned today so far, 0 until stats load
+func (d *DashboardModel) earnedToday() int {
+	if d.stats == nil {
+		return 0
+	}
+	return d.stats.Today.XP
+}
+
+// checkGoal celebrates the weekly goal the first time it's reached each week
+func (d *DashboardModel) checkGoal() {
+	if d.stats == nil {
+		return
+	}
+	weekXP, week := d.stats.Week.XP, goals.WeekKey(time.Now())
+	if d.config.ClaimGoalCelebration(weekXP, week) {
+		_ = auth.Update(func(c *auth.Config) { c.ClaimGoalCelebration(weekXP, week) })
+		d.notice = fmt.Sprintf("%sweekly goal hit! %d / %d XP", components.Glyphs.Goal, d.stats.Week.XP, d.config.WeeklyGoal)
+	}
+}
+
+// goalNudge is an insight for when the user is behind an even pace toward
+// their weekly goal, pointing at the smallest open quest that would catch
+// them up. It's "" with no goal set or when on pace.
+func (d *DashboardModel) goalNudge() string {
+	if d.stats == nil {
+		return ""
+	}
+	now := time.Now()
+	behind := goals.Behind(d.stats.Week.XP, d.config.WeeklyGoal, now)
+	if behind <= 0 {
+		return ""
+	}
+
+	event := d.stats.ActiveEvent(now)
+	var catchUp *api.Quest
+	for i, q := range d.quests {
+		xp := event.Apply(q.RemainingXP())
+		if q.IsOpen() && !q.IsSnoozed() && xp >= behind && (catchUp == nil || xp < event.Apply(catchUp.RemainingXP())) {
+			catchUp = &d.quests[i]
 		}
 	}
+	if catchUp != nil {
+		return i18n.Tf("goal.nudgeQuest", i18n.Number(behind), truncate(catchUp.Title, 24))
+	}
+	return i18n.Tf("goal.nudge", i18n.Number(behind), i18n.Number(d.config.WeeklyGoal))
+}
+
+// showLevelUp posts a level-up to the activity feed and opens the modal.
+// It reports whether the modal needs the animation tick.
+func (d *DashboardModel) showLevelUp(level int) bool {
+	d.addActivity(api.Activity{
+		UserID:    d.user.ID,
+		UserName:  d.user.Name,
+		Type:      "level_up",
+		NewLevel:  level,
+		CreatedAt: time.Now().UnixMilli(),
+	})
+
+	if d.levelUpModal == nil {
+		return false
+	}
+	d.levelUpModal.Show(levels.GetLevelByNumber(level))
+	return true
+}
 
-	// Length/complexity bonus
-	words := len(strings.Fields(title))
-	if words > 5 {
-		xp += 10
+// showLevelUpIfCrossed shows a single level-up if going from oldXP to newXP
+// crossed one or more level boundaries
+func (d *DashboardModel) showLevelUpIfCrossed(oldXP, newXP int) {
+	newLevel := levels.GetLevel(newXP).Number
+	if newLevel > levels.GetLevel(oldXP).Number {
+		d.showLevelUp(newLevel)
 	}
+}
 
-	// Clamp
-	if xp > 100 {
-		xp = 100
+// completeAll completes every unfinished quest one at a time, keeping the
+// ones that succeed even if later ones fail
+func (d *DashboardModel) completeAll(quests []api.Quest) tea.Cmd {
+	return func() tea.Msg {
+		var msg BulkCompletedMsg
+		for _, quest := range quests {
+			result, err := d.completeQuestCall(quest)
+			if err != nil {
+				msg.Failed = append(msg.Failed, QuestCompletedMsg{Quest: quest, Err: err})
+				continue
+			}
+			msg.Completed = append(msg.Completed, QuestCompletedMsg{Quest: quest, XPEarned: result.XPEarned})
+		}
+		return msg
 	}
+}
+
+// completeQuestCall completes quest through the client
+func (d *DashboardModel) completeQuestCall(quest api.Quest) (*api.CompleteResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	return d.client.CompleteQuest(ctx, quest.ID)
+}
+
+// BulkCompletedMsg is sent when a "complete all" finishes
+type BulkCompletedMsg struct {
+	Completed []QuestCompletedMsg
+	Failed    []QuestCompletedMsg
+}
+
+// completeQuest transitions a quest to completed and earns XP. shown is
+// the XP celebrateCompletion already added.
+func (d *DashboardModel) completeQuest(quest api.Quest, shown int) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		result, err := d.client.Mutation(ctx, "quests:complete", map[string]any{
+			"questId": quest.ID,
+		})
+		if err != nil {
+			return QuestCompletedMsg{Quest: quest, Shown: shown, Err: err}
+		}
+
+		// Parse response
+		var res api.CompleteResult
+		if err := api.DecodeInto(result, &res); err != nil || result == nil {
+			return QuestCompletedMsg{
+				Quest:    quest,
+				XPEarned: shown,
+				Shown:    shown,
+				LevelUp:  false,
+				NewLevel: 0,
+			}
+		}
+
+		newLevel := 0
+		if res.LeveledUp {
+			newLevel = res.NewLevel
+		}
 
-	return xp
+		return QuestCompletedMsg{
+			Quest:    quest,
+			XPEarned: res.XPEarned,
+			LevelUp:  res.LeveledUp,
+			NewLevel: newLevel,
+			Capped:   res.Capped,
+			Shown:    shown,
+		}
+	}
 }
 
 // View renders the dashboard
@@ -971,14 +2396,23 @@ func (d *DashboardModel) View() string {
 		return d.groupModal.View(d.width, d.height)
 	}
 
+	// Check for rival modal overlay
+	if d.rivalModal != nil && d.rivalModal.Visible {
+		return d.rivalModal.View(d.width, d.height)
+	}
+
+	// Check for catch-up modal overlay
+	if d.catchUpModal != nil && d.catchUpModal.Visible {
+		return d.catchUpModal.View(d.width, d.height)
+	}
+
 	// Check for level-up modal overlay
 	if d.levelUpModal != nil && d.levelUpModal.Visible {
-		baseView := d.renderCyberHUD()
-		modalView := d.levelUpModal.View(d.width, d.height)
-		if modalView != "" {
-			return modalView
-		}
-		return baseView
+		return d.levelUpModal.View(d.width, d.height)
+	}
+
+	if d.compact {
+		return d.renderCompactView()
 	}
 
 	if d.useCyberHUD {
@@ -992,7 +2426,17 @@ func (d *DashboardModel) View() string {
 func (d *DashboardModel) renderCyberHUD() string {
 	// Update component data
 	d.headerComp.Update(d.user, d.stats)
-	d.questPanel.Update(d.quests, d.selectedQuest, d.questFocus)
+	d.headerComp.Animation = d.animation
+	d.headerComp.WeeklyGoal = d.config.WeeklyGoal
+	d.headerComp.StreakFreezes = d.config.GetStreakFreezes()
+	d.headerComp.Connection = d.connection()
+	d.headerComp.Profile = d.profileLabel()
+	d.questPanel.Update(d.quests, d.selectedQuest, d.focus == panelQuests)
+	d.questPanel.Expanded = d.questDetail
+	d.questPanel.Animation = d.animation
+	d.questPanel.Event = d.stats.ActiveEvent(time.Now())
+	d.questPanel.Timers = d.config.QuestTimers
+	d.questPanel.EarnedToday = d.earnedToday()
 
 	// Get AI insight from stats
 	insight := ""
@@ -1001,7 +2445,28 @@ func (d *DashboardModel) renderCyberHUD() string {
 		insight = d.stats.CompetitiveInsight
 		insightType = d.stats.InsightType
 	}
+	// Falling behind on the weekly goal beats a generic insight, though
+	// not a rivalry alert, until the user asks for a fresh one with i
+	if nudge := d.goalNudge(); nudge != "" && insightType != "rivalry" && d.rerolledAt.IsZero() {
+		insight, insightType = nudge, "analyst"
+	}
 	d.intelFeed.Update(d.activity, d.leaderboard, insight, insightType)
+	d.intelFeed.InsightSpinner = ""
+	if d.rerolling {
+		d.intelFeed.InsightSpinner = d.spinner.View()
+	}
+	d.intelFeed.AllTime = d.config.LeaderboardAllTime
+	d.intelFeed.Filter = d.boardFilter
+	d.intelFeed.Selected = -1
+	d.intelFeed.Focused = d.focus == panelFeed
+	d.intelFeed.InviteCode = d.inviteCode
+	d.intelFeed.MemberCount = 0
+	if d.stats != nil && d.stats.Group != nil {
+		d.intelFeed.MemberCount = d.stats.Group.MemberCount
+	}
+	if d.focus == panelFeed {
+		d.intelFeed.Selected = d.selectedFeed
+	}
 
 	// Render header
 	header := d.headerComp.View()
@@ -1041,6 +2506,52 @@ func (d *DashboardModel) renderCyberHUD() string {
 	)
 }
 
+// renderCompactView renders a single column of header and quests, with no
+// intel feed, for side-docked panes around 50 columns wide
+func (d *DashboardModel) renderCompactView() string {
+	width := d.compactWidth()
+
+	d.compactHeader.Update(d.user, d.stats)
+	d.compactHeader.Animation = d.animation
+	d.compactHeader.WeeklyGoal = d.config.WeeklyGoal
+	d.compactHeader.StreakFreezes = d.config.GetStreakFreezes()
+	d.compactHeader.Connection = d.connection()
+	d.compactHeader.Width = width
+	d.compactQuests.Update(d.quests, d.selectedQuest, d.focus == panelQuests)
+	d.compactQuests.Expanded = d.questDetail
+	d.compactQuests.Animation = d.animation
+	d.compactQuests.Event = d.stats.ActiveEvent(time.Now())
+	d.compactQuests.Timers = d.config.QuestTimers
+	d.compactQuests.EarnedToday = d.earnedToday()
+	d.compactQuests.Width = width
+
+	var errorLine string
+	if d.err != nil {
+		errorLine = ErrorStyle.Width(width).Render(fmt.Sprintf("error: %v", d.err))
+	}
+
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		d.compactHeader.View(),
+		d.compactQuests.View(),
+		d.renderInputWidth(width),
+		lipgloss.NewStyle().Width(width).Render(d.renderHelp()),
+		errorLine,
+	)
+}
+
+// compactWidth returns the column width for the compact layout
+func (d *DashboardModel) compactWidth() int {
+	width := d.width - 2
+	if width < 40 {
+		width = 40
+	}
+	if width > 60 {
+		width = 60
+	}
+	return width
+}
+
 // renderClassicView renders the old-style dashboard (fallback)
 func (d *DashboardModel) renderClassicView() string {
 	// Header with user info
@@ -1081,22 +2592,38 @@ func (d *DashboardModel) renderClassicView() string {
 	)
 }
 
+// profileLabel names the profile and crew in use, so an account mix-up is
+// visible at a glance. The default profile has no label; a guest account
+// is badged as local mode instead.
+func (d *DashboardModel) profileLabel() string {
+	if d.config.Local {
+		return i18n.T("dashboard.localMode")
+	}
+	p := auth.Profile()
+	if p == auth.DefaultProfile {
+		return ""
+	}
+	if d.config.GroupName != "" {
+		return p + " " + components.Glyphs.Dot + " " + d.config.GroupName
+	}
+	return p
+}
+
+// headerColWidth is the width of each stats column in the classic header,
+// and headerNameWidth caps the user's name in its greeting
+const (
+	headerColWidth  = 16
+	headerNameWidth = 20
+)
+
 func (d *DashboardModel) renderHeader() string {
 	level := levels.GetLevelByNumber(d.user.Level)
 
-	// Greeting based on time of day
-	hour := time.Now().Hour()
-	greeting := "hey"
-	if hour < 12 {
-		greeting = "gm"
-	} else if hour < 17 {
-		greeting = "hey"
-	} else {
-		greeting = "evening"
-	}
+	// Greeting based on time of day in the user's timezone
+	greeting := Greeting(time.Now().In(d.config.Location()), d.greetingVariant)
 
 	// Title line
-	title := fmt.Sprintf("%s %s", greeting, d.user.Name)
+	title := fmt.Sprintf("%s %s", greeting, truncate(d.user.Name, headerNameWidth))
 	levelBadge := LevelBadgeStyle.Render(fmt.Sprintf("L%d %s", level.Number, level.Name))
 
 	titleLine := lipgloss.JoinHorizontal(
@@ -1105,80 +2632,94 @@ func (d *DashboardModel) renderHeader() string {
 		"  ",
 		levelBadge,
 	)
+	if label := d.profileLabel(); label != "" {
+		titleLine += "  " + MutedStyle.Render(label)
+	}
+	if conn := d.connection(); conn != nil {
+		titleLine += "  " + conn.View()
+	}
 
 	// Stats columns
 	var todayCol, weekCol, crewCol string
 
 	if d.stats != nil {
 		// Today column
-		todayXP := fmt.Sprintf("+%d XP", d.stats.Today.XP)
+		todayXP := fmt.Sprintf("+%s XP", i18n.Number(d.stats.Today.XP))
 		if d.stats.Today.XP == 0 {
 			todayXP = "0 XP"
 		}
-		todayQuests := fmt.Sprintf("%d/%d done", d.stats.Today.QuestsCompleted, d.stats.Today.QuestsTotal)
+		todayQuests := i18n.Tf("dashboard.questsDone", d.stats.Today.QuestsCompleted, d.stats.Today.QuestsTotal)
 		if d.stats.Today.QuestsTotal == 0 {
-			todayQuests = "no quests"
+			todayQuests = i18n.T("dashboard.noQuests")
 		}
 		todayCol = lipgloss.JoinVertical(lipgloss.Left,
-			MutedStyle.Render("today"),
+			MutedStyle.Render(i18n.T("dashboard.today")),
 			XPStyle.Render(todayXP),
 			MutedStyle.Render(todayQuests),
 		)
 
 		// Week column
-		weekXP := fmt.Sprintf("%d XP", d.stats.Week.XP)
+		weekXP := fmt.Sprintf("%s XP", i18n.Number(d.stats.Week.XP))
 		var weekRank string
 		if d.stats.Week.Rank > 0 {
-			weekRank = fmt.Sprintf("#%d rank", d.stats.Week.Rank)
+			weekRank = i18n.Tf("dashboard.rank", d.stats.Week.Rank)
 		} else {
-			weekRank = "no group"
+			weekRank = i18n.T("dashboard.noGroup")
 		}
-		weekCol = lipgloss.JoinVertical(lipgloss.Left,
-			MutedStyle.Render("this week"),
+		weekLines := []string{
+			MutedStyle.Render(i18n.T("dashboard.thisWeek")),
 			XPStyle.Render(weekXP),
 			MutedStyle.Render(weekRank),
-		)
+		}
+		if d.stats.Group != nil {
+			// The crew's combined week, so individual ranks aren't the
+			// only sense of how the team is doing
+			weekLines = append(weekLines, MutedStyle.Render(i18n.Tf("dashboard.crewWeek", i18n.Number(d.stats.Group.GroupWeeklyXP))))
+		}
+		weekCol = lipgloss.JoinVertical(lipgloss.Left, weekLines...)
 
 		// Crew column
 		if d.stats.Group != nil {
-			activeStr := fmt.Sprintf("%d active", d.stats.Group.ActiveToday)
+			activeStr := i18n.Tf("dashboard.active", d.stats.Group.ActiveToday, d.stats.Group.MemberCount)
 			var leaderStr string
 			if d.stats.Group.IsUserLeading {
-				leaderStr = "you're leading!"
+				leaderStr = i18n.T("dashboard.youLead")
 			} else {
-				leaderStr = fmt.Sprintf("%s leading", truncate(d.stats.Group.LeaderName, 10))
+				// Fit the name in what the column has left around the text
+				nameWidth := headerColWidth - 1 - lipgloss.Width(i18n.Tf("dashboard.leading", ""))
+				leaderStr = i18n.Tf("dashboard.leading", truncate(d.stats.Group.LeaderName, max(nameWidth, 4)))
 			}
 			crewCol = lipgloss.JoinVertical(lipgloss.Left,
-				MutedStyle.Render("crew"),
-				XPStyle.Render(fmt.Sprintf("%d members", d.stats.Group.MemberCount)),
+				MutedStyle.Render(i18n.T("dashboard.crew")),
+				XPStyle.Render(fmt.Sprintf("%s XP", i18n.Number(d.stats.Group.GroupTodayXP))),
 				MutedStyle.Render(activeStr),
 				MutedStyle.Render(leaderStr),
 			)
 		} else {
 			crewCol = lipgloss.JoinVertical(lipgloss.Left,
-				MutedStyle.Render("crew"),
-				MutedStyle.Render("no group"),
-				MutedStyle.Render("join one!"),
+				MutedStyle.Render(i18n.T("dashboard.crew")),
+				MutedStyle.Render(i18n.T("dashboard.noGroup")),
+				MutedStyle.Render(i18n.T("dashboard.joinOne")),
 			)
 		}
 	} else {
 		// Loading state
 		todayCol = lipgloss.JoinVertical(lipgloss.Left,
-			MutedStyle.Render("today"),
+			MutedStyle.Render(i18n.T("dashboard.today")),
 			MutedStyle.Render("..."),
 		)
 		weekCol = lipgloss.JoinVertical(lipgloss.Left,
-			MutedStyle.Render("this week"),
+			MutedStyle.Render(i18n.T("dashboard.thisWeek")),
 			MutedStyle.Render("..."),
 		)
 		crewCol = lipgloss.JoinVertical(lipgloss.Left,
-			MutedStyle.Render("crew"),
+			MutedStyle.Render(i18n.T("dashboard.crew")),
 			MutedStyle.Render("..."),
 		)
 	}
 
 	// Style columns with fixed width
-	colStyle := lipgloss.NewStyle().Width(16)
+	colStyle := lipgloss.NewStyle().Width(headerColWidth)
 	statsRow := lipgloss.JoinHorizontal(
 		lipgloss.Top,
 		colStyle.Render(todayCol),
@@ -1194,7 +2735,7 @@ func (d *DashboardModel) renderHeader() string {
 			insightLine = lipgloss.NewStyle().
 				Foreground(ColorPrimary).
 				Bold(true).
-				Render("→ " + d.stats.CompetitiveInsight)
+				Render(components.Glyphs.Arrow + " " + d.stats.CompetitiveInsight)
 		} else if d.stats.Quote != "" {
 			// Fallback to quote
 			insightLine = MutedStyle.Render(fmt.Sprintf("\"%s\"", d.stats.Quote))
@@ -1204,7 +2745,7 @@ func (d *DashboardModel) renderHeader() string {
 	content := lipgloss.JoinVertical(
 		lipgloss.Left,
 		titleLine,
-		MutedStyle.Render("═══════════════════════════════════════════════"),
+		MutedStyle.Render(strings.Repeat(components.Glyphs.Rule, 47)),
 		"",
 		statsRow,
 		"",
@@ -1215,64 +2756,79 @@ func (d *DashboardModel) renderHeader() string {
 }
 
 func (d *DashboardModel) renderQuestPanel() string {
-	title := TitleStyle.Render("today's quests")
+	title := TitleStyle.Render(i18n.T("dashboard.todaysQuests"))
 
 	// Legend explaining the symbols
-	legend := MutedStyle.Render("☐ todo  ◐ working  ✓ done")
+	g := components.Glyphs
+	legend := MutedStyle.Render(i18n.Tf("dashboard.legend", g.Todo, g.Working, g.Check))
 
 	var questLines []string
 	activeCount := 0
 	potentialXP := 0
 
+	event := d.stats.ActiveEvent(time.Now())
 	for i, q := range d.quests {
 		var line string
 		xpStr := XPStyle.Render(fmt.Sprintf("%dXP", q.XP))
-		isSelected := d.questFocus && i == d.selectedQuest
+		if event != nil && q.IsOpen() {
+			xpStr += XPStyle.Render(components.Multiplier(event.Multiplier))
+		}
+		isSelected := d.focus == panelQuests && i == d.selectedQuest
 
 		switch q.Status {
 		case "completed":
 			// ✓ Completed - muted, no XP shown
 			if isSelected {
-				line = fmt.Sprintf("→  ✓ %s", MutedStyle.Render(truncate(q.Title, 20)))
+				line = fmt.Sprintf("%s  %s %s", g.Arrow, g.Check, MutedStyle.Render(truncate(q.Title, 20)))
+			} else {
+				line = fmt.Sprintf("[%d] %s %s", i+1, g.Check, MutedStyle.Render(truncate(q.Title, 20)))
+			}
+
+		case "abandoned":
+			// – Abandoned - muted, no XP shown
+			if isSelected {
+				line = fmt.Sprintf("%s  %s %s", g.Arrow, g.Dropped, MutedStyle.Render(truncate(q.Title, 20)))
 			} else {
-				line = fmt.Sprintf("[%d] ✓ %s", i+1, MutedStyle.Render(truncate(q.Title, 20)))
+				line = fmt.Sprintf("[%d] %s %s", i+1, g.Dropped, MutedStyle.Render(truncate(q.Title, 20)))
 			}
 
 		case "in_progress":
 			// ◐ In progress - highlighted in gold
 			activeCount++
-			potentialXP += q.XP
+			potentialXP += event.Apply(q.RemainingXP())
 			if isSelected {
-				line = fmt.Sprintf("→  ◐ %s %s", InProgressStyle.Render(truncate(q.Title, 12)), xpStr)
+				line = fmt.Sprintf("%s  %s %s %s", g.Arrow, g.Working, InProgressStyle.Render(truncate(q.Title, 12)), xpStr)
 				line += HelpStyle.Render(" [done]")
 			} else {
-				line = fmt.Sprintf("[%d] ◐ %s %s", i+1, InProgressStyle.Render(truncate(q.Title, 15)), xpStr)
+				line = fmt.Sprintf("[%d] %s %s %s", i+1, g.Working, InProgressStyle.Render(truncate(q.Title, 15)), xpStr)
 			}
 
 		default: // "pending"
 			// ☐ Pending - normal
 			activeCount++
-			potentialXP += q.XP
+			potentialXP += event.Apply(q.RemainingXP())
 			if isSelected {
-				line = fmt.Sprintf("→  ☐ %s %s", QuestSelectedStyle.Render(truncate(q.Title, 12)), xpStr)
+				line = fmt.Sprintf("%s  %s %s %s", g.Arrow, g.Todo, QuestSelectedStyle.Render(truncate(q.Title, 12)), xpStr)
 				line += HelpStyle.Render(" [start]")
 			} else {
-				line = fmt.Sprintf("[%d] ☐ %s %s", i+1, truncate(q.Title, 15), xpStr)
+				line = fmt.Sprintf("[%d] %s %s %s", i+1, g.Todo, truncate(q.Title, 15), xpStr)
 			}
 		}
+		line += MutedStyle.Render(components.SubtaskBadge(q) + components.StatusLabel(q.Status, q.IsSnoozed()))
 
 		questLines = append(questLines, line)
 	}
 
 	if len(questLines) == 0 {
-		questLines = append(questLines, MutedStyle.Render("no quests yet"))
-		questLines = append(questLines, MutedStyle.Render("type below to add one"))
+		questLines = append(questLines, MutedStyle.Render(i18n.T("dashboard.noQuestsYet")))
+		questLines = append(questLines, MutedStyle.Render(i18n.T("dashboard.typeToAdd")))
 	}
 
-	// Summary
+	// Summary: earned today and what's still on the table
 	var summary string
-	if activeCount > 0 {
-		summary = fmt.Sprintf("\npotential: %s", XPStyle.Render(fmt.Sprintf("+%d XP", potentialXP)))
+	if earned := d.earnedToday(); activeCount > 0 || earned > 0 {
+		summary = "\n" + i18n.Tf("dashboard.earned", SuccessStyle.Render(fmt.Sprintf("+%d", earned))) + " / " +
+			i18n.Tf("dashboard.potential", XPStyle.Render(fmt.Sprintf("+%d XP", potentialXP)))
 	}
 
 	questList := strings.Join(questLines, "\n")
@@ -1308,20 +2864,23 @@ func (d *DashboardModel) renderActivityPanel() string {
 			var line string
 			switch a.Type {
 			case "quest_completed":
-				line = fmt.Sprintf("✓ %s", truncate(a.QuestTitle, 12))
+				line = fmt.Sprintf("%s %s", components.Glyphs.Check, truncate(a.QuestTitle, 12))
 				activityLines = append(activityLines, SuccessStyle.Render(line))
 				activityLines = append(activityLines, XPStyle.Render(fmt.Sprintf("  +%d XP", a.XP)))
 			case "quest_started":
-				line = fmt.Sprintf("◐ %s", truncate(a.QuestTitle, 12))
+				line = fmt.Sprintf("%s %s", components.Glyphs.Working, truncate(a.QuestTitle, 12))
 				activityLines = append(activityLines, ActivityStyle.Render(line))
 			case "quest_created":
 				line = fmt.Sprintf("+ %s", truncate(a.QuestTitle, 12))
 				activityLines = append(activityLines, ActivityStyle.Render(line))
+			case "quest_abandoned":
+				line = fmt.Sprintf("%s %s", components.Glyphs.Dropped, truncate(a.QuestTitle, 12))
+				activityLines = append(activityLines, MutedStyle.Render(line))
 			case "level_up":
-				line = fmt.Sprintf("⚡ LEVEL %d!", a.NewLevel)
+				line = fmt.Sprintf("%s LEVEL %d!", components.Glyphs.LevelUp, a.NewLevel)
 				activityLines = append(activityLines, LevelStyle.Render(line))
 			default:
-				line = fmt.Sprintf("• %s", a.Type)
+				line = fmt.Sprintf("%s %s", components.Glyphs.Bullet, a.Type)
 				activityLines = append(activityLines, ActivityStyle.Render(line))
 			}
 		}
@@ -1337,34 +2896,191 @@ func (d *DashboardModel) renderActivityPanel() string {
 	return BoxStyleMuted.Width(22).Height(12).Render(content)
 }
 
-// truncate shortens a string to max length
+// truncate shortens s to max cells, ending it with an ellipsis
 func truncate(s string, max int) string {
-	if len(s) <= max {
-		return s
-	}
-	return s[:max-1] + "…"
+	return components.FitLine(s, max)
 }
 
 func (d *DashboardModel) renderInput() string {
+	return d.renderInputWidth(58)
+}
+
+// renderInputWidth renders the input bar at the given width
+func (d *DashboardModel) renderInputWidth(width int) string {
 	var prefix string
 	if d.loading {
 		prefix = d.spinner.View() + " "
+		if d.retryLabel != "" {
+			prefix += MutedStyle.Render(d.retryLabel) + " "
+		}
 	} else {
 		prefix = "> "
 	}
 
 	style := InputStyle
-	if d.inputFocused {
+	if d.focus == panelInput {
 		style = InputFocusedStyle
 	}
 
 	// textinput.View() already includes the cursor, just add our prefix
-	return style.Width(58).Render(prefix + d.input.View())
+	input := style.Width(width).Render(prefix + d.input.View())
+	if d.xpEditID != "" {
+		input = InputFocusedStyle.Width(width).Render("xp> " + d.xpInput.View())
+	} else if d.subtaskForID != "" {
+		input = InputFocusedStyle.Width(width).Render("sub-task> " + d.subtaskInput.View())
+	}
+
+	// Character counter once the title approaches the limit
+	var status string
+	length := len([]rune(d.input.Value()))
+	limit := d.input.CharLimit
+	if d.inputHint != "" {
+		status = ErrorStyle.Render(d.inputHint)
+	} else if d.notice != "" {
+		status = SuccessStyle.Render(d.notice)
+	} else if d.xpEditID != "" {
+		status = MutedStyle.Render(fmt.Sprintf("set XP (%d-%d)%senter save%sesc cancel", api.MinQuestXP, api.MaxQuestXP, helpSeparator(), helpSeparator()))
+	} else if d.subtaskForID != "" {
+		status = MutedStyle.Render(strings.Join([]string{"add sub-task", "enter save", "esc cancel"}, helpSeparator()))
+	} else if d.focus == panelInput && limit > 0 && length >= limit-40 {
+		counter := fmt.Sprintf("%d/%d", length, limit)
+		if length >= limit {
+			status = ErrorStyle.Render(counter + helpSeparator() + "limit reached")
+		} else {
+			status = MutedStyle.Render(counter)
+		}
+	}
+
+	if status == "" {
+		return input
+	}
+	return lipgloss.JoinVertical(lipgloss.Right, input, status)
 }
 
 func (d *DashboardModel) renderHelp() string {
-	if d.inputFocused {
-		return HelpStyle.Render("enter add task · tab switch to quests · G crew · q quit")
+	if d.abandonID != "" {
+		for _, q := range d.quests {
+			if q.ID == d.abandonID {
+				return InProgressStyle.Render(fmt.Sprintf("abandon %q? it stays in your history for 0 XP", truncate(q.Title, 24))) +
+					HelpStyle.Render(" y confirm" + helpSeparator() + "any other key cancels")
+			}
+		}
+	}
+	if d.missingGroup {
+		return InProgressStyle.Render("your crew no longer exists. leave it?") +
+			HelpStyle.Render(" enter/y leave" + helpSeparator() + "n/esc keep")
+	}
+	if d.confirmDoneID != "" {
+		for _, q := range d.quests {
+			if q.ID == d.confirmDoneID {
+				xp := d.stats.ActiveEvent(time.Now()).Apply(q.RemainingXP())
+				return InProgressStyle.Render(fmt.Sprintf("complete %q for +%d XP?", truncate(q.Title, 24), xp)) +
+					HelpStyle.Render(" y confirm" + helpSeparator() + "any other key cancels")
+			}
+		}
+	}
+	if d.confirmBulk {
+		pending := api.Unfinished(d.quests)
+		event := d.stats.ActiveEvent(time.Now())
+		xp := 0
+		for _, q := range pending {
+			xp += event.Apply(q.RemainingXP())
+		}
+		return InProgressStyle.Render(fmt.Sprintf("complete all %d quests for +%d XP?", len(pending), xp)) +
+			HelpStyle.Render(" y confirm" + helpSeparator() + "any other key cancels")
+	}
+	if d.bulkTotal > 0 {
+		return InProgressStyle.Render("completing " + i18n.Plural("plural.quest", d.bulkTotal) + components.Glyphs.Ellipsis)
+	}
+	if d.pickTemplate {
+		var choices []string
+		for i, name := range d.config.TemplateNames() {
+			if i == 9 {
+				break
+			}
+			choices = append(choices, fmt.Sprintf("%d %s (%d)", i+1, name, len(d.config.Templates[name])))
+		}
+		return InProgressStyle.Render("add template: ") + HelpStyle.Render(strings.Join(append(choices, "any other key cancels"), helpSeparator()))
+	}
+	if d.idle {
+		return HelpStyle.Render(helpf("dashboard.idle"))
+	}
+	if d.helpExpanded {
+		return d.renderCheatSheet()
+	}
+	if d.focus == panelInput {
+		return HelpStyle.Render(helpf("dashboard.helpInput"))
+	}
+	if d.focus == panelFeed {
+		g := components.Glyphs.Reactions
+		help := HelpStyle.Render(helpf("dashboard.helpFeed", g["fire"], g["muscle"], g["clap"]))
+		// Spell out a name the feed had to cut short
+		if name := d.intelFeed.SelectedFullName(); name != "" {
+			help = InProgressStyle.Render(name) + HelpStyle.Render(helpSeparator()) + help
+		}
+		return help
+	}
+	return HelpStyle.Render(helpf("dashboard.helpQuests"))
+}
+
+// helpSeparator goes between keys in help lines
+func helpSeparator() string {
+	return " " + components.Glyphs.Dot + " "
+}
+
+// helpf formats a help line from the catalog with the active glyphs: the
+// separator as %[1]s and the up/down arrows as %[2]s, then args
+func helpf(key string, args ...any) string {
+	return i18n.Tf(key, append([]any{components.Glyphs.Dot, components.Glyphs.UpDown}, args...)...)
+}
+
+// renderCheatSheet renders the expanded help: navigation and global keys
+// on one line and actions on the next when the terminal is wide enough,
+// wrapping each group onto more lines when it isn't
+func (d *DashboardModel) renderCheatSheet() string {
+	width := d.width
+	if d.compact {
+		width = d.compactWidth()
+	}
+	if width <= 0 {
+		width = 80
+	}
+
+	nav := helpGroup(i18n.T("dashboard.cheatNav"), helpf("dashboard.cheatNavKeys"), width)
+	global := helpGroup(i18n.T("dashboard.cheatGlobal"), helpf("dashboard.cheatGlobalKeys"), width)
+	actions := helpGroup(i18n.T("dashboard.cheatActions"), helpf("dashboard.cheatActionsKeys"), width)
+
+	var lines []string
+	if len(nav) == 1 && len(global) == 1 && lipgloss.Width(nav[0])+3+lipgloss.Width(global[0]) <= width {
+		lines = append(lines, nav[0]+"   "+global[0])
+	} else {
+		lines = append(append(lines, nav...), global...)
+	}
+	lines = append(lines, actions...)
+	return strings.Join(lines, "\n")
+}
+
+// helpGroup lays out one cheat-sheet group: its label, then keys (items
+// separated by helpSeparator) packed into lines no wider than width, with
+// continuation lines indented under the first key
+func helpGroup(label, keys string, width int) []string {
+	label = lipgloss.NewStyle().Foreground(ColorPrimary).Render(label) + " "
+	indent := strings.Repeat(" ", lipgloss.Width(label))
+
+	var lines []string
+	line := label
+	empty := true
+	for _, item := range strings.Split(keys, helpSeparator()) {
+		switch {
+		case empty:
+			line += HelpStyle.Render(item)
+			empty = false
+		case lipgloss.Width(line)+3+lipgloss.Width(item) > width:
+			lines = append(lines, line)
+			line = indent + HelpStyle.Render(item)
+		default:
+			line += HelpStyle.Render(helpSeparator() + item)
+		}
 	}
-	return HelpStyle.Render("enter start/done · ↑↓ select · G crew · a add · q quit")
+	return append(lines, line)
 }
@@ -0,0 +1,330 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"grind/internal/api"
+	"grind/internal/api/apitest"
+	"grind/internal/auth"
+	"grind/internal/store"
+)
+
+// polledActivity makes n backend items, newest first, a second apart
+func polledActivity(n int, newest int64) []api.Activity {
+	items := make([]api.Activity, n)
+	for i := range items {
+		items[i] = api.Activity{
+			ID:         fmt.Sprintf("polled_%d", i),
+			Type:       "quest_completed",
+			UserID:     "u1",
+			QuestTitle: fmt.Sprintf("quest %d", i),
+			CreatedAt:  newest - int64(i)*1000,
+		}
+	}
+	return items
+}
+
+func TestMergeActivityCap(t *testing.T) {
+	now := time.UnixMilli(1_700_000_000_000)
+	tests := []struct {
+		name    string
+		current int
+		polled  int
+		want    int
+	}{
+		{"empty", 0, 0, 0},
+		{"under cap", 0, 10, 10},
+		{"at cap", 0, maxActivity, maxActivity},
+		{"over cap", 0, maxActivity + 50, maxActivity},
+		{"repeated polls don't grow", maxActivity, maxActivity, maxActivity},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			current := polledActivity(tt.current, now.UnixMilli())
+			polled := polledActivity(tt.polled, now.UnixMilli())
+			if got := mergeActivity(current, polled, now); len(got) != tt.want {
+				t.Errorf("merged %d items, want %d", len(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestAddActivityCap(t *testing.T) {
+	d := &DashboardModel{}
+	for i := range maxActivity + 20 {
+		d.addActivity(api.Activity{Type: "quest_added", QuestTitle: fmt.Sprintf("quest %d", i)})
+	}
+	if len(d.activity) != maxActivity {
+		t.Fatalf("feed holds %d items, want %d", len(d.activity), maxActivity)
+	}
+	if got := d.activity[0].QuestTitle; got != fmt.Sprintf("quest %d", maxActivity+19) {
+		t.Errorf("newest item is %q", got)
+	}
+}
+
+func TestMergeActivityDedupe(t *testing.T) {
+	now := time.UnixMilli(1_700_000_000_000)
+	at := func(ago time.Duration) int64 { return now.Add(-ago).UnixMilli() }
+	item := func(id, title string, createdAt int64) api.Activity {
+		return api.Activity{ID: id, Type: "quest_completed", UserID: "u1", QuestTitle: title, CreatedAt: createdAt}
+	}
+
+	tests := []struct {
+		name    string
+		current []api.Activity
+		polled  []api.Activity
+		want    []string
+	}{
+		{
+			name:   "duplicate IDs in a poll",
+			polled: []api.Activity{item("p1", "ship", at(0)), item("p1", "ship", at(0))},
+			want:   []string{"p1"},
+		},
+		{
+			name:    "polled items are replaced by the next poll",
+			current: []api.Activity{item("p0", "old", at(time.Minute))},
+			polled:  []api.Activity{item("p1", "ship", at(0))},
+			want:    []string{"p1"},
+		},
+		{
+			name:    "local item replaced by the backend's copy",
+			current: []api.Activity{item("local_1", "ship", at(time.Second))},
+			polled:  []api.Activity{item("p1", "ship", at(0))},
+			want:    []string{"p1"},
+		},
+		{
+			name:    "local item kept until the backend catches up",
+			current: []api.Activity{item("local_1", "ship", at(0))},
+			polled:  []api.Activity{item("p1", "other", at(time.Minute))},
+			want:    []string{"local_1", "p1"},
+		},
+		{
+			name:    "stale local item dropped",
+			current: []api.Activity{item("local_1", "ship", at(10*time.Minute))},
+			polled:  []api.Activity{item("p1", "other", at(time.Minute))},
+			want:    []string{"p1"},
+		},
+		{
+			name:    "old local item kept while newer than the poll",
+			current: []api.Activity{item("local_1", "ship", at(10*time.Minute))},
+			polled:  []api.Activity{item("p1", "other", at(20*time.Minute))},
+			want:    []string{"local_1", "p1"},
+		},
+		{
+			name: "repeat of a quest: recent copy matches, old one is stale",
+			current: []api.Activity{
+				item("local_2", "ship", at(time.Second)),
+				item("local_1", "ship", at(time.Hour)),
+			},
+			polled: []api.Activity{item("p1", "ship", at(0))},
+			want:   []string{"p1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mergeActivity(tt.current, tt.polled, now)
+			ids := make([]string, len(got))
+			for i, a := range got {
+				ids[i] = a.ID
+			}
+			if !slices.Equal(ids, tt.want) {
+				t.Errorf("merged %v, want %v", ids, tt.want)
+			}
+		})
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		s   string
+		max int
+	}{
+		{"ship", 10},
+		{"a quest title far too long for the slot", 24},
+		{"山田太郎さんのとても長いクエスト", 24},
+		{"Zoë Ångström-Öberg's crew", 10},
+		{"🔥🔥🔥🔥🔥🔥🔥🔥", 5},
+	}
+	for _, tt := range tests {
+		got := truncate(tt.s, tt.max)
+		if w := lipgloss.Width(got); w > tt.max {
+			t.Errorf("truncate(%q, %d) = %q, %d cells", tt.s, tt.max, got, w)
+		}
+		if lipgloss.Width(tt.s) <= tt.max && got != tt.s {
+			t.Errorf("truncate changed %q, which fits", tt.s)
+		}
+	}
+}
+
+func TestRenderHeaderCrewXP(t *testing.T) {
+	d := &DashboardModel{
+		user:   &api.User{Name: "ada", Level: 1},
+		config: &auth.Config{},
+		stats: &api.DashboardStats{Group: &api.GroupStats{
+			MemberCount:   5,
+			ActiveToday:   3,
+			LeaderName:    "ada",
+			IsUserLeading: true,
+			GroupTodayXP:  420,
+			GroupWeeklyXP: 2100,
+		}},
+	}
+	header := d.renderHeader()
+	for _, want := range []string{"420 XP", "3/5 active", "crew 2,100 XP"} {
+		if !strings.Contains(header, want) {
+			t.Errorf("header is missing %q:\n%s", want, header)
+		}
+	}
+
+	d.stats.Group = nil
+	if header := d.renderHeader(); strings.Contains(header, "crew 2,100") {
+		t.Errorf("header shows crew XP without a crew:\n%s", header)
+	}
+}
+
+func TestLoadQuests(t *testing.T) {
+	fake := apitest.NewFake()
+	fake.Return("quests:listToday", []api.Quest{{ID: "q1", Title: "ship", XP: 20, Status: "pending"}})
+	d := NewDashboardModel(&auth.Config{UserID: "u1", UserName: "ada"}, fake)
+
+	msg, ok := d.loadQuests()().(QuestsLoadedMsg)
+	if !ok || msg.Err != nil {
+		t.Fatalf("loadQuests = %+v", msg)
+	}
+	if len(msg.Quests) != 1 || msg.Quests[0].Title != "ship" {
+		t.Errorf("quests = %+v", msg.Quests)
+	}
+	if calls := fake.CallsTo("quests:listToday"); len(calls) != 1 || calls[0].Args["userId"] != "u1" {
+		t.Errorf("calls = %+v", calls)
+	}
+
+	fake.Fail("quests:listToday", "Unauthorized", nil)
+	if msg := d.loadQuests()().(QuestsLoadedMsg); msg.Err == nil {
+		t.Error("failed query loaded without an error")
+	}
+}
+
+// TestLocalDashboard checks a guest's dashboard goes through the local
+// store like any other backend, with the crew features switched off
+func TestLocalDashboard(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	cfg := &auth.Config{UserID: "u1", UserName: "ada", GroupID: "g1", Local: true}
+	d := NewDashboardModel(cfg, store.Backend{Location: time.UTC})
+	if d.remote() || d.connection() != nil {
+		t.Error("local dashboard acts as if it has a deployment")
+	}
+
+	added, ok := d.addQuestCmd("write the README")().(QuestAddedMsg)
+	if !ok || added.Err != nil {
+		t.Fatalf("addQuestCmd = %+v", added)
+	}
+	if msg := d.startQuest(added.Quest)().(QuestStartedMsg); msg.Err != nil {
+		t.Fatal(msg.Err)
+	}
+	done := d.completeQuest(added.Quest, added.Quest.XP)().(QuestCompletedMsg)
+	if done.Err != nil || done.XPEarned != added.Quest.XP {
+		t.Fatalf("completeQuest = %+v", done)
+	}
+
+	loaded := d.loadQuests()().(QuestsLoadedMsg)
+	if loaded.Err != nil || len(loaded.Quests) != 1 || loaded.Quests[0].Status != "completed" {
+		t.Errorf("loadQuests = %+v", loaded)
+	}
+	if msg := d.loadLeaderboard()().(LeaderboardLoadedMsg); msg.Err != nil {
+		t.Errorf("leaderboard in local mode: err = %v", msg.Err)
+	}
+}
+
+func TestSaveStateKeepsOtherChanges(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	cfg := &auth.Config{UserID: "u1", UserName: "ada", TotalXP: 100}
+	if err := auth.Save(cfg); err != nil {
+		t.Fatal(err)
+	}
+	d := NewDashboardModel(cfg, apitest.NewFake())
+
+	// Meanwhile, another shell renames the account and sets a timer
+	if err := auth.Update(func(c *auth.Config) {
+		c.UserName = "grace"
+		c.SetQuestTimer("q1", time.Unix(1_800_000_000, 0))
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	d.input.SetValue("half-typed quest")
+	d.user.TotalXP = 250
+	if err := d.SaveState(); err != nil {
+		t.Fatal(err)
+	}
+
+	saved, err := auth.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.DraftQuest != "half-typed quest" || saved.TotalXP != 250 {
+		t.Errorf("draft %q, XP %d not saved", saved.DraftQuest, saved.TotalXP)
+	}
+	if saved.UserName != "grace" || saved.QuestTimers["q1"] == 0 {
+		t.Errorf("other shell's changes lost: name %q, timers %v", saved.UserName, saved.QuestTimers)
+	}
+}
+
+func TestCompleteAllHoldsQuests(t *testing.T) {
+	fake := apitest.NewFake()
+	fake.Return("quests:complete", api.CompleteResult{XPEarned: 20})
+	d := NewDashboardModel(&auth.Config{UserID: "u1", UserName: "ada"}, fake)
+	d.quests = []api.Quest{
+		{ID: "q1", Title: "ship", XP: 20, Status: "in_progress"},
+		{ID: "q2", Title: "review", XP: 20, Status: "pending"},
+		{ID: "q3", Title: "done", XP: 20, Status: "completed"},
+	}
+	d.focus = panelQuests
+	press := func(key string) tea.Cmd {
+		msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+		if key == "enter" {
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		}
+		_, cmd := d.Update(msg)
+		return cmd
+	}
+
+	press("C")
+	run := press("y")
+	if run == nil {
+		t.Fatal("confirming started nothing")
+	}
+	if !d.pending["q1"] || !d.pending["q2"] || d.pending["q3"] {
+		t.Errorf("pending = %v, want the two open quests", d.pending)
+	}
+	if help := d.renderHelp(); !strings.Contains(help, "completing 2 quests") {
+		t.Errorf("help = %q, want a progress label", help)
+	}
+
+	// Nothing else completes a quest while the batch runs
+	d.selectedQuest = 0
+	if cmd := press("enter"); cmd != nil {
+		t.Error("enter fired a second completion")
+	}
+	if press("C"); d.confirmBulk {
+		t.Error("C asked again during a batch")
+	}
+
+	msg, ok := run().(BulkCompletedMsg)
+	if !ok || len(msg.Completed) != 2 {
+		t.Fatalf("batch = %+v", msg)
+	}
+	d.Update(msg)
+	if len(d.pending) != 0 || d.bulkTotal != 0 {
+		t.Errorf("after the batch: pending %v, total %d", d.pending, d.bulkTotal)
+	}
+	if calls := fake.CallsTo("quests:complete"); len(calls) != 2 {
+		t.Errorf("%d complete calls, want 2", len(calls))
+	}
+}
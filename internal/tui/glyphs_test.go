@@ -0,0 +1,61 @@
+package tui
+
+import (
+	"testing"
+	"unicode"
+
+	"grind/internal/auth"
+	"grind/internal/tui/components"
+)
+
+// TestHelpASCII renders every help line, prompt and hint in ASCII mode, so
+// a separator or mark hardcoded in the catalog or a view shows up here
+func TestHelpASCII(t *testing.T) {
+	components.SetASCII(true)
+	ApplyGlyphs()
+	defer func() {
+		components.SetASCII(false)
+		ApplyGlyphs()
+	}()
+
+	cfg := &auth.Config{UserID: "u1", UserName: "ada"}
+	d := NewDashboardModel(cfg, nil)
+	d.width = 60
+	lines := map[string]string{}
+	for name, focus := range map[string]focusPanel{"input help": panelInput, "quests help": panelQuests, "feed help": panelFeed} {
+		d.focus = focus
+		lines[name] = d.renderHelp()
+	}
+	d.helpExpanded = true
+	lines["cheat sheet"] = d.renderHelp()
+	d.helpExpanded = false
+	d.idle = true
+	lines["idle"] = d.renderHelp()
+	d.idle = false
+	d.confirmBulk = true
+	lines["bulk confirm"] = d.renderHelp()
+	d.confirmBulk = false
+	d.missingGroup = true
+	lines["missing group"] = d.renderHelp()
+	d.missingGroup = false
+	d.pickTemplate = true
+	lines["template picker"] = d.renderHelp()
+	d.pickTemplate = false
+	d.subtaskForID = "q1"
+	lines["sub-task input"] = d.renderInput()
+	lines["still syncing"] = helpf("dashboard.stillSyncing")
+
+	o := NewOnboardingModel(cfg, nil)
+	lines["group choice"] = o.viewGroupChoice()
+	lines["all set"] = o.viewComplete()
+	lines["settings"] = NewSettingsModel(cfg, nil).View()
+
+	for name, line := range lines {
+		for _, r := range line {
+			if r > unicode.MaxASCII {
+				t.Errorf("%s has %q in ASCII mode:\n%s", name, r, line)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package tui
+
+import (
+	"strings"
+	"time"
+
+	"grind/internal/i18n"
+)
+
+// greetingSlots maps each hour of the day to a greeting catalog key
+var greetingSlots = [24]string{
+	"greeting.midnight", "greeting.midnight", "greeting.midnight", "greeting.midnight", // 0-3
+	"greeting.early", "greeting.early", // 4-5
+	"greeting.morning", "greeting.morning", "greeting.morning", "greeting.morning", "greeting.morning", "greeting.morning", // 6-11
+	"greeting.afternoon", "greeting.afternoon", "greeting.afternoon", "greeting.afternoon", "greeting.afternoon", // 12-16
+	"greeting.evening", "greeting.evening", "greeting.evening", "greeting.evening", "greeting.evening", // 17-21
+	"greeting.late", "greeting.late", // 22-23
+}
+
+// Greeting returns a greeting for the time of day at t, in t's location.
+// Each slot's catalog message holds a few "|"-separated variants; variant
+// picks one (wrapping), so a caller can vary it per session while the
+// result stays a pure function of its arguments.
+func Greeting(t time.Time, variant int) string {
+	variants := strings.Split(i18n.T(greetingSlots[t.Hour()]), "|")
+	if variant < 0 {
+		variant = -variant
+	}
+	return variants[variant%len(variants)]
+}
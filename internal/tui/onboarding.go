@@ -2,7 +2,11 @@ package tui
 
 import (
 	"context"
+	crand "crypto/rand"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"io"
 	"math/rand"
 	"strings"
 	"time"
@@ -13,6 +17,9 @@ import (
 
 	"grind/internal/api"
 	"grind/internal/auth"
+	"grind/internal/i18n"
+	"grind/internal/store"
+	"grind/internal/tui/components"
 )
 
 // OnboardingStep represents steps in the onboarding flow
@@ -36,12 +43,30 @@ type OnboardingModel struct {
 	groupInput   textinput.Model
 	codeInput    textinput.Model
 	focusedInput int // -1 = no input focused, 0+ = input index
-	groupChoice  int // 0 = create, 1 = join
+	groupChoice  int // groupCreate, groupJoin or groupKeep
 	inviteCode   string
 	loading      bool
 	err          error
+	failedAt     time.Time // When account creation last failed, for the retry debounce
+
+	// edit is set when re-running setup for an existing account: the steps
+	// start pre-filled, a new name updates the account instead of creating
+	// one, and esc goes back to the dashboard
+	edit bool
 }
 
+// Group step choices. groupKeep is only offered when editing an account
+// that's already in a crew.
+const (
+	groupCreate = iota
+	groupJoin
+	groupKeep
+)
+
+// retryDebounce ignores Enter for a moment after a failed signup so a
+// held or repeated key doesn't fire a burst of retries
+const retryDebounce = time.Second
+
 // UserCreatedMsg is sent when user is created in Convex
 type UserCreatedMsg struct {
 	UserID string
@@ -55,15 +80,22 @@ type GroupCreatedMsg struct {
 	Err        error
 }
 
+// GroupJoinedMsg is sent when the user has joined a group in Convex
+type GroupJoinedMsg struct {
+	GroupID   string
+	GroupName string
+	Err       error
+}
+
 // NewOnboardingModel creates a new onboarding model
-func NewOnboardingModel(cfg *auth.Config, client *api.Client) *OnboardingModel {
+func NewOnboardingModel(cfg *auth.Config, backend api.ConvexAPI) *OnboardingModel {
 	nameInput := textinput.New()
-	nameInput.Placeholder = "your name"
-	nameInput.CharLimit = 32
+	nameInput.Placeholder = i18n.T("onboarding.namePlaceholder")
+	nameInput.CharLimit = api.MaxUserNameLength
 	nameInput.Width = 30
 
 	groupInput := textinput.New()
-	groupInput.Placeholder = "group name"
+	groupInput.Placeholder = i18n.T("onboarding.groupPlaceholder")
 	groupInput.CharLimit = 32
 	groupInput.Width = 30
 
@@ -72,19 +104,63 @@ func NewOnboardingModel(cfg *auth.Config, client *api.Client) *OnboardingModel {
 	codeInput.CharLimit = 10
 	codeInput.Width = 15
 
-	return &OnboardingModel{
+	m := &OnboardingModel{
 		config:       cfg,
-		client:       client,
+		client:       api.ClientFor(backend),
 		step:         StepWelcome,
 		nameInput:    nameInput,
 		groupInput:   groupInput,
 		codeInput:    codeInput,
 		focusedInput: -1,
 	}
+
+	// A previous signup was interrupted before its account ID was saved:
+	// pick up at the name step so Init can finish it with the same key
+	if cfg.SignupKey != "" && cfg.UserName != "" {
+		m.step = StepName
+		m.nameInput.SetValue(cfg.UserName)
+		m.nameInput.Focus()
+		m.focusedInput = 0
+		m.loading = true
+	}
+
+	return m
 }
 
-// Init initializes the model
+// NewSetupModel re-runs onboarding for an existing account, pre-filled with
+// its current name and crew
+func NewSetupModel(cfg *auth.Config, backend api.ConvexAPI) *OnboardingModel {
+	m := NewOnboardingModel(cfg, backend)
+	m.edit = true
+	m.step = StepName
+	m.nameInput.SetValue(cfg.UserName)
+	m.nameInput.Focus()
+	m.focusedInput = 0
+	if cfg.HasGroup() {
+		m.groupChoice = groupKeep
+	}
+	return m
+}
+
+// groupChoices returns how many options the group step offers
+func (m *OnboardingModel) groupChoices() int {
+	if m.edit && m.config.HasGroup() {
+		return 3
+	}
+	return 2
+}
+
+// UserUpdatedMsg is sent when a name change has been saved
+type UserUpdatedMsg struct {
+	Name string
+	Err  error
+}
+
+// Init initializes the model, resuming an interrupted signup if there is one
 func (m *OnboardingModel) Init() tea.Cmd {
+	if m.step == StepName && m.loading {
+		return m.createUserCmd(m.config.UserName, m.config.SignupKey)
+	}
 	return nil
 }
 
@@ -100,13 +176,17 @@ func (m *OnboardingModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m.handleEnter()
 		case "up", "k":
 			if m.step == StepGroupChoice {
-				m.groupChoice = 0
+				m.groupChoice = moveCursor(m.groupChoice, -1, m.groupChoices(), m.config.WrapNavigation)
 			}
 		case "down", "j":
 			if m.step == StepGroupChoice {
-				m.groupChoice = 1
+				m.groupChoice = moveCursor(m.groupChoice, 1, m.groupChoices(), m.config.WrapNavigation)
 			}
 		case "esc":
+			if m.edit {
+				// Nothing is half-saved: each step saves as it completes
+				return m, func() tea.Msg { return SwitchScreenMsg{Screen: ScreenDashboard} }
+			}
 			if m.focusedInput >= 0 {
 				m.focusedInput = -1
 			}
@@ -116,14 +196,32 @@ func (m *OnboardingModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.loading = false
 		if msg.Err != nil {
 			m.err = msg.Err
+			m.failedAt = time.Now()
 			return m, nil
 		}
 		m.config.UserID = msg.UserID
+		// Save right away so quitting during the group step keeps the account
+		_ = auth.Save(m.config) // Best effort - the signup key still reconciles on retry
 		m.nameInput.Blur()
 		m.focusedInput = -1
 		m.step = StepGroupChoice
+		if m.config.Local {
+			// There are no crews to join without a backend
+			m.step = StepComplete
+		}
 		return m, nil
 
+	case UserUpdatedMsg:
+		m.loading = false
+		if msg.Err != nil {
+			m.err = msg.Err
+			m.failedAt = time.Now()
+			return m, nil
+		}
+		m.config.UserName = msg.Name
+		_ = auth.Save(m.config) // Best effort - StepComplete saves again
+		return m.afterName()
+
 	case GroupCreatedMsg:
 		m.loading = false
 		if msg.Err != nil {
@@ -136,6 +234,20 @@ func (m *OnboardingModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.focusedInput = -1
 		m.step = StepComplete
 		return m, nil
+
+	case GroupJoinedMsg:
+		m.loading = false
+		if msg.Err != nil {
+			// Stay on the code step so another code can be tried
+			m.err = msg.Err
+			return m, nil
+		}
+		m.config.GroupID = msg.GroupID
+		m.config.GroupName = msg.GroupName
+		m.codeInput.Blur()
+		m.focusedInput = -1
+		m.step = StepComplete
+		return m, nil
 	}
 
 	// Update text inputs
@@ -161,19 +273,44 @@ func (m *OnboardingModel) handleEnter() (tea.Model, tea.Cmd) {
 		return m, textinput.Blink
 
 	case StepName:
-		name := strings.TrimSpace(m.nameInput.Value())
-		if name == "" {
+		if strings.TrimSpace(m.nameInput.Value()) == "" {
+			return m, nil
+		}
+		if m.err != nil && time.Since(m.failedAt) < retryDebounce {
 			return m, nil
 		}
+		name, err := api.ValidateUserName(m.nameInput.Value())
+		if err != nil {
+			m.err = err
+			return m, nil
+		}
+		if m.edit {
+			if name == m.config.UserName {
+				return m.afterName()
+			}
+			m.loading = true
+			m.err = nil
+			return m, updateUserNameCmd(m.config, m.client, name)
+		}
+		// Keep the key across retries and restarts so the backend hands
+		// back the same account if an earlier attempt got through
+		if m.config.SignupKey == "" {
+			m.config.SignupKey = generateSignupKey()
+		}
 		m.config.UserName = name
+		_ = auth.Save(m.config) // Best effort - lets a relaunch resume this signup
 		m.loading = true
 		m.err = nil
 
 		// Call API to create user
-		return m, m.createUserCmd(name)
+		return m, m.createUserCmd(name, m.config.SignupKey)
 
 	case StepGroupChoice:
-		if m.groupChoice == 0 {
+		if m.groupChoice == groupKeep {
+			m.step = StepComplete
+			return m, nil
+		}
+		if m.groupChoice == groupCreate {
 			m.step = StepCreateGroup
 			m.groupInput.Focus()
 			m.focusedInput = 0
@@ -198,20 +335,21 @@ func (m *OnboardingModel) handleEnter() (tea.Model, tea.Cmd) {
 		return m, m.createGroupCmd(groupName)
 
 	case StepJoinGroup:
-		code := strings.TrimSpace(m.codeInput.Value())
-		if code == "" {
+		if strings.TrimSpace(m.codeInput.Value()) == "" {
 			return m, nil
 		}
-		// TODO: Validate invite code with backend
-		m.config.GroupID = "joined-group" // Placeholder
-		m.config.GroupName = "Joined Group"
-		m.codeInput.Blur()
-		m.focusedInput = -1
-		m.step = StepComplete
-		return m, nil
+		code, err := api.ValidateInviteCode(m.codeInput.Value())
+		if err != nil {
+			m.err = err
+			return m, nil
+		}
+		m.loading = true
+		m.err = nil
+		return m, m.joinGroupCmd(code)
 
 	case StepComplete:
 		// Save config and transition
+		m.config.SignupKey = ""
 		if err := auth.Save(m.config); err != nil {
 			m.err = err
 			return m, nil
@@ -225,8 +363,16 @@ func (m *OnboardingModel) handleEnter() (tea.Model, tea.Cmd) {
 }
 
 // createUserCmd creates a user in Convex
-func (m *OnboardingModel) createUserCmd(name string) tea.Cmd {
+func (m *OnboardingModel) createUserCmd(name, signupKey string) tea.Cmd {
 	return func() tea.Msg {
+		if m.config.Local {
+			user := api.User{ID: generateUserID(), Name: name, Level: 1, CreatedAt: time.Now().UnixMilli()}
+			err := store.Update(func(data *store.Data) error {
+				data.User = user
+				return nil
+			})
+			return UserCreatedMsg{UserID: user.ID, Err: err}
+		}
 		if m.client == nil {
 			return UserCreatedMsg{Err: fmt.Errorf("no API client available")}
 		}
@@ -235,7 +381,8 @@ func (m *OnboardingModel) createUserCmd(name string) tea.Cmd {
 		defer cancel()
 
 		result, err := m.client.Mutation(ctx, "users:create", map[string]any{
-			"name": name,
+			"name":      name,
+			"signupKey": signupKey,
 		})
 		if err != nil {
 			return UserCreatedMsg{Err: err}
@@ -251,6 +398,35 @@ func (m *OnboardingModel) createUserCmd(name string) tea.Cmd {
 	}
 }
 
+// afterName moves on from the name step of an edit: to the crew choice,
+// or straight to the end in local mode where there are no crews
+func (m *OnboardingModel) afterName() (tea.Model, tea.Cmd) {
+	m.nameInput.Blur()
+	m.focusedInput = -1
+	m.err = nil
+	m.step = StepGroupChoice
+	if m.config.Local {
+		m.step = StepComplete
+	}
+	return m, nil
+}
+
+// updateUserNameCmd renames the existing account via users:update, which
+// the local store answers for a guest. Onboarding's edit mode and settings
+// share it.
+func updateUserNameCmd(cfg *auth.Config, client *api.Client, name string) tea.Cmd {
+	return func() tea.Msg {
+		if client == nil {
+			return UserUpdatedMsg{Err: fmt.Errorf("no API client available")}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		return UserUpdatedMsg{Name: name, Err: client.UpdateUserName(ctx, cfg.UserID, name)}
+	}
+}
+
 // createGroupCmd creates a group in Convex
 func (m *OnboardingModel) createGroupCmd(groupName string) tea.Cmd {
 	return func() tea.Msg {
@@ -289,6 +465,21 @@ func (m *OnboardingModel) createGroupCmd(groupName string) tea.Cmd {
 	}
 }
 
+// joinGroupCmd joins a group in Convex by invite code
+func (m *OnboardingModel) joinGroupCmd(code string) tea.Cmd {
+	return func() tea.Msg {
+		if m.client == nil {
+			return GroupJoinedMsg{Err: fmt.Errorf("no API client available")}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		groupID, groupName, err := m.client.JoinGroup(ctx, m.config.UserID, code)
+		return GroupJoinedMsg{GroupID: groupID, GroupName: groupName, Err: err}
+	}
+}
+
 // View renders the onboarding screen
 func (m *OnboardingModel) View() string {
 	switch m.step {
@@ -309,9 +500,9 @@ func (m *OnboardingModel) View() string {
 }
 
 func (m *OnboardingModel) viewWelcome() string {
-	logo := LogoStyle.Render("⚡ GRIND")
-	subtitle := SubtitleStyle.Render("competitive task tracking")
-	tagline := MutedStyle.Render("for hackers")
+	logo := LogoStyle.Render(components.Glyphs.LevelUp + " GRIND")
+	subtitle := SubtitleStyle.Render(i18n.T("onboarding.subtitle"))
+	tagline := MutedStyle.Render(i18n.T("onboarding.tagline"))
 
 	content := lipgloss.JoinVertical(
 		lipgloss.Center,
@@ -322,20 +513,26 @@ func (m *OnboardingModel) viewWelcome() string {
 	)
 
 	box := BoxStyle.Width(44).Render(content)
-	help := HelpStyle.Render("\npress enter to start")
+	help := HelpStyle.Render("\n" + i18n.T("onboarding.pressStart"))
 
 	return lipgloss.JoinVertical(lipgloss.Center, box, help)
 }
 
 func (m *OnboardingModel) viewName() string {
-	title := TitleStyle.Render("first time? let's set up.")
-	prompt := "\nyour name: " + m.nameInput.View()
+	title := TitleStyle.Render(i18n.T("onboarding.setUp"))
+	if m.edit {
+		title = TitleStyle.Render(i18n.T("onboarding.editTitle"))
+	}
+	prompt := "\n" + i18n.T("onboarding.yourName") + m.nameInput.View()
 
 	var statusLine string
-	if m.loading {
-		statusLine = "\n" + MutedStyle.Render("creating account...")
+	if m.loading && m.edit {
+		statusLine = "\n" + MutedStyle.Render(i18n.T("onboarding.savingName"))
+	} else if m.loading {
+		statusLine = "\n" + MutedStyle.Render(i18n.T("onboarding.creatingAccount"))
 	} else if m.err != nil {
-		statusLine = "\n" + ErrorStyle.Render(fmt.Sprintf("error: %v", m.err))
+		statusLine = "\n" + ErrorStyle.Render(i18n.Tf("onboarding.error", m.err)) +
+			"\n" + MutedStyle.Render(i18n.T("onboarding.retry"))
 	}
 
 	content := lipgloss.JoinVertical(
@@ -349,24 +546,22 @@ func (m *OnboardingModel) viewName() string {
 }
 
 func (m *OnboardingModel) viewGroupChoice() string {
-	title := TitleStyle.Render(fmt.Sprintf("hey %s!", m.config.UserName))
-	question := "\njoin existing group or create new?"
-
-	create := "  create new group"
-	join := "  join with invite code"
+	title := TitleStyle.Render(i18n.Tf("onboarding.hey", m.config.UserName))
+	question := "\n" + i18n.T("onboarding.groupQuestion")
 
-	if m.groupChoice == 0 {
-		create = QuestSelectedStyle.Render("→ create new group")
-	} else {
-		join = QuestSelectedStyle.Render("→ join with invite code")
+	labels := []string{i18n.T("onboarding.createGroup"), i18n.T("onboarding.joinGroup")}
+	if m.groupChoices() > groupKeep {
+		labels = append(labels, i18n.Tf("onboarding.keepGroup", m.config.GroupName))
 	}
-
-	options := lipgloss.JoinVertical(
-		lipgloss.Left,
-		"",
-		create,
-		join,
-	)
+	lines := []string{""}
+	for i, label := range labels {
+		if i == m.groupChoice {
+			lines = append(lines, QuestSelectedStyle.Render(components.Glyphs.Arrow+" "+label))
+		} else {
+			lines = append(lines, strings.Repeat(" ", lipgloss.Width(components.Glyphs.Arrow)+1)+label)
+		}
+	}
+	options := lipgloss.JoinVertical(lipgloss.Left, lines...)
 
 	content := lipgloss.JoinVertical(
 		lipgloss.Left,
@@ -375,7 +570,7 @@ func (m *OnboardingModel) viewGroupChoice() string {
 		options,
 	)
 
-	help := HelpStyle.Render("\n↑/↓ to select, enter to confirm")
+	help := HelpStyle.Render("\n" + i18n.Tf("onboarding.selectHelp", components.Glyphs.UpDown))
 
 	return lipgloss.JoinVertical(
 		lipgloss.Center,
@@ -385,14 +580,14 @@ func (m *OnboardingModel) viewGroupChoice() string {
 }
 
 func (m *OnboardingModel) viewCreateGroup() string {
-	title := TitleStyle.Render("create your group")
-	prompt := "\ngroup name: " + m.groupInput.View()
+	title := TitleStyle.Render(i18n.T("onboarding.createTitle"))
+	prompt := "\n" + i18n.T("onboarding.groupName") + m.groupInput.View()
 
 	var statusLine string
 	if m.loading {
-		statusLine = "\n" + MutedStyle.Render("creating group...")
+		statusLine = "\n" + MutedStyle.Render(i18n.T("onboarding.creatingGroup"))
 	} else if m.err != nil {
-		statusLine = "\n" + ErrorStyle.Render(fmt.Sprintf("error: %v", m.err))
+		statusLine = "\n" + ErrorStyle.Render(i18n.Tf("onboarding.error", m.err))
 	}
 
 	content := lipgloss.JoinVertical(
@@ -406,27 +601,47 @@ func (m *OnboardingModel) viewCreateGroup() string {
 }
 
 func (m *OnboardingModel) viewJoinGroup() string {
-	title := TitleStyle.Render("join a group")
-	prompt := "\ninvite code: " + m.codeInput.View()
+	title := TitleStyle.Render(i18n.T("onboarding.joinTitle"))
+	prompt := "\n" + i18n.T("onboarding.inviteCode") + m.codeInput.View()
+
+	var statusLine string
+	switch {
+	case m.loading:
+		statusLine = "\n" + MutedStyle.Render(i18n.T("onboarding.joiningGroup"))
+	case errors.Is(m.err, api.ErrGroupFull):
+		statusLine = "\n" + ErrorStyle.Render(i18n.T("onboarding.groupFull"))
+	case errors.Is(m.err, api.ErrInvalidInviteCode):
+		statusLine = "\n" + ErrorStyle.Render(i18n.T("onboarding.badCode"))
+	case m.err != nil:
+		statusLine = "\n" + ErrorStyle.Render(i18n.Tf("onboarding.error", m.err))
+	}
 
 	content := lipgloss.JoinVertical(
 		lipgloss.Left,
 		title,
 		prompt,
+		statusLine,
 	)
 
 	return BoxStyle.Width(44).Render(content)
 }
 
 func (m *OnboardingModel) viewComplete() string {
-	title := SuccessStyle.Render("✓ you're all set!")
+	title := SuccessStyle.Render(i18n.Tf("onboarding.allSet", components.Glyphs.Check))
+	if m.edit {
+		title = SuccessStyle.Render(i18n.Tf("onboarding.profileUpdated", components.Glyphs.Check))
+	}
 
 	var groupInfo string
-	if m.inviteCode != "" {
-		groupInfo = fmt.Sprintf("\ninvite your friends:\n\n%s",
-			BoxStyleMuted.Render("grind join "+m.inviteCode))
+	if m.config.Local {
+		groupInfo = "\n" + i18n.T("onboarding.localOnly")
+	} else if m.edit && m.groupChoice == groupKeep {
+		groupInfo = "\n" + i18n.Tf("onboarding.stillIn", m.config.GroupName)
+	} else if m.inviteCode != "" {
+		groupInfo = fmt.Sprintf("\n%s\n\n%s", i18n.T("onboarding.inviteFriends"),
+			BoxStyleMuted.Render(components.JoinCommand(m.inviteCode)))
 	} else {
-		groupInfo = fmt.Sprintf("\njoined: %s", m.config.GroupName)
+		groupInfo = "\n" + i18n.Tf("onboarding.joined", m.config.GroupName)
 	}
 
 	content := lipgloss.JoinVertical(
@@ -435,7 +650,7 @@ func (m *OnboardingModel) viewComplete() string {
 		groupInfo,
 	)
 
-	help := HelpStyle.Render("\npress enter to start grinding...")
+	help := HelpStyle.Render("\n" + i18n.T("onboarding.startGrinding"))
 
 	return lipgloss.JoinVertical(
 		lipgloss.Center,
@@ -446,26 +661,56 @@ func (m *OnboardingModel) viewComplete() string {
 
 // Helper functions
 func generateUserID() string {
-	// Simple local ID for now - will be replaced by Convex ID
-	return fmt.Sprintf("user_%d", randomID())
+	// Guest accounts in local mode never get a Convex ID
+	return fmt.Sprintf("user_%s", randomID())
+}
+
+func generateSignupKey() string {
+	return fmt.Sprintf("signup_%s", randomID())
 }
 
 func generateGroupID() string {
-	return fmt.Sprintf("group_%d", randomID())
+	return fmt.Sprintf("group_%s", randomID())
 }
 
 func generateInviteCode() string {
-	// Simple invite code format: XXX-XXX
-	chars := "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
-	code := make([]byte, 6)
-	for i := range code {
-		code[i] = chars[randomID()%int64(len(chars))]
+	return newInviteCode(randSource)
+}
+
+// newInviteCode builds an XXX-XXX code from r. Bytes that would bias the
+// modulo are rejected, so every character is equally likely.
+func newInviteCode(r io.Reader) string {
+	limit := 256 - 256%len(api.InviteCodeChars)
+	code := make([]byte, 0, 6)
+	buf := make([]byte, 1)
+	for len(code) < 6 {
+		if _, err := io.ReadFull(r, buf); err != nil {
+			buf[0] = byte(rng.Intn(limit))
+		}
+		if int(buf[0]) >= limit {
+			continue
+		}
+		code = append(code, api.InviteCodeChars[int(buf[0])%len(api.InviteCodeChars)])
 	}
 	return string(code[:3]) + "-" + string(code[3:])
 }
 
+// randSource supplies the randomness for local IDs and invite codes. Tests
+// can swap in a fixed reader to get deterministic output.
+var randSource io.Reader = crand.Reader
+
+// rng is for cosmetic choices like greeting variants, not identifiers
 var rng = rand.New(rand.NewSource(time.Now().UnixNano()))
 
-func randomID() int64 {
-	return time.Now().UnixNano() + rng.Int63n(10000)
+// randomID returns 16 hex characters read from randSource
+func randomID() string {
+	b := make([]byte, 8)
+	if _, err := io.ReadFull(randSource, b); err != nil {
+		// Should never happen with crypto/rand; fall back rather than
+		// hand out an empty ID
+		for i := range b {
+			b[i] = byte(rng.Intn(256))
+		}
+	}
+	return hex.EncodeToString(b)
 }
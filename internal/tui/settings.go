@@ -0,0 +1,341 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"grind/internal/api"
+	"grind/internal/auth"
+	"grind/internal/tui/components"
+)
+
+// Settings rows, in display order
+const (
+	settingName = iota
+	settingView
+	settingGlyphs
+	settingLeaderboard
+	settingPollInterval
+	settingWrap
+	settingColorSafe
+	settingCount
+)
+
+// Values the view and icons settings cycle through; the first is the default
+var (
+	layouts    = []string{"cyber", "classic", "compact"}
+	glyphModes = []string{"auto", "unicode", "ascii"}
+)
+
+// moveCursor steps a list cursor by dir (+1 down, -1 up) over n items.
+// At either end it stops, or wraps to the other end when wrap is set.
+func moveCursor(cur, dir, n int, wrap bool) int {
+	if n <= 0 {
+		return cur
+	}
+	next := cur + dir
+	if wrap {
+		return (next%n + n) % n
+	}
+	return min(max(next, 0), n-1)
+}
+
+// cycle returns the value dir steps away from current in values, wrapping.
+// An unset current counts as the first value.
+func cycle(values []string, current string, dir int) string {
+	idx := 0
+	for i, v := range values {
+		if v == current {
+			idx = i
+		}
+	}
+	return values[(idx+dir+len(values))%len(values)]
+}
+
+// SettingsModel lets users change preferences from inside the TUI.
+// Every change is validated and saved immediately.
+type SettingsModel struct {
+	config    *auth.Config
+	client    *api.Client // The local store in local mode
+	selected  int
+	editing   bool // The selected row's input is focused
+	renaming  bool // A name change is waiting on the backend
+	pollInput textinput.Model
+	nameInput textinput.Model
+	status    string
+	err       error
+}
+
+// SettingsClosedMsg is sent when the user leaves the settings screen
+type SettingsClosedMsg struct{}
+
+// NewSettingsModel creates a new settings model. backend renames the
+// account; in local mode it's the local store.
+func NewSettingsModel(cfg *auth.Config, backend api.ConvexAPI) *SettingsModel {
+	pollInput := textinput.New()
+	pollInput.Placeholder = auth.DefaultPollInterval.String()
+	pollInput.CharLimit = 8
+	pollInput.Width = 10
+
+	nameInput := textinput.New()
+	nameInput.Placeholder = "your name"
+	nameInput.CharLimit = api.MaxUserNameLength
+	nameInput.Width = 20
+
+	return &SettingsModel{
+		config:    cfg,
+		client:    api.ClientFor(backend),
+		pollInput: pollInput,
+		nameInput: nameInput,
+	}
+}
+
+// Init initializes the model
+func (m *SettingsModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (m *SettingsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(UserUpdatedMsg); ok {
+		m.renaming = false
+		if msg.Err != nil {
+			m.err = fmt.Errorf("rename failed: %w", msg.Err)
+			return m, nil
+		}
+		m.config.UserName = msg.Name
+		m.save()
+		return m, nil
+	}
+
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		var cmd tea.Cmd
+		if m.editing && m.selected == settingName {
+			m.nameInput, cmd = m.nameInput.Update(msg)
+		} else if m.editing {
+			m.pollInput, cmd = m.pollInput.Update(msg)
+		}
+		return m, cmd
+	}
+
+	// Keys wait for a rename to finish, so its result lands here
+	if m.renaming {
+		return m, nil
+	}
+	if m.editing && m.selected == settingName {
+		return m.updateNameInput(keyMsg)
+	}
+	if m.editing {
+		return m.updatePollInput(keyMsg)
+	}
+
+	switch keyMsg.String() {
+	case "esc":
+		return m, func() tea.Msg { return SettingsClosedMsg{} }
+	case "up", "k":
+		m.selected = moveCursor(m.selected, -1, settingCount, m.config.WrapNavigation)
+	case "down", "j":
+		m.selected = moveCursor(m.selected, 1, settingCount, m.config.WrapNavigation)
+	case "enter", " ", "right", "l":
+		return m.change(1)
+	case "left", "h":
+		return m.change(-1)
+	}
+	return m, nil
+}
+
+// change steps the selected setting forward or back and saves it
+func (m *SettingsModel) change(dir int) (tea.Model, tea.Cmd) {
+	m.status = ""
+	m.err = nil
+
+	switch m.selected {
+	case settingName:
+		m.editing = true
+		m.nameInput.SetValue(m.config.UserName)
+		m.nameInput.CursorEnd()
+		m.nameInput.Focus()
+		return m, textinput.Blink
+
+	case settingView:
+		m.config.Layout = cycle(layouts, m.config.Layout, dir)
+
+	case settingGlyphs:
+		m.config.Glyphs = cycle(glyphModes, m.config.Glyphs, dir)
+		components.UseGlyphs(m.config.Glyphs)
+		ApplyGlyphs()
+
+	case settingLeaderboard:
+		m.config.LeaderboardAllTime = !m.config.LeaderboardAllTime
+
+	case settingWrap:
+		m.config.WrapNavigation = !m.config.WrapNavigation
+
+	case settingColorSafe:
+		m.config.ColorSafe = !m.config.ColorSafe
+		components.SetColorSafe(m.config.ColorSafe)
+
+	case settingPollInterval:
+		m.editing = true
+		m.pollInput.SetValue(m.config.GetPollInterval().String())
+		m.pollInput.CursorEnd()
+		m.pollInput.Focus()
+		return m, textinput.Blink
+	}
+
+	m.save()
+	return m, nil
+}
+
+// updatePollInput handles keys while the poll interval is being edited
+func (m *SettingsModel) updatePollInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.editing = false
+		m.pollInput.Blur()
+		m.err = nil
+		return m, nil
+	case "enter":
+		value := strings.TrimSpace(m.pollInput.Value())
+		if err := auth.ValidatePollInterval(value); err != nil {
+			m.err = err
+			return m, nil
+		}
+		m.config.PollInterval = value
+		m.editing = false
+		m.pollInput.Blur()
+		m.save()
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.pollInput, cmd = m.pollInput.Update(msg)
+	return m, cmd
+}
+
+// updateNameInput handles keys while the name is being edited. The new
+// name is saved to the account first and to the config once that works.
+func (m *SettingsModel) updateNameInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.editing = false
+		m.nameInput.Blur()
+		m.err = nil
+		return m, nil
+	case "enter":
+		name, err := api.ValidateUserName(m.nameInput.Value())
+		if err != nil {
+			m.err = err
+			return m, nil
+		}
+		m.editing = false
+		m.nameInput.Blur()
+		m.err = nil
+		if name == m.config.UserName {
+			return m, nil
+		}
+		m.renaming = true
+		return m, updateUserNameCmd(m.config, m.client, name)
+	}
+
+	var cmd tea.Cmd
+	m.nameInput, cmd = m.nameInput.Update(msg)
+	return m, cmd
+}
+
+// save persists the config and reports the result on the status line
+func (m *SettingsModel) save() {
+	if err := auth.Save(m.config); err != nil {
+		m.err = fmt.Errorf("save failed: %w", err)
+		return
+	}
+	m.status = "saved"
+}
+
+// View renders the settings screen
+func (m *SettingsModel) View() string {
+	title := TitleStyle.Render("settings")
+
+	viewValue := m.config.Layout
+	if viewValue == "" {
+		viewValue = "cyber"
+	}
+	glyphValue := m.config.Glyphs
+	if glyphValue == "" {
+		glyphValue = "auto"
+	}
+	boardValue := "weekly"
+	if m.config.LeaderboardAllTime {
+		boardValue = "all time"
+	}
+	wrapValue := "stop at ends"
+	if m.config.WrapNavigation {
+		wrapValue = "wrap around"
+	}
+	colorValue := "color only"
+	if m.config.ColorSafe {
+		colorValue = "color + labels"
+	}
+	pollValue := m.config.GetPollInterval().String()
+	nameValue := m.config.UserName
+	if m.editing && m.selected == settingName {
+		nameValue = m.nameInput.View()
+	} else if m.editing {
+		pollValue = m.pollInput.View()
+	} else if m.renaming {
+		nameValue = MutedStyle.Render("saving...")
+	}
+
+	rows := []string{
+		m.renderRow(settingName, "name", nameValue),
+		m.renderRow(settingView, "view", viewValue),
+		m.renderRow(settingGlyphs, "icons", glyphValue),
+		m.renderRow(settingLeaderboard, "leaderboard", boardValue),
+		m.renderRow(settingPollInterval, "refresh", pollValue),
+		m.renderRow(settingWrap, "lists", wrapValue),
+		m.renderRow(settingColorSafe, "status", colorValue),
+	}
+
+	var statusLine string
+	if m.err != nil {
+		statusLine = ErrorStyle.Render(fmt.Sprintf("error: %v", m.err))
+	} else if m.status != "" {
+		statusLine = SuccessStyle.Render(components.Glyphs.Check + " " + m.status)
+	}
+
+	content := lipgloss.JoinVertical(
+		lipgloss.Left,
+		title,
+		"",
+		strings.Join(rows, "\n"),
+		"",
+		statusLine,
+	)
+
+	help := "\n" + components.Glyphs.UpDown + " to select, enter to change, esc to go back"
+	if m.editing && m.selected == settingName {
+		help = "\nenter to save, esc to cancel"
+	} else if m.editing {
+		help = fmt.Sprintf("\nenter to save (min %s), esc to cancel", auth.MinPollInterval)
+	}
+
+	return lipgloss.JoinVertical(
+		lipgloss.Center,
+		BoxStyle.Width(44).Render(content),
+		HelpStyle.Render(help),
+	)
+}
+
+// renderRow renders one setting, highlighting the selected row
+func (m *SettingsModel) renderRow(row int, label, value string) string {
+	line := fmt.Sprintf("%-12s %s", label, value)
+	if row == m.selected {
+		return QuestSelectedStyle.Render(components.Glyphs.Arrow + " " + line)
+	}
+	return strings.Repeat(" ", lipgloss.Width(components.Glyphs.Arrow)+1) + line
+}
@@ -1,6 +1,10 @@
 package tui
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"github.com/charmbracelet/lipgloss"
+
+	"grind/internal/tui/components"
+)
 
 // Cyber-HUD Color Palette
 var (
@@ -205,6 +209,20 @@ var (
 				Foreground(ColorSlate)
 )
 
+// ApplyGlyphs redraws the framed styles with the active glyph set's
+// borders. The styles are built before the glyph setting is read, so call
+// it after components.SetASCII or UseGlyphs.
+func ApplyGlyphs() {
+	rounded := components.Glyphs.Rounded.Border()
+	BoxStyle = BoxStyle.Border(rounded)
+	BoxStyleMuted = BoxStyleMuted.Border(rounded)
+	InsightBoxStyle = InsightBoxStyle.Border(rounded)
+
+	square := components.Glyphs.Square.Border()
+	InputStyle = InputStyle.BorderStyle(square)
+	InputFocusedStyle = InputFocusedStyle.BorderStyle(square)
+}
+
 // ProgressBar renders a progress bar
 func ProgressBar(current, max, width int) string {
 	if max == 0 {
@@ -218,10 +236,10 @@ func ProgressBar(current, max, width int) string {
 
 	bar := ""
 	for i := 0; i < filled; i++ {
-		bar += ProgressFullStyle.Render("█")
+		bar += ProgressFullStyle.Render(components.Glyphs.BarFull)
 	}
 	for i := 0; i < empty; i++ {
-		bar += ProgressEmptyStyle.Render("░")
+		bar += ProgressEmptyStyle.Render(components.Glyphs.BarLight)
 	}
 	return bar
 }
@@ -235,7 +253,8 @@ func CenteredBox(content string, width int) string {
 // TitledPanel creates a panel with title in the top border
 // Example: ╭─ ⚔️ ACTIVE QUESTS ─────────────╮
 func TitledPanel(title, content string, width int, borderColor lipgloss.Color) string {
-	titlePart := "╭─ " + title + " "
+	b := components.Glyphs.Rounded
+	titlePart := b.TopLeft + b.Horizontal + " " + title + " "
 	titleLen := lipgloss.Width(titlePart)
 	remainingWidth := width - titleLen - 1
 	if remainingWidth < 0 {
@@ -244,23 +263,24 @@ func TitledPanel(title, content string, width int, borderColor lipgloss.Color) s
 
 	// Build top border with title
 	topBorder := PanelTitleStyle.Foreground(borderColor).Render(titlePart)
-	topBorder += lipgloss.NewStyle().Foreground(borderColor).Render(repeat("─", remainingWidth) + "╮")
+	topBorder += lipgloss.NewStyle().Foreground(borderColor).Render(repeat(b.Horizontal, remainingWidth) + b.TopRight)
 
 	// Build sides and content
 	lines := splitLines(content)
 	var body string
 	for _, line := range lines {
+		line = components.FitLine(line, width-4)
 		lineWidth := lipgloss.Width(line)
 		padding := width - lineWidth - 4 // 4 = "│ " + " │"
 		if padding < 0 {
 			padding = 0
 		}
 		borderStyle := lipgloss.NewStyle().Foreground(borderColor)
-		body += borderStyle.Render("│") + " " + line + repeat(" ", padding) + " " + borderStyle.Render("│") + "\n"
+		body += borderStyle.Render(b.Vertical) + " " + line + repeat(" ", padding) + " " + borderStyle.Render(b.Vertical) + "\n"
 	}
 
 	// Build bottom border
-	bottomBorder := lipgloss.NewStyle().Foreground(borderColor).Render("╰" + repeat("─", width-2) + "╯")
+	bottomBorder := lipgloss.NewStyle().Foreground(borderColor).Render(b.BottomLeft + repeat(b.Horizontal, width-2) + b.BottomRight)
 
 	return topBorder + "\n" + body + bottomBorder
 }
@@ -310,10 +330,10 @@ func ProgressBarBracketed(current, max, width int) string {
 
 	bar := ProgressBracketStyle.Render("[")
 	for i := 0; i < filled; i++ {
-		bar += ProgressFullStyle.Render("█")
+		bar += ProgressFullStyle.Render(components.Glyphs.BarFull)
 	}
 	for i := 0; i < empty; i++ {
-		bar += ProgressEmptyStyle.Render("▒")
+		bar += ProgressEmptyStyle.Render(components.Glyphs.BarEmpty)
 	}
 	bar += ProgressBracketStyle.Render("]")
 
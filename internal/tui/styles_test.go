@@ -0,0 +1,55 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"unicode"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"grind/internal/tui/components"
+)
+
+func TestTitledPanelBorders(t *testing.T) {
+	content := strings.Join([]string{
+		"short",
+		strings.Repeat("ship the feature ", 10),
+		strings.Repeat("日本語", 20),
+		strings.Repeat("🔥", 30),
+	}, "\n")
+
+	for _, width := range []int{20, 40, 61} {
+		lines := strings.Split(TitledPanel("QUESTS", content, width, ColorPrimary), "\n")
+		for i, line := range lines {
+			if w := lipgloss.Width(line); w != width {
+				t.Errorf("width %d: line %d is %d cells: %q", width, i, w, line)
+			}
+		}
+	}
+}
+
+func TestApplyGlyphsASCII(t *testing.T) {
+	components.SetASCII(true)
+	ApplyGlyphs()
+	defer func() {
+		components.SetASCII(false)
+		ApplyGlyphs()
+	}()
+
+	styles := map[string]lipgloss.Style{
+		"BoxStyle":          BoxStyle,
+		"BoxStyleMuted":     BoxStyleMuted,
+		"InsightBoxStyle":   InsightBoxStyle,
+		"InputStyle":        InputStyle,
+		"InputFocusedStyle": InputFocusedStyle,
+	}
+	for name, style := range styles {
+		out := style.Render("quest")
+		for _, r := range out {
+			if r > unicode.MaxASCII {
+				t.Errorf("%s draws %q in ASCII mode:\n%s", name, r, out)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,125 @@
+// Package xp estimates a quest's XP from keywords in its title, for when
+// the AI evaluator isn't available. The keyword lists and their weights
+// can be overridden in config.
+package xp
+
+import "strings"
+
+// Base is the XP every active quest starts from
+const Base = 20
+
+// Max caps an estimate
+const Max = 100
+
+// longTitleWords and longTitleBonus reward longer, more specific titles
+const (
+	longTitleWords = 5
+	longTitleBonus = 10
+)
+
+// Tier is a keyword list and the XP a match adds. The first matching
+// keyword counts; further matches in the same tier add nothing.
+type Tier struct {
+	Keywords []string `json:"keywords"`
+	XP       *int     `json:"xp,omitempty"`
+}
+
+// Rules holds the keyword lists the estimator uses. Passive titles are
+// worth 0 XP; the others add their tier's XP to Base. A nil list or
+// weight keeps the default, while an empty list turns that tier off, so
+// "passive": [] gives credit for everything.
+type Rules struct {
+	Passive []string `json:"passive"`
+	High    *Tier    `json:"high,omitempty"`
+	Medium  *Tier    `json:"medium,omitempty"`
+	Low     *Tier    `json:"low,omitempty"`
+}
+
+// DefaultRules returns the built-in keyword lists. This is a GRIND app -
+// we reward ACTIVE effort, not passive activities.
+func DefaultRules() Rules {
+	return Rules{
+		Passive: []string{"sleep", "rest", "nap", "relax", "chill", "watch", "scroll"},
+		High:    tier(40, "ship", "deploy", "launch", "build", "implement", "create", "refactor", "marathon", "10km", "20km"),
+		Medium:  tier(25, "gym", "workout", "run", "fix", "deep work", "study", "learn", "practice", "write", "design", "code"),
+		Low:     tier(10, "read", "review", "call", "meeting", "email", "update", "check"),
+	}
+}
+
+func tier(xp int, keywords ...string) *Tier {
+	return &Tier{Keywords: keywords, XP: &xp}
+}
+
+// withDefaults fills anything r leaves unset from DefaultRules
+func (r *Rules) withDefaults() Rules {
+	def := DefaultRules()
+	if r == nil {
+		return def
+	}
+	out := Rules{
+		Passive: r.Passive,
+		High:    mergeTier(r.High, def.High),
+		Medium:  mergeTier(r.Medium, def.Medium),
+		Low:     mergeTier(r.Low, def.Low),
+	}
+	if out.Passive == nil {
+		out.Passive = def.Passive
+	}
+	return out
+}
+
+func mergeTier(t, def *Tier) *Tier {
+	if t == nil {
+		return def
+	}
+	out := *t
+	if out.Keywords == nil {
+		out.Keywords = def.Keywords
+	}
+	if out.XP == nil {
+		out.XP = def.XP
+	}
+	return &out
+}
+
+// Estimate gives a rough XP value for title from its keywords and length,
+// using rules (nil for the defaults)
+func Estimate(title string, rules *Rules) int {
+	r := rules.withDefaults()
+	lower := strings.ToLower(title)
+
+	// Passive activities get 0 XP - not a grind task
+	if matches(lower, r.Passive) {
+		return 0
+	}
+
+	xp := Base
+	for _, t := range []*Tier{r.High, r.Medium, r.Low} {
+		if matches(lower, t.Keywords) {
+			xp += *t.XP
+		}
+	}
+
+	if len(strings.Fields(title)) > longTitleWords {
+		xp += longTitleBonus
+	}
+
+	// Clamp
+	if xp > Max {
+		xp = Max
+	}
+	if xp < 0 {
+		xp = 0
+	}
+	return xp
+}
+
+// matches reports whether lower contains any of keywords
+func matches(lower string, keywords []string) bool {
+	for _, kw := range keywords {
+		if kw != "" && strings.Contains(lower, strings.ToLower(kw)) {
+			return true
+		}
+	}
+	return false
+}